@@ -0,0 +1,3 @@
+module github.com/Mukam21/go-worker-pool
+
+go 1.22
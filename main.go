@@ -3,125 +3,36 @@ package main
 import (
 	"context"
 	"fmt"
-	"sync"
+	"log/slog"
+	"os"
 	"time"
-)
-
-// Worker представляет собой структуру с ID и функцией отмены context.
-// Context используется для управления завершением работы горутины.
-type Worker struct {
-	ID     int
-	Cancel context.CancelFunc
-}
-
-// Pool реализует структуру worker-pool.
-// Включает мьютекс для синхронизации, список воркеров, канал заданий и счётчик активных горутин.
-type Pool struct {
-	mu      sync.Mutex
-	workers map[int]Worker
-	jobs    chan string
-	nextID  int
-	wg      sync.WaitGroup
-}
-
-// NewPool создаёт новый пул с буфером для заданий
-func NewPool(bufferSize int) *Pool {
-	return &Pool{
-		workers: make(map[int]Worker),
-		jobs:    make(chan string, bufferSize),
-	}
-}
-
-// AddWorker запускает нового воркера в виде горутины.
-// Каждому воркеру присваивается уникальный ID и создаётся свой context.
-func (p *Pool) AddWorker() int {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	ctx, cancel := context.WithCancel(context.Background())
-	id := p.nextID
-	p.nextID++
-
-	worker := Worker{
-		ID:     id,
-		Cancel: cancel,
-	}
-	p.workers[id] = worker
-	p.wg.Add(1)
-
-	// Запускаем горутину — сам воркер
-	go func(id int, ctx context.Context) {
-		defer func() {
-			// При завершении удаляем воркера из пула и помечаем завершение wg
-			p.mu.Lock()
-			delete(p.workers, id)
-			p.mu.Unlock()
-			p.wg.Done()
-			fmt.Printf("Worker %d stopped\n", id)
-		}()
-
-		fmt.Printf("Worker %d started\n", id)
-		for {
-			select {
-			case <-ctx.Done():
-				// Контекст отменён — завершение воркера
-				return
-			case job, ok := <-p.jobs:
-				if !ok {
-					// Канал закрыт — завершение воркера
-					return
-				}
-				// Обработка задания
-				fmt.Printf("Worker %d processing job: %s\n", id, job)
-				time.Sleep(500 * time.Millisecond) // имитация обработки
-			}
-		}
-	}(id, ctx)
 
-	return id
-}
-
-// RemoveWorker отключает конкретного воркера по ID.
-// Контекст воркера будет отменён, и тот завершит выполнение.
-func (p *Pool) RemoveWorker(id int) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	if worker, exists := p.workers[id]; exists {
-		worker.Cancel() // воркер удалится сам при завершении горутины
-	}
-}
-
-// SendJob помещает задание в очередь.
-// Если очередь заполнена, возвращается ошибка.
-func (p *Pool) SendJob(job string) error {
-	select {
-	case p.jobs <- job:
-		return nil
-	default:
-		return fmt.Errorf("job queue is full")
-	}
-}
-
-// Shutdown завершает работу всех воркеров и очищает ресурсы.
-// Важен порядок: сначала закрываем канал, затем отменяем контексты, потом ждём завершения всех горутин.
-func (p *Pool) Shutdown() {
-	// Сигнализируем воркерам, что больше не будет заданий
-	close(p.jobs)
+	"github.com/Mukam21/go-worker-pool/workerpool"
+)
 
-	// Отменяем контексты всех активных воркеров
-	p.mu.Lock()
-	for _, worker := range p.workers {
-		worker.Cancel()
+// processJob имитирует обработку задания и возвращает отчёт о нём.
+// Пауза идёт по часам пула, поэтому с WithClock пример проверяется без настоящих ожиданий.
+func processJob(ctx context.Context, job string) (string, error) {
+	if err := workerpool.Sleep(ctx, 500*time.Millisecond); err != nil { // имитация обработки
+		return "", err
 	}
-	p.mu.Unlock()
-
-	// Ждём завершения всех воркеров
-	p.wg.Wait()
+	return job + " done", nil
 }
 
 func main() {
-	pool := NewPool(10) // создаём пул с буфером на 10 заданий
+	// Выводим события пула, включая обработку каждого задания
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	pool := workerpool.NewPool[string, string](
+		workerpool.WithHandler(processJob),
+		workerpool.WithBufferSize(10), // буфер на 10 заданий
+		workerpool.WithLogger(logger),
+	)
+	pool.OnResult(func(res workerpool.Result[string, string]) {
+		if res.Err == nil {
+			fmt.Println("Result:", res.Value)
+		}
+	})
 
 	// Добавляем двух воркеров
 	worker1 := pool.AddWorker()
@@ -146,6 +57,15 @@ func main() {
 		}
 	}
 
+	// Отправляем задание и дожидаемся его результата
+	if future, err := pool.Submit("Task 8"); err != nil {
+		fmt.Println("Failed to submit job:", err)
+	} else if err := future.Err(); err != nil {
+		fmt.Println("Task 8 failed:", err)
+	} else {
+		fmt.Println("Task 8 finished:", future.Result())
+	}
+
 	time.Sleep(2 * time.Second)
 
 	// Удаляем одного воркера
@@ -155,6 +75,10 @@ func main() {
 
 	// Завершаем пул
 	fmt.Println("Shutting down pool...")
-	pool.Shutdown()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := pool.Shutdown(ctx); err != nil {
+		fmt.Println("Shutdown error:", err)
+	}
 	fmt.Println("Pool shutdown complete.")
 }
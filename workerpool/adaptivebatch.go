@@ -0,0 +1,108 @@
+package workerpool
+
+import (
+	"sync"
+	"time"
+)
+
+// WithAdaptiveBatching включает для WithBatchHandler подбор размера пакета под целевую
+// задержку target — от постановки первого задания пакета в очередь до завершения пакета.
+// Пул оценивает время обработки одного задания по прошлым пакетам и собирает пакет,
+// пока оставшегося времени хватает на обработку ещё одного задания: при слабой нагрузке
+// пакеты выходят маленькими, при сильной — растут, пока пакет успевает обработаться
+// за target, но не больше maxSize из WithBatchHandler. Задания, которые уже не успевают
+// в срок, не ждут новых и уходят пакетом с остальными ждущими.
+// Ненулевой maxWait из WithBatchHandler по-прежнему ограничивает ожидание сверху.
+func WithAdaptiveBatching(target time.Duration) Option {
+	return func(c *config) {
+		if target <= 0 {
+			panic("workerpool: WithAdaptiveBatching requires a positive target latency")
+		}
+		c.batchTarget = target
+	}
+}
+
+// adaptiveBatch — оценка времени обработки для WithAdaptiveBatching.
+type adaptiveBatch struct {
+	target time.Duration
+
+	mu     sync.Mutex
+	perJob time.Duration // сглаженное время обработки одного задания пакета (0 — ещё не известно)
+}
+
+// budget возвращает, сколько ещё можно собирать пакет из n заданий, первое из которых
+// к моменту now ждёт с момента enqueued, чтобы пакет из n+1 задания успел обработаться в срок.
+func (a *adaptiveBatch) budget(now, enqueued time.Time, n int) time.Duration {
+	a.mu.Lock()
+	perJob := a.perJob
+	a.mu.Unlock()
+
+	return a.target - now.Sub(enqueued) - time.Duration(n+1)*perJob
+}
+
+// limit возвращает наибольший размер пакета, который успевает обработаться за target.
+func (a *adaptiveBatch) limit(maxSize int) int {
+	a.mu.Lock()
+	perJob := a.perJob
+	a.mu.Unlock()
+
+	if perJob <= 0 {
+		return maxSize
+	}
+	return max(1, min(maxSize, int(a.target/perJob)))
+}
+
+// observe учитывает время обработки пакета из n заданий.
+func (a *adaptiveBatch) observe(n int, latency time.Duration) {
+	sample := latency / time.Duration(n)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.perJob == 0 {
+		a.perJob = sample
+		return
+	}
+	// Экспоненциальное сглаживание: последний пакет весит 1/5
+	a.perJob += (sample - a.perJob) / 5
+}
+
+// batchWait возвращает, сколько ещё ждать заданий для пакета из n заданий, начатого с first.
+func (p *Pool[T, R]) batchWait(first *task[T, R], n int) time.Duration {
+	a := p.batch.adaptive
+	if a == nil {
+		return p.batch.maxWait
+	}
+	wait := a.budget(p.clock.Now(), first.enqueued, n)
+	if p.batch.maxWait > 0 && wait > p.batch.maxWait {
+		wait = p.batch.maxWait
+	}
+	return wait
+}
+
+// batchLimit возвращает наибольший размер собираемого пакета.
+func (p *Pool[T, R]) batchLimit() int {
+	if a := p.batch.adaptive; a != nil {
+		return a.limit(p.batch.maxSize)
+	}
+	return p.batch.maxSize
+}
+
+// takeReady добирает в пакет задания, уже ждущие в очереди, не дожидаясь новых.
+func (p *Pool[T, R]) takeReady(id int, tasks []*task[T, R], limit int) []*task[T, R] {
+	for len(tasks) < limit {
+		tokens, _ := p.tokenChans()
+		select {
+		case _, ok := <-tokens:
+			if !ok {
+				return tasks
+			}
+			if t := p.startWork(id); t != nil {
+				tasks = append(tasks, t)
+			}
+		default:
+			return tasks
+		}
+	}
+	return tasks
+}
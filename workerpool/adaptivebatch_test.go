@@ -0,0 +1,78 @@
+package workerpool_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestAdaptiveBatching(t *testing.T) {
+	const perJob, target = 10 * time.Millisecond, 100 * time.Millisecond
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	var (
+		mu    sync.Mutex
+		sizes []int
+	)
+	// Пакет обрабатывается perJob на задание по часам пула
+	pool := workerpool.NewPool[int, int](
+		workerpool.WithBatchHandler(func(ctx context.Context, jobs []int) ([]int, error) {
+			mu.Lock()
+			sizes = append(sizes, len(jobs))
+			mu.Unlock()
+			if err := workerpool.Sleep(ctx, time.Duration(len(jobs))*perJob); err != nil {
+				return nil, err
+			}
+			return jobs, nil
+		}, 50, 0),
+		workerpool.WithAdaptiveBatching(target),
+		workerpool.WithClock(clock),
+		workerpool.WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	// send ставит задания на паузе, чтобы воркер увидел их все разом, и ждёт их обработки
+	send := func(n int) []int {
+		t.Helper()
+		mu.Lock()
+		sizes = nil
+		mu.Unlock()
+		if err := pool.Pause(); err != nil {
+			t.Fatalf("Pause: %v", err)
+		}
+		processed := pool.Stats().Processed
+		for i := 0; i < n; i++ {
+			if err := pool.SendJob(i); err != nil {
+				t.Fatalf("SendJob: %v", err)
+			}
+		}
+		if err := pool.Resume(); err != nil {
+			t.Fatalf("Resume: %v", err)
+		}
+		advanceUntil(t, clock, perJob, "jobs processed", func() bool {
+			return pool.Stats().Processed == processed+uint64(n)
+		})
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), sizes...)
+	}
+
+	// Пока время обработки неизвестно, пакет ограничен только maxSize
+	if got := send(5); len(got) != 1 || got[0] != 5 {
+		t.Fatalf("first batch sizes = %v, want [5]", got)
+	}
+	// Узнав, что задание обрабатывается 10 мс, пул собирает пакеты, успевающие за 100 мс
+	got := send(30)
+	if len(got) < 3 {
+		t.Fatalf("batch sizes = %v, want the backlog split into batches of at most 10", got)
+	}
+	for _, n := range got {
+		if n > int(target/perJob) {
+			t.Errorf("batch sizes = %v, want at most %d", got, target/perJob)
+			break
+		}
+	}
+}
@@ -0,0 +1,275 @@
+// Package admin предоставляет HTTP API для управления пулом workerpool на ходу:
+// список воркеров, показатели очереди, добавление и снятие воркеров, пауза и пробные задания.
+// Операторы могут подстраивать число воркеров без перевыкладки сервиса.
+//
+//	mux.Handle("/admin/pool/", http.StripPrefix("/admin/pool", admin.NewHandler(pool,
+//		admin.WithSubmit(admin.JSONSubmitter(pool)))))
+//
+// Обработчик не проверяет права доступа: монтируйте его только за авторизацией или на внутреннем порту.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+)
+
+// Pool — пул, которым управляет Handler.
+// Ему удовлетворяет *workerpool.Pool с любыми типами заданий.
+type Pool interface {
+	Workers() []workerpool.WorkerInfo
+	Stats() workerpool.Stats
+	AddWorker() int
+	RemoveWorkerGraceful(id int) error
+	RemoveWorkerNow(id int) error
+	Pause() error
+	Resume() error
+	Paused() bool
+}
+
+// SubmitFunc отправляет пробное задание из тела запроса body и возвращает его результат.
+type SubmitFunc func(ctx context.Context, body []byte) (any, error)
+
+// JSONSubmitter возвращает SubmitFunc, который разбирает тело запроса как JSON-задание T,
+// отправляет его в пул и ждёт результата, пока не отменён контекст запроса.
+func JSONSubmitter[T, R any](p *workerpool.Pool[T, R]) SubmitFunc {
+	return func(ctx context.Context, body []byte) (any, error) {
+		var job T
+		if err := json.Unmarshal(body, &job); err != nil {
+			return nil, &requestError{fmt.Errorf("decode job: %w", err)}
+		}
+		future, err := p.SubmitContext(ctx, job)
+		if err != nil {
+			return nil, err
+		}
+		select {
+		case <-future.Done():
+			return future.Result(), future.Err()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Option настраивает Handler.
+type Option func(*Handler)
+
+// WithSubmit включает POST /jobs для отправки пробных заданий через submit.
+func WithSubmit(submit SubmitFunc) Option {
+	return func(h *Handler) {
+		h.submit = submit
+	}
+}
+
+// maxBodySize ограничивает тело запроса POST /jobs.
+const maxBodySize = 1 << 20
+
+// Handler — HTTP API управления пулом:
+//
+//	GET    /workers       список воркеров
+//	POST   /workers       добавить воркеров (?count=N, по умолчанию 1)
+//	DELETE /workers/{id}  снять воркера, дав доделать задание (?now=true — прервать задание)
+//	GET    /stats         показатели пула
+//	POST   /pause         приостановить выдачу заданий
+//	POST   /resume        возобновить выдачу заданий
+//	POST   /jobs          отправить пробное задание (только с WithSubmit)
+//
+// Ответы — JSON, ошибки — {"error": "..."} с подходящим кодом статуса.
+type Handler struct {
+	pool   Pool
+	submit SubmitFunc
+	mux    *http.ServeMux
+}
+
+// NewHandler создаёт HTTP API для пула p.
+func NewHandler(p Pool, opts ...Option) *Handler {
+	h := &Handler{pool: p, mux: http.NewServeMux()}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.mux.HandleFunc("GET /workers", h.listWorkers)
+	h.mux.HandleFunc("POST /workers", h.addWorkers)
+	h.mux.HandleFunc("DELETE /workers/{id}", h.removeWorker)
+	h.mux.HandleFunc("GET /stats", h.stats)
+	h.mux.HandleFunc("POST /pause", h.pause)
+	h.mux.HandleFunc("POST /resume", h.resume)
+	h.mux.HandleFunc("POST /jobs", h.submitJob)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// worker — воркер в ответе GET /workers.
+type worker struct {
+	ID           int       `json:"id"`
+	Status       string    `json:"status"`
+	CurrentJobID uint64    `json:"current_job_id,omitempty"`
+	Started      time.Time `json:"started"`
+	Uptime       string    `json:"uptime"`
+	BusyTime     string    `json:"busy_time"`
+	Processed    uint64    `json:"processed"`
+}
+
+func (h *Handler) listWorkers(w http.ResponseWriter, r *http.Request) {
+	infos := h.pool.Workers()
+	workers := make([]worker, 0, len(infos))
+	for _, info := range infos {
+		workers = append(workers, worker{
+			ID:           info.ID,
+			Status:       info.Status.String(),
+			CurrentJobID: uint64(info.CurrentJobID),
+			Started:      info.Started,
+			Uptime:       info.Uptime.Round(time.Millisecond).String(),
+			BusyTime:     info.BusyTime.Round(time.Millisecond).String(),
+			Processed:    info.Processed,
+		})
+	}
+	writeJSON(w, http.StatusOK, workers)
+}
+
+func (h *Handler) addWorkers(w http.ResponseWriter, r *http.Request) {
+	count := 1
+	if s := r.URL.Query().Get("count"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid count %q", s))
+			return
+		}
+		count = n
+	}
+	ids := make([]int, 0, count)
+	for i := 0; i < count; i++ {
+		id := h.pool.AddWorker()
+		if id < 0 {
+			writeError(w, http.StatusConflict, errors.New("pool is shutting down"))
+			return
+		}
+		ids = append(ids, id)
+	}
+	writeJSON(w, http.StatusCreated, map[string][]int{"ids": ids})
+}
+
+func (h *Handler) removeWorker(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid worker id %q", r.PathValue("id")))
+		return
+	}
+	remove := h.pool.RemoveWorkerGraceful
+	if now, _ := strconv.ParseBool(r.URL.Query().Get("now")); now {
+		remove = h.pool.RemoveWorkerNow
+	}
+	if err := remove(id); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, workerpool.ErrWorkerNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// stats — показатели в ответе GET /stats.
+type stats struct {
+	QueueLen     int    `json:"queue_len"`
+	Workers      int    `json:"workers"`
+	BusyWorkers  int    `json:"busy_workers"`
+	IdleWorkers  int    `json:"idle_workers"`
+	Processed    uint64 `json:"processed"`
+	Failed       uint64 `json:"failed"`
+	NoOp         uint64 `json:"no_op"`
+	AvgLatency   string `json:"avg_latency"`
+	P50Latency   string `json:"p50_latency"`
+	P95Latency   string `json:"p95_latency"`
+	P99Latency   string `json:"p99_latency"`
+	AvgQueueWait string `json:"avg_queue_wait"`
+	P95QueueWait string `json:"p95_queue_wait"`
+	Paused       bool   `json:"paused"`
+}
+
+func (h *Handler) stats(w http.ResponseWriter, r *http.Request) {
+	s := h.pool.Stats()
+	writeJSON(w, http.StatusOK, stats{
+		QueueLen:     s.QueueLen,
+		Workers:      s.Workers,
+		BusyWorkers:  s.BusyWorkers,
+		IdleWorkers:  s.IdleWorkers,
+		Processed:    s.Processed,
+		Failed:       s.Failed,
+		NoOp:         s.NoOp,
+		AvgLatency:   s.AvgLatency.String(),
+		P50Latency:   s.P50Latency.String(),
+		P95Latency:   s.P95Latency.String(),
+		P99Latency:   s.P99Latency.String(),
+		AvgQueueWait: s.AvgQueueWait.String(),
+		P95QueueWait: s.P95QueueWait.String(),
+		Paused:       h.pool.Paused(),
+	})
+}
+
+func (h *Handler) pause(w http.ResponseWriter, r *http.Request) {
+	if err := h.pool.Pause(); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"paused": true})
+}
+
+func (h *Handler) resume(w http.ResponseWriter, r *http.Request) {
+	if err := h.pool.Resume(); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"paused": false})
+}
+
+func (h *Handler) submitJob(w http.ResponseWriter, r *http.Request) {
+	if h.submit == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("job submission is not enabled"))
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	result, err := h.submit(r.Context(), body)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var reqErr *requestError
+		if errors.As(err, &reqErr) {
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"result": result})
+}
+
+// requestError — ошибка в самом запросе, а не в обработке задания.
+type requestError struct {
+	err error
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+func (e *requestError) Unwrap() error { return e.err }
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
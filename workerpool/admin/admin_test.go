@@ -0,0 +1,106 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/admin"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestHandler(t *testing.T) {
+	pool := workerpool.NewPool[int, int](workerpool.WithHandler(func(ctx context.Context, job int) (int, error) {
+		return job * 2, nil
+	}))
+	defer pool.Shutdown(context.Background())
+	srv := httptest.NewServer(admin.NewHandler(pool, admin.WithSubmit(admin.JSONSubmitter(pool))))
+	defer srv.Close()
+
+	// do выполняет запрос и разбирает JSON-ответ в out (nil — тело не нужно)
+	do := func(method, path, body string, wantStatus int, out any) {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != wantStatus {
+			t.Fatalf("%s %s = %d, want %d", method, path, resp.StatusCode, wantStatus)
+		}
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				t.Fatalf("%s %s: decode response: %v", method, path, err)
+			}
+		}
+	}
+
+	var added struct{ IDs []int }
+	do("POST", "/workers?count=2", "", http.StatusCreated, &added)
+	if len(added.IDs) != 2 {
+		t.Fatalf("added workers = %v, want 2 ids", added.IDs)
+	}
+	do("POST", "/workers?count=0", "", http.StatusBadRequest, nil)
+
+	var workers []struct {
+		ID     int
+		Status string
+	}
+	do("GET", "/workers", "", http.StatusOK, &workers)
+	if len(workers) != 2 {
+		t.Fatalf("GET /workers listed %d workers, want 2", len(workers))
+	}
+
+	var result struct{ Result int }
+	do("POST", "/jobs", "21", http.StatusOK, &result)
+	if result.Result != 42 {
+		t.Errorf("POST /jobs result = %d, want 42", result.Result)
+	}
+	do("POST", "/jobs", "not json", http.StatusBadRequest, nil)
+
+	do("POST", "/pause", "", http.StatusOK, nil)
+	do("POST", "/pause", "", http.StatusConflict, nil)
+	var stats struct {
+		Workers   int
+		Processed uint64
+		Paused    bool
+	}
+	do("GET", "/stats", "", http.StatusOK, &stats)
+	if stats.Workers != 2 || stats.Processed != 1 || !stats.Paused {
+		t.Errorf("GET /stats = %+v, want 2 workers, 1 processed, paused", stats)
+	}
+	do("POST", "/resume", "", http.StatusOK, nil)
+
+	do("DELETE", "/workers/"+strconv.Itoa(added.IDs[0]), "", http.StatusNoContent, nil)
+	do("DELETE", "/workers/"+strconv.Itoa(added.IDs[1])+"?now=true", "", http.StatusNoContent, nil)
+	do("DELETE", "/workers/"+strconv.Itoa(added.IDs[1]), "", http.StatusNotFound, nil)
+	do("DELETE", "/workers/abc", "", http.StatusBadRequest, nil)
+	deadline := time.Now().Add(2 * time.Second)
+	for pool.Stats().Workers != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Workers = %d after removing both", pool.Stats().Workers)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestHandlerWithoutSubmit(t *testing.T) {
+	pool := workerpool.NewPool[int, int](workerpool.WithHandler(workerpooltest.Echo[int]))
+	defer pool.Shutdown(context.Background())
+
+	rec := httptest.NewRecorder()
+	admin.NewHandler(pool).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader("1")))
+	if rec.Code != http.StatusNotImplemented {
+		t.Errorf("POST /jobs without WithSubmit = %d, want 501", rec.Code)
+	}
+}
@@ -0,0 +1,198 @@
+package workerpool
+
+// SubmitToWorker помещает задание в личную очередь воркера workerID: его выполнит только
+// этот воркер (с WithWorkStealing — преимущественно этот). Нужно для заданий, зависящих от локального состояния воркера — прогретого
+// кэша, привязки к GPU, постоянного соединения из WithWorkerInit.
+//
+// Если воркера нет или он уже снимается с работы, возвращается ErrWorkerNotFound.
+// Задания, оставшиеся в личной очереди завершившегося воркера, переходят в общую очередь
+// и выполняются любым воркером. Личные очереди занимают места в общем буфере заданий.
+func (p *Pool[T, R]) SubmitToWorker(workerID int, job T) (*Future[R], error) {
+	t := &task[T, R]{job: job, cost: 1, pinned: true, worker: workerID, future: newFuture[R]()}
+	if err := p.enqueue(t); err != nil {
+		return nil, err
+	}
+	return t.future, nil
+}
+
+// WithWorkStealing разрешает простаивающим воркерам забирать задания из личных очередей
+// (SubmitToWorker) занятых воркеров. Это держит загрузку высокой при перекошенной нагрузке,
+// когда на один воркер приходится больше заданий, чем он успевает выполнить.
+// Владелец берёт задания из начала своей очереди, а воркер-похититель — с конца,
+// поэтому ближайшие задания почти всегда выполняет владелец и его локальное состояние
+// по-прежнему используется. SubmitToWorker при этом становится предпочтением, а не гарантией:
+// не включайте опцию, если задание может выполнить только конкретный воркер.
+func WithWorkStealing() Option {
+	return func(c *config) {
+		c.workStealing = true
+	}
+}
+
+// checkPinnedLocked проверяет, что воркер, которому адресовано задание, ещё работает.
+// Вызывается под p.mu.
+func (p *Pool[T, R]) checkPinnedLocked(t *task[T, R]) error {
+	if !t.pinned {
+		return nil
+	}
+	if worker, exists := p.workers[t.worker]; !exists || worker.removed {
+		return ErrWorkerNotFound
+	}
+	return nil
+}
+
+// pinLocked ставит задание в личную очередь его воркера и будит воркера.
+// Вызывается под p.mu.
+func (p *Pool[T, R]) pinLocked(t *task[T, R]) {
+	if p.affinity == nil {
+		p.affinity = make(map[int][]*task[T, R])
+	}
+	p.affinity[t.worker] = append(p.affinity[t.worker], t)
+	p.pinnedCount++
+	p.wakePinnedLocked(t.worker)
+	if p.workStealing && p.workers[t.worker].working {
+		// Владелец занят — задание может забрать простаивающий воркер
+		p.wakeIdleLocked()
+	}
+}
+
+// wakeIdleLocked будит одного простаивающего воркера, чтобы тот попробовал украсть задание.
+// Вызывается под p.mu.
+func (p *Pool[T, R]) wakeIdleLocked() {
+	for id, worker := range p.workers {
+		if !worker.working && !worker.removed {
+			p.wakePinnedLocked(id)
+			return
+		}
+	}
+}
+
+// wakePinnedLocked сигналит воркеру id о заданиях в его личной очереди.
+// Сигнал не накапливается: воркер будит себя сам, пока очередь не опустеет.
+func (p *Pool[T, R]) wakePinnedLocked(id int) {
+	if worker, exists := p.workers[id]; exists {
+		select {
+		case worker.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// startPinned извлекает задание из личной очереди воркера id и отмечает его занятым.
+// Возвращает nil, если очередь пуста или пул на паузе.
+func (p *Pool[T, R]) startPinned(id int) *task[T, R] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// На паузе задания остаются в личной очереди, Resume разбудит воркера снова
+	if p.paused {
+		return nil
+	}
+	pinned := p.affinity[id]
+	if len(pinned) == 0 {
+		if p.workStealing {
+			return p.stealLocked(id)
+		}
+		return nil
+	}
+	t := pinned[0]
+	pinned[0] = nil
+	if len(pinned) == 1 {
+		delete(p.affinity, id)
+	} else {
+		p.affinity[id] = pinned[1:]
+		p.wakePinnedLocked(id)
+	}
+	p.pinnedCount--
+	p.closeTokensIfDrainedLocked()
+	p.signalSpaceLocked()
+	p.markWorkingLocked(id, t)
+	if p.workStealing && len(p.affinity[id]) > 0 {
+		// Остаток очереди может разобрать простаивающий воркер
+		p.wakeIdleLocked()
+	}
+	return t
+}
+
+// stealLocked забирает для воркера id последнее задание из самой длинной личной очереди
+// занятого воркера. Возвращает nil, если красть нечего. Вызывается под p.mu.
+func (p *Pool[T, R]) stealLocked(id int) *task[T, R] {
+	victim, longest := 0, 0
+	for owner, pinned := range p.affinity {
+		if len(pinned) > longest && p.workers[owner].working {
+			victim, longest = owner, len(pinned)
+		}
+	}
+	if longest == 0 {
+		return nil
+	}
+	pinned := p.affinity[victim]
+	t := pinned[longest-1]
+	pinned[longest-1] = nil
+	if longest == 1 {
+		delete(p.affinity, victim)
+	} else {
+		p.affinity[victim] = pinned[:longest-1]
+	}
+	t.pinned = false
+	p.pinnedCount--
+	p.closeTokensIfDrainedLocked()
+	p.signalSpaceLocked()
+	p.markWorkingLocked(id, t)
+	p.logger.Debug("stole pinned job", "worker", id, "from", victim, "id", t.id)
+	if longest > 1 {
+		p.wakeIdleLocked()
+	}
+	return t
+}
+
+// unpinLocked переносит задания из личной очереди завершившегося воркера id в общую.
+// Вызывается под p.mu.
+func (p *Pool[T, R]) unpinLocked(id int) {
+	pinned := p.affinity[id]
+	if len(pinned) == 0 {
+		return
+	}
+	delete(p.affinity, id)
+	now := p.clock.Now()
+	for _, t := range pinned {
+		t.pinned = false
+		p.queue.push(t, now)
+		p.tokens <- struct{}{}
+	}
+	p.pinnedCount -= len(pinned)
+	p.closeTokensIfDrainedLocked()
+	p.logger.Info("pinned jobs moved to shared queue", "worker", id, "jobs", len(pinned))
+}
+
+// removePinnedLocked убирает задание из личной очереди воркера.
+// Возвращает false, если задания там нет. Вызывается под p.mu.
+func (p *Pool[T, R]) removePinnedLocked(t *task[T, R]) bool {
+	if !t.pinned {
+		return false
+	}
+	pinned := p.affinity[t.worker]
+	for i, queued := range pinned {
+		if queued == t {
+			if len(pinned) == 1 {
+				delete(p.affinity, t.worker)
+			} else {
+				p.affinity[t.worker] = append(pinned[:i], pinned[i+1:]...)
+			}
+			p.pinnedCount--
+			p.closeTokensIfDrainedLocked()
+			return true
+		}
+	}
+	return false
+}
+
+// dropPinnedLocked извлекает задания из всех личных очередей. Вызывается под p.mu.
+func (p *Pool[T, R]) dropPinnedLocked() []*task[T, R] {
+	var tasks []*task[T, R]
+	for _, pinned := range p.affinity {
+		tasks = append(tasks, pinned...)
+	}
+	p.affinity = nil
+	p.pinnedCount = 0
+	return tasks
+}
@@ -0,0 +1,111 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// newAffinityPool создаёт пул, обработчик которого возвращает ID выполнившего задание
+// воркера, а задания с block > 0 ждут release перед этим.
+func newAffinityPool(t *testing.T, release <-chan struct{}, opts ...Option) *Pool[int, int] {
+	t.Helper()
+	opts = append([]Option{
+		WithHandler(func(ctx context.Context, block int) (int, error) {
+			if block > 0 {
+				select {
+				case <-release:
+				case <-ctx.Done():
+					return 0, ctx.Err()
+				}
+			}
+			return WorkerState(ctx).(int), nil
+		}),
+		WithWorkerInit(func(workerID int) (any, error) { return workerID, nil }),
+	}, opts...)
+	return NewPool[int, int](opts...)
+}
+
+func TestSubmitToWorker(t *testing.T) {
+	release := make(chan struct{})
+	pool := newAffinityPool(t, release)
+	defer pool.Shutdown(context.Background())
+	ids := []int{pool.AddWorker(), pool.AddWorker(), pool.AddWorker()}
+
+	// Задание выполняет только тот воркер, которому оно адресовано
+	for i := 0; i < 3; i++ {
+		for _, id := range ids {
+			future, err := pool.SubmitToWorker(id, 0)
+			if err != nil {
+				t.Fatalf("SubmitToWorker(%d): %v", id, err)
+			}
+			if got, err := await(t, future); err != nil || got != id {
+				t.Fatalf("job for worker %d ran on %d, %v", id, got, err)
+			}
+		}
+	}
+	if _, err := pool.SubmitToWorker(-1, 0); !errors.Is(err, ErrWorkerNotFound) {
+		t.Errorf("SubmitToWorker to an unknown worker = %v, want ErrWorkerNotFound", err)
+	}
+
+	// Личная очередь снимаемого воркера переходит в общую
+	busy, err := pool.SubmitToWorker(ids[0], 1)
+	if err != nil {
+		t.Fatalf("SubmitToWorker: %v", err)
+	}
+	eventually(t, "pinned job started", func() bool { return pool.Stats().BusyWorkers == 1 })
+	left, err := pool.SubmitToWorker(ids[0], 0)
+	if err != nil {
+		t.Fatalf("SubmitToWorker: %v", err)
+	}
+	if err := pool.RemoveWorkerGraceful(ids[0]); err != nil {
+		t.Fatalf("RemoveWorkerGraceful: %v", err)
+	}
+	if _, err := pool.SubmitToWorker(ids[0], 0); !errors.Is(err, ErrWorkerNotFound) {
+		t.Errorf("SubmitToWorker to a retiring worker = %v, want ErrWorkerNotFound", err)
+	}
+	close(release)
+	if got, err := await(t, busy); err != nil || got != ids[0] {
+		t.Errorf("running job of the retired worker = %d, %v; want it finished on %d", got, err, ids[0])
+	}
+	if got, err := await(t, left); err != nil || got == ids[0] {
+		t.Errorf("queued job of the retired worker = %d, %v; want it run by another worker", got, err)
+	}
+}
+
+func TestWorkStealing(t *testing.T) {
+	release := make(chan struct{})
+	pool := newAffinityPool(t, release, WithWorkStealing())
+	defer pool.Shutdown(context.Background())
+	owner, thief := pool.AddWorker(), pool.AddWorker()
+
+	busy, err := pool.SubmitToWorker(owner, 1)
+	if err != nil {
+		t.Fatalf("SubmitToWorker: %v", err)
+	}
+	eventually(t, "owner busy", func() bool { return pool.Stats().BusyWorkers == 1 })
+
+	// Пока владелец занят, его личную очередь разбирает простаивающий воркер
+	for i := 0; i < 3; i++ {
+		future, err := pool.SubmitToWorker(owner, 0)
+		if err != nil {
+			t.Fatalf("SubmitToWorker: %v", err)
+		}
+		if got, err := await(t, future); err != nil || got != thief {
+			t.Errorf("pinned job ran on %d, %v; want it stolen by %d", got, err, thief)
+		}
+	}
+	close(release)
+	if got, err := await(t, busy); err != nil || got != owner {
+		t.Errorf("owner job ran on %d, %v; want %d", got, err, owner)
+	}
+
+	// Свободный владелец по-прежнему выполняет свои задания сам
+	future, err := pool.SubmitToWorker(owner, 0)
+	if err != nil {
+		t.Fatalf("SubmitToWorker: %v", err)
+	}
+	if got, err := await(t, future); err != nil || got != owner {
+		t.Errorf("job for an idle owner ran on %d, %v; want %d", got, err, owner)
+	}
+}
@@ -0,0 +1,27 @@
+package workerpool
+
+import "time"
+
+// AgingFunc возвращает эффективный приоритет задания с приоритетом priority, которое ждёт
+// в очереди waited с момента приёма. Задания с большим эффективным приоритетом выполняются раньше.
+// Функция не должна убывать по waited, иначе ждущие задания могут голодать.
+type AgingFunc func(priority int, waited time.Duration) float64
+
+// WithAgingInterval задаёт линейное старение приоритетов: эффективный приоритет ждущего
+// задания растёт на 1 за каждый interval ожидания (по умолчанию за секунду).
+// interval меньше или равный 0 выключает старение: порядок задают только приоритеты.
+func WithAgingInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.agingInterval = max(0, interval)
+		c.agingFunc = nil
+	}
+}
+
+// WithAgingFunc задаёт произвольное старение приоритетов, например ступенчатое
+// или с ограничением роста. В отличие от линейного WithAgingInterval, порядок очереди
+// пересчитывается по aging не чаще раза в 100 мс, и каждый пересчёт обходит всю очередь.
+func WithAgingFunc(aging AgingFunc) Option {
+	return func(c *config) {
+		c.agingFunc = aging
+	}
+}
@@ -0,0 +1,76 @@
+package workerpool_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestAging(t *testing.T) {
+	// order ставит фоновое задание, через 10 минут срочное и возвращает порядок их выполнения
+	order := func(opts ...workerpool.Option) []string {
+		clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+		var (
+			mu  sync.Mutex
+			ran []string
+		)
+		pool := workerpool.NewPool[string, string](append([]workerpool.Option{
+			workerpool.WithHandler(func(ctx context.Context, job string) (string, error) {
+				mu.Lock()
+				ran = append(ran, job)
+				mu.Unlock()
+				return job, nil
+			}),
+			workerpool.WithClock(clock),
+		}, opts...)...)
+		defer pool.Shutdown(context.Background())
+
+		if err := pool.SendJobWithPriority("background", 0); err != nil {
+			t.Fatalf("SendJobWithPriority: %v", err)
+		}
+		clock.Advance(10 * time.Minute)
+		if err := pool.SendJobWithPriority("urgent", 5); err != nil {
+			t.Fatalf("SendJobWithPriority: %v", err)
+		}
+		// Единственный воркер разбирает накопившуюся очередь по эффективным приоритетам
+		pool.AddWorker()
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := pool.Wait(ctx); err != nil {
+			t.Fatalf("Wait: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return ran
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []workerpool.Option
+		want []string
+	}{
+		// По умолчанию приоритет растёт на 1 в секунду, и 10 минут ожидания перевешивают 5
+		{"default", nil, []string{"background", "urgent"}},
+		{"slow", []workerpool.Option{workerpool.WithAgingInterval(time.Minute)}, []string{"background", "urgent"}},
+		{"slower", []workerpool.Option{workerpool.WithAgingInterval(5 * time.Minute)}, []string{"urgent", "background"}},
+		{"disabled", []workerpool.Option{workerpool.WithAgingInterval(0)}, []string{"urgent", "background"}},
+		// Ограниченный рост не даёт фоновому заданию обогнать срочное, сколько бы оно ни ждало
+		{"capped", []workerpool.Option{workerpool.WithAgingFunc(func(priority int, waited time.Duration) float64 {
+			return float64(priority) + min(waited.Hours(), 3)
+		})}, []string{"urgent", "background"}},
+		{"func", []workerpool.Option{workerpool.WithAgingFunc(func(priority int, waited time.Duration) float64 {
+			return float64(priority) + waited.Minutes()
+		})}, []string{"background", "urgent"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := order(tc.opts...); !slices.Equal(got, tc.want) {
+				t.Errorf("order = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package workerpool
+
+import (
+	"time"
+)
+
+// defaultAutoscaleInterval — период проверки автомасштабирования, если Interval не задан.
+const defaultAutoscaleInterval = 500 * time.Millisecond
+
+// AutoscaleConfig задаёт правила автоматического изменения числа воркеров.
+type AutoscaleConfig struct {
+	MinWorkers int // воркеров не меньше этого числа
+	MaxWorkers int // воркеров не больше этого числа
+
+	// ScaleUpQueueLen — длина очереди, при которой добавляется воркер (по умолчанию 1)
+	ScaleUpQueueLen int
+
+	// PriorityWeight — вес ждущего задания по его приоритету (nil — все задания весят 1).
+	// С ним длиной очереди считается сумма весов, и за одну проверку добавляется по воркеру
+	// на каждые ScaleUpQueueLen единиц веса: несколько срочных заданий сразу поднимают
+	// число воркеров, а длинная очередь фоновых растит его осторожно. Например:
+	//
+	//	PriorityWeight: func(priority int) float64 { return math.Pow(2, float64(priority)) }
+	PriorityWeight func(priority int) float64
+
+	// TargetP95 — цель по 95-му процентилю времени задания от постановки в очередь до завершения
+	// (0 — масштабировать по длине очереди). См. AutoscaleForLatency
+	TargetP95 time.Duration
+
+	// IdleTimeout — сколько воркер сверх MinWorkers может простаивать, прежде чем его снимут (0 — не снимать)
+	IdleTimeout time.Duration
+
+	// Interval — период проверки (по умолчанию 500 мс)
+	Interval time.Duration
+}
+
+// WithAutoscale включает автомасштабирование: пул сам вызывает AddWorker, когда очередь
+// растёт, и снимает простаивающих воркеров, держа их число в пределах [MinWorkers, MaxWorkers].
+func WithAutoscale(cfg AutoscaleConfig) Option {
+	return func(c *config) {
+		if cfg.ScaleUpQueueLen <= 0 {
+			cfg.ScaleUpQueueLen = 1
+		}
+		if cfg.Interval <= 0 {
+			cfg.Interval = defaultAutoscaleInterval
+		}
+		if cfg.MaxWorkers < cfg.MinWorkers {
+			cfg.MaxWorkers = cfg.MinWorkers
+		}
+		c.autoscale = &cfg
+	}
+}
+
+// AutoscaleForLatency включает автомасштабирование под цель по задержке: пока 95-й процентиль
+// времени задания (ожидание в очереди плюс обработка, по последним замерам Stats) выше targetP95
+// и в очереди есть задания, за каждую проверку добавляется воркер, а когда процентиль ниже
+// половины цели, снимается простаивающий воркер. Число воркеров держится в пределах [min, max].
+// Прочие параметры — как у WithAutoscale с настройками по умолчанию.
+func AutoscaleForLatency(targetP95 time.Duration, min, max int) Option {
+	if targetP95 <= 0 {
+		panic("workerpool: AutoscaleForLatency requires a positive target")
+	}
+	return WithAutoscale(AutoscaleConfig{MinWorkers: min, MaxWorkers: max, TargetP95: targetP95})
+}
+
+// runAutoscaler периодически подстраивает число воркеров до остановки пула.
+func (p *Pool[T, R]) runAutoscaler() {
+	timer := p.clock.NewTimer(p.autoscale.Interval)
+	defer timer.Stop()
+
+	p.scaleOnce()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-timer.C():
+			p.scaleOnce()
+			timer.Reset(p.autoscale.Interval)
+		}
+	}
+}
+
+// scaleOnce выполняет один шаг автомасштабирования.
+func (p *Pool[T, R]) scaleOnce() {
+	cfg := p.autoscale
+	var p95 time.Duration
+	if cfg.TargetP95 > 0 {
+		p95 = p.metrics.p95EndToEnd()
+	}
+
+	p.mu.Lock()
+	live := p.liveWorkersLocked()
+	queued := p.queue.len()
+	backlog := p.weighBacklogLocked()
+	paused := p.paused
+	now := p.clock.Now()
+
+	// Ищем воркера, дольше всех простаивающего сверх IdleTimeout
+	idleID := -1
+	var idleSince time.Time
+	if cfg.IdleTimeout > 0 && live > cfg.MinWorkers && queued == 0 {
+		for id, worker := range p.workers {
+			if worker.removed || worker.working || now.Sub(worker.lastActive) < cfg.IdleTimeout {
+				continue
+			}
+			if idleID < 0 || worker.lastActive.Before(idleSince) {
+				idleID, idleSince = id, worker.lastActive
+			}
+		}
+	}
+	if idleID < 0 && cfg.TargetP95 > 0 && p95 < cfg.TargetP95/2 && live > cfg.MinWorkers {
+		// Задержка с запасом укладывается в цель — лишний воркер не нужен
+		for id, worker := range p.workers {
+			if !worker.removed && !worker.working {
+				idleID = id
+				break
+			}
+		}
+	}
+	if idleID >= 0 {
+		p.retireLocked(idleID)
+	}
+	p.mu.Unlock()
+
+	switch {
+	case live < cfg.MinWorkers:
+		for i := live; i < cfg.MinWorkers; i++ {
+			p.AddWorker()
+		}
+	case paused || live >= cfg.MaxWorkers:
+	case cfg.TargetP95 > 0:
+		// Без очереди новый воркер задержку не сократит
+		if p95 > cfg.TargetP95 && queued > 0 {
+			p.AddWorker()
+		}
+	case cfg.PriorityWeight != nil:
+		add := min(int(backlog/float64(cfg.ScaleUpQueueLen)), cfg.MaxWorkers-live)
+		for i := 0; i < add; i++ {
+			p.AddWorker()
+		}
+	case queued >= cfg.ScaleUpQueueLen:
+		p.AddWorker()
+	}
+}
+
+// weighBacklogLocked возвращает сумму весов PriorityWeight заданий общей очереди
+// (0 — веса не заданы). Вызывается под p.mu.
+func (p *Pool[T, R]) weighBacklogLocked() float64 {
+	weight := p.autoscale.PriorityWeight
+	if weight == nil {
+		return 0
+	}
+	backlog := 0.0
+	for _, h := range p.queue.tenants {
+		for _, t := range *h {
+			backlog += weight(t.priority)
+		}
+	}
+	return backlog
+}
@@ -0,0 +1,170 @@
+package workerpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestAutoscalePriorityWeight(t *testing.T) {
+	// scaleUp ставит в очередь четыре задания с приоритетом priority и возвращает
+	// число воркеров, до которого их поднял автомасштабировщик
+	scaleUp := func(priority int) int {
+		clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+		release := make(chan struct{})
+		pool := workerpool.NewPool[int, int](
+			workerpool.WithHandler(func(ctx context.Context, job int) (int, error) {
+				<-release
+				return job, nil
+			}),
+			workerpool.WithClock(clock),
+			workerpool.WithAutoscale(workerpool.AutoscaleConfig{
+				MaxWorkers: 8,
+				Interval:   time.Second,
+				PriorityWeight: func(priority int) float64 {
+					if priority > 0 {
+						return 4
+					}
+					return 0.25
+				},
+			}),
+		)
+		defer pool.Shutdown(context.Background())
+		defer close(release)
+
+		// На паузе воркеры не добавляются, поэтому первая проверка после Resume видит всю очередь
+		if err := pool.Pause(); err != nil {
+			t.Fatalf("Pause: %v", err)
+		}
+		for i := 0; i < 4; i++ {
+			if err := pool.SendJobWithPriority(i, priority); err != nil {
+				t.Fatalf("SendJobWithPriority: %v", err)
+			}
+		}
+		if err := pool.Resume(); err != nil {
+			t.Fatalf("Resume: %v", err)
+		}
+		advanceUntil(t, clock, time.Second, "workers added", func() bool { return pool.Stats().Workers > 0 })
+		// Следующие проверки видят уже разобранную очередь и воркеров не добавляют
+		for i := 0; i < 3; i++ {
+			clock.Advance(time.Second)
+			time.Sleep(time.Millisecond)
+		}
+		return pool.Stats().Workers
+	}
+
+	urgent, background := scaleUp(10), scaleUp(0)
+	if urgent != 8 {
+		t.Errorf("high-priority backlog scaled to %d workers, want 8", urgent)
+	}
+	if background != 1 {
+		t.Errorf("low-priority backlog scaled to %d workers, want 1", background)
+	}
+}
+
+func TestAutoscaleForLatency(t *testing.T) {
+	const slow = 100 * time.Millisecond
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	pool := workerpool.NewPool[int, int](
+		// Положительные задания обрабатываются slow по часам пула, остальные — мгновенно
+		workerpool.WithHandler(func(ctx context.Context, job int) (int, error) {
+			if job > 0 {
+				if err := workerpool.Sleep(ctx, slow); err != nil {
+					return 0, err
+				}
+			}
+			return job, nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithBufferSize(2048),
+		workerpool.AutoscaleForLatency(10*time.Millisecond, 1, 4),
+	)
+	defer pool.Shutdown(context.Background())
+
+	for i := 1; i <= 100; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+
+	// Задержка выше цели поднимает число воркеров по одному за проверку до максимума
+	maxSeen := 0
+	advanceUntil(t, clock, slow, "scaled up to max", func() bool {
+		n := pool.Stats().Workers
+		maxSeen = max(maxSeen, n)
+		return n == 4
+	})
+	if maxSeen > 4 {
+		t.Errorf("workers reached %d, want at most 4", maxSeen)
+	}
+	advanceUntil(t, clock, slow, "slow jobs done", func() bool {
+		return pool.Stats().Processed == 100
+	})
+
+	// Быстрые задания вытесняют медленные замеры, и простаивающие воркеры снимаются
+	for i := 0; i < 1024; i++ {
+		if err := pool.SendJob(-i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := pool.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	advanceUntil(t, clock, 500*time.Millisecond, "idle workers retired", func() bool {
+		return pool.Stats().Workers == 1
+	})
+}
+
+func TestAutoscaleQueueDepth(t *testing.T) {
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	release := make(chan struct{})
+	pool := workerpool.NewPool[int, int](
+		workerpool.WithHandler(func(ctx context.Context, job int) (int, error) {
+			<-release
+			return job, nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithAutoscale(workerpool.AutoscaleConfig{
+			MinWorkers:  1,
+			MaxWorkers:  3,
+			IdleTimeout: 10 * time.Second,
+			Interval:    time.Second,
+		}),
+	)
+	defer pool.Shutdown(context.Background())
+
+	// Первая проверка доводит число воркеров до минимума
+	advanceUntil(t, clock, time.Second, "min workers", func() bool { return pool.Stats().Workers == 1 })
+	for i := 0; i < 10; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+
+	// Пока очередь не пуста, воркеры добавляются, но не сверх максимума
+	advanceUntil(t, clock, time.Second, "max workers", func() bool { return pool.Stats().Workers == 3 })
+	for i := 0; i < 5; i++ {
+		clock.Advance(time.Second)
+		time.Sleep(time.Millisecond)
+	}
+	if n := pool.Stats().Workers; n != 3 {
+		t.Fatalf("workers with a long queue = %d, want the max of 3", n)
+	}
+
+	// Простаивающие дольше IdleTimeout воркеры снимаются до минимума
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := pool.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	elapsed := advanceUntil(t, clock, time.Second, "idle workers retired", func() bool { return pool.Stats().Workers == 1 })
+	if elapsed < 10*time.Second {
+		t.Errorf("idle workers retired after %v, want at least the 10s idle timeout", elapsed)
+	}
+}
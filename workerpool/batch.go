@@ -0,0 +1,172 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// SendJobs ставит в очередь несколько заданий. Постановка прекращается на первой ошибке;
+// accepted — сколько заданий из начала jobs успело попасть в очередь.
+func (p *Pool[T, R]) SendJobs(jobs []T) (accepted int, err error) {
+	for _, job := range jobs {
+		if err := p.SendJob(job); err != nil {
+			return accepted, err
+		}
+		accepted++
+	}
+	return accepted, nil
+}
+
+// BatchHandler обрабатывает пакет заданий разом и возвращает результаты в том же порядке.
+// Ошибка относится ко всему пакету.
+type BatchHandler[T, R any] func(ctx context.Context, jobs []T) ([]R, error)
+
+// ErrBatchResults — пакетный обработчик вернул не столько результатов, сколько получил заданий.
+var ErrBatchResults = errors.New("batch handler returned wrong number of results")
+
+// batchConfig — настройки WithBatchHandler; handler проверяется по типу в NewPool.
+type batchConfig struct {
+	handler any
+	maxSize int
+	maxWait time.Duration
+}
+
+// WithBatchHandler включает пакетный режим: воркер собирает до maxSize заданий,
+// ожидая недостающие не дольше maxWait, и передаёт их handler одним вызовом.
+// Удобно для массовой вставки в БД и API, принимающих массивы. В этом режиме
+// WithHandler не нужен; повторы, WithRateLimit и таймауты отдельных заданий не применяются,
+// а Cancel для уже собранного пакета не прерывает его обработку.
+func WithBatchHandler[T, R any](handler BatchHandler[T, R], maxSize int, maxWait time.Duration) Option {
+	return func(c *config) {
+		if maxSize < 1 {
+			maxSize = 1
+		}
+		c.batchConfig = &batchConfig{handler: handler, maxSize: maxSize, maxWait: maxWait}
+	}
+}
+
+// batchRunner — пакетный обработчик с проверенным типом.
+type batchRunner[T, R any] struct {
+	handler BatchHandler[T, R]
+	maxSize int
+	maxWait time.Duration
+
+	// adaptive — подбор размера пакета WithAdaptiveBatching (nil — размер и ожидание постоянны)
+	adaptive *adaptiveBatch
+}
+
+func newBatchRunner[T, R any](c *batchConfig, target time.Duration) *batchRunner[T, R] {
+	handler, ok := c.handler.(BatchHandler[T, R])
+	if !ok || handler == nil {
+		panic(fmt.Sprintf("workerpool: batch handler type %T does not match pool type %T", c.handler, BatchHandler[T, R](nil)))
+	}
+	b := &batchRunner[T, R]{handler: handler, maxSize: c.maxSize, maxWait: c.maxWait}
+	if target > 0 {
+		b.adaptive = &adaptiveBatch{target: target}
+	}
+	return b
+}
+
+// collectBatch добирает к первому заданию пакета остальные, пока пакет не заполнится
+// или не истечёт maxWait (с WithAdaptiveBatching — время, оставшееся до целевой задержки).
+func (p *Pool[T, R]) collectBatch(ctx context.Context, id int, first *task[T, R]) []*task[T, R] {
+	tasks := []*task[T, R]{first}
+	timer := p.clock.NewTimer(p.batchWait(first, 1))
+	defer timer.Stop()
+
+	limit := p.batchLimit()
+	for len(tasks) < limit {
+		tokens, changed := p.tokenChans()
+		select {
+		case <-changed:
+		case _, ok := <-tokens:
+			if !ok {
+				return tasks
+			}
+			if t := p.startWork(id); t != nil {
+				tasks = append(tasks, t)
+				if p.batch.adaptive != nil {
+					// С каждым заданием пакет обрабатывается дольше, и ждать остаётся меньше
+					if !timer.Stop() {
+						select {
+						case <-timer.C():
+						default:
+						}
+					}
+					timer.Reset(p.batchWait(first, len(tasks)))
+				}
+			}
+		case <-timer.C():
+			if p.batch.adaptive != nil {
+				// Ждать больше нельзя, но уже ждущие задания уходят этим же пакетом
+				return p.takeReady(id, tasks, limit)
+			}
+			return tasks
+		case <-ctx.Done():
+			return tasks
+		}
+	}
+	return tasks
+}
+
+// runBatch вызывает пакетный обработчик и раздаёт результаты заданиям пакета.
+func (p *Pool[T, R]) runBatch(ctx context.Context, tasks []*task[T, R]) {
+	if tasks = p.dropExpired(tasks); len(tasks) == 0 {
+		return
+	}
+	if err := p.coordinatorAcquire(ctx); err != nil {
+		for _, t := range tasks {
+			p.complete(t, *new(R), err, 0, p.clock.Now(), 0)
+		}
+		return
+	}
+	defer p.coordinatorRelease()
+	jobs := make([]T, len(tasks))
+	for i, t := range tasks {
+		jobs[i] = t.job
+	}
+	p.logger.Debug("processing batch", "size", len(jobs))
+
+	start := p.clock.Now()
+	for _, t := range tasks {
+		p.traceStarted(ctx, t, start)
+		p.emit(EventStarted, t, 0, nil)
+	}
+	values, err := p.callBatch(p.withClock(ctx), jobs)
+	latency := p.clock.Now().Sub(start)
+	if a := p.batch.adaptive; a != nil {
+		a.observe(len(jobs), latency)
+	}
+	if err == nil && len(values) != len(tasks) {
+		err = fmt.Errorf("%w: got %d, want %d", ErrBatchResults, len(values), len(tasks))
+	}
+
+	for i, t := range tasks {
+		var value R
+		if err == nil {
+			value = values[i]
+		}
+		p.complete(t, value, err, 1, start, latency)
+	}
+}
+
+// callBatch вызывает пакетный обработчик, превращая панику в *PanicError.
+func (p *Pool[T, R]) callBatch(ctx context.Context, jobs []T) (values []R, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.panics.Add(1)
+			stack := debug.Stack()
+			err = &PanicError{Value: r, Stack: stack}
+			if p.panicHandler != nil {
+				p.panicHandler(jobs, r, stack)
+			} else {
+				p.logger.Error("batch handler panicked", "jobs", len(jobs), "panic", r, "stack", string(stack))
+			}
+		}
+	}()
+
+	return p.batch.handler(ctx, jobs)
+}
@@ -0,0 +1,75 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSendJobsStopsOnFirstError(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]), WithBufferSize(2))
+	defer pool.ShutdownNow()
+
+	accepted, err := pool.SendJobs([]int{1, 2, 3, 4})
+	if accepted != 2 || !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("SendJobs = %d, %v; want 2, ErrQueueFull", accepted, err)
+	}
+}
+
+func TestBatchHandler(t *testing.T) {
+	var mu sync.Mutex
+	var sizes []int
+	pool := NewPool[int, int](WithBatchHandler(func(ctx context.Context, jobs []int) ([]int, error) {
+		mu.Lock()
+		sizes = append(sizes, len(jobs))
+		mu.Unlock()
+		results := make([]int, len(jobs))
+		for i, job := range jobs {
+			results[i] = job * 10
+		}
+		return results, nil
+	}, 3, 10*time.Millisecond))
+	defer pool.Shutdown(context.Background())
+
+	// Задания ставятся в очередь до запуска воркера, чтобы пакеты собрались полностью
+	var futures []*Future[int]
+	for i := 0; i < 5; i++ {
+		future, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		futures = append(futures, future)
+	}
+	pool.AddWorker()
+
+	for i, future := range futures {
+		if got, err := await(t, future); err != nil || got != i*10 {
+			t.Errorf("job %d result = %d, %v; want %d, nil", i, got, err, i*10)
+		}
+	}
+	// Последний пакет неполный: воркер не дождался третьего задания за maxWait
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.Equal(sizes, []int{3, 2}) {
+		t.Errorf("batch sizes = %v, want [3 2]", sizes)
+	}
+}
+
+func TestBatchHandlerWrongResultCount(t *testing.T) {
+	pool := NewPool[int, int](WithBatchHandler(func(ctx context.Context, jobs []int) ([]int, error) {
+		return jobs[1:], nil
+	}, 2, time.Millisecond))
+	defer pool.Shutdown(context.Background())
+
+	first, _ := pool.Submit(1)
+	second, _ := pool.Submit(2)
+	pool.AddWorker()
+	for _, future := range []*Future[int]{first, second} {
+		if _, err := await(t, future); !errors.Is(err, ErrBatchResults) {
+			t.Errorf("job %d error = %v, want ErrBatchResults", future.ID(), err)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// CircuitState — состояние автомата защиты WithCircuitBreaker.
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // задания выдаются воркерам как обычно
+	CircuitOpen                         // выдача заданий остановлена до конца Cooldown
+	CircuitHalfOpen                     // выполняются пробные задания
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitBreakerConfig задаёт пороги WithCircuitBreaker.
+type CircuitBreakerConfig struct {
+	// FailureThreshold — число ошибок подряд, после которого цепь размыкается (по умолчанию 5)
+	FailureThreshold int
+
+	// Cooldown — сколько цепь остаётся разомкнутой перед пробой (по умолчанию 30 с)
+	Cooldown time.Duration
+
+	// HalfOpenProbes — число пробных заданий в полуоткрытом состоянии (по умолчанию 1).
+	// Успех всех проб замыкает цепь, ошибка любой снова размыкает её.
+	HalfOpenProbes int
+
+	// OnStateChange вызывается при каждой смене состояния (nil — не вызывается).
+	OnStateChange func(from, to CircuitState)
+}
+
+// WithCircuitBreaker включает автомат защиты вокруг обработчика: после FailureThreshold
+// ошибок подряд пул перестаёт выдавать задания воркерам на время Cooldown, затем пропускает
+// HalfOpenProbes пробных заданий и по их итогу замыкает цепь или снова размыкает её.
+// Задания, пришедшие при разомкнутой цепи, ждут в очереди, а не отклоняются.
+// Ошибкой считается итог задания после всех повторов WithRetry.
+func WithCircuitBreaker(cfg CircuitBreakerConfig) Option {
+	return func(c *config) {
+		if cfg.FailureThreshold <= 0 {
+			cfg.FailureThreshold = 5
+		}
+		if cfg.Cooldown <= 0 {
+			cfg.Cooldown = 30 * time.Second
+		}
+		if cfg.HalfOpenProbes <= 0 {
+			cfg.HalfOpenProbes = 1
+		}
+		c.breaker = &circuitBreaker{cfg: cfg, changed: make(chan struct{})}
+	}
+}
+
+// CircuitState возвращает текущее состояние автомата защиты.
+// Без WithCircuitBreaker цепь всегда замкнута.
+func (p *Pool[T, R]) CircuitState() CircuitState {
+	if p.breaker == nil {
+		return CircuitClosed
+	}
+	p.breaker.mu.Lock()
+	defer p.breaker.mu.Unlock()
+
+	return p.breaker.state
+}
+
+// circuitBreaker — автомат защиты WithCircuitBreaker.
+type circuitBreaker struct {
+	cfg    CircuitBreakerConfig
+	logger *slog.Logger
+	clock  Clock
+
+	mu        sync.Mutex
+	state     CircuitState
+	failures  int       // ошибок подряд в замкнутом состоянии
+	openUntil time.Time // конец Cooldown в разомкнутом состоянии
+	probes    int       // выданные пробы в полуоткрытом состоянии
+	passed    int       // успешные пробы
+	changed   chan struct{}
+}
+
+// acquire ждёт, пока цепь позволит воркеру взять задание. probe сообщает, что задание
+// будет пробным; такое разрешение нужно вернуть через record или cancel.
+// Возвращает false, если ожидание прервано ctx или stop.
+func (b *circuitBreaker) acquire(ctx context.Context, stop <-chan struct{}) (probe, ok bool) {
+	for {
+		b.mu.Lock()
+		var timer Timer
+		var wait <-chan time.Time
+		changed := b.changed
+		notify := noop
+		switch b.state {
+		case CircuitClosed:
+			b.mu.Unlock()
+			return false, true
+		case CircuitOpen:
+			if d := b.openUntil.Sub(b.clock.Now()); d > 0 {
+				timer = b.clock.NewTimer(d)
+				wait = timer.C()
+				break
+			}
+			notify = b.setLocked(CircuitHalfOpen)
+			fallthrough
+		case CircuitHalfOpen:
+			if b.probes < b.cfg.HalfOpenProbes {
+				b.probes++
+				b.mu.Unlock()
+				notify()
+				return true, true
+			}
+		}
+		b.mu.Unlock()
+		notify()
+
+		select {
+		case <-wait:
+		case <-changed:
+		case <-ctx.Done():
+		case <-stop:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil || stopped(stop) {
+			return false, false
+		}
+	}
+}
+
+// cancel возвращает неиспользованное пробное разрешение.
+func (b *circuitBreaker) cancel(probe bool) {
+	if !probe {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen && b.probes > 0 {
+		b.probes--
+		b.broadcastLocked()
+	}
+}
+
+// record учитывает итог задания.
+func (b *circuitBreaker) record(probe bool, err error) {
+	b.mu.Lock()
+	notify := noop
+	switch {
+	case b.state == CircuitClosed && err != nil:
+		b.failures++
+		if b.failures >= b.cfg.FailureThreshold {
+			notify = b.openLocked()
+		}
+	case b.state == CircuitClosed:
+		b.failures = 0
+	case b.state == CircuitHalfOpen && probe && err != nil:
+		notify = b.openLocked()
+	case b.state == CircuitHalfOpen && probe:
+		b.passed++
+		if b.passed >= b.cfg.HalfOpenProbes {
+			b.failures = 0
+			notify = b.setLocked(CircuitClosed)
+		}
+	}
+	// Итоги заданий, начатых до размыкания цепи, в остальных случаях не учитываются
+	b.mu.Unlock()
+	notify()
+}
+
+// openLocked размыкает цепь на время Cooldown. Вызывается под b.mu.
+func (b *circuitBreaker) openLocked() func() {
+	b.openUntil = b.clock.Now().Add(b.cfg.Cooldown)
+	return b.setLocked(CircuitOpen)
+}
+
+// setLocked меняет состояние, будит ждущих воркеров и возвращает уведомление
+// для вызова после снятия блокировки. Вызывается под b.mu.
+func (b *circuitBreaker) setLocked(state CircuitState) func() {
+	from := b.state
+	b.state = state
+	b.probes, b.passed = 0, 0
+	b.broadcastLocked()
+	return func() {
+		b.logger.Warn("circuit breaker state changed", "from", from, "to", state)
+		if b.cfg.OnStateChange != nil {
+			b.cfg.OnStateChange(from, state)
+		}
+	}
+}
+
+func (b *circuitBreaker) broadcastLocked() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+// stopped сообщает, закрыт ли канал stop.
+func stopped(stop <-chan struct{}) bool {
+	select {
+	case <-stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// breakerAcquire ждёт разрешения автомата защиты перед тем, как воркер возьмёт задание.
+func (p *Pool[T, R]) breakerAcquire(ctx context.Context, stop <-chan struct{}) (probe, ok bool) {
+	if p.breaker == nil {
+		return false, true
+	}
+	return p.breaker.acquire(ctx, stop)
+}
+
+// breakerRecord передаёт автомату защиты итог задания t.
+func (p *Pool[T, R]) breakerRecord(t *task[T, R], err error) {
+	if p.breaker != nil {
+		p.breaker.record(t.probe, err)
+	}
+}
+
+// releasePermits возвращает разрешения медленного старта, регулятора нагрузки
+// и автомата защиты, если воркер так и не взял задание.
+func (p *Pool[T, R]) releasePermits(probe bool) {
+	p.slowStartRelease()
+	p.governorRelease()
+	if p.breaker != nil {
+		p.breaker.cancel(probe)
+	}
+}
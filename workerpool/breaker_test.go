@@ -0,0 +1,94 @@
+package workerpool_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	var failing atomic.Bool
+	failing.Store(true)
+	var mu sync.Mutex
+	var transitions []string
+	pool := workerpool.NewPool[int, int](
+		workerpool.WithHandler(func(ctx context.Context, job int) (int, error) {
+			if failing.Load() {
+				return 0, errors.New("backend down")
+			}
+			return job, nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithInitialWorkers(1),
+		workerpool.WithCircuitBreaker(workerpool.CircuitBreakerConfig{
+			FailureThreshold: 2,
+			Cooldown:         10 * time.Second,
+			OnStateChange: func(from, to workerpool.CircuitState) {
+				mu.Lock()
+				transitions = append(transitions, fmt.Sprint(from, "->", to))
+				mu.Unlock()
+			},
+		}),
+	)
+	defer pool.Shutdown(context.Background())
+
+	submit := func(job int) *workerpool.Future[int] {
+		t.Helper()
+		future, err := pool.Submit(job)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		return future
+	}
+	// holdsFor проверяет, что задание ждёт в очереди, пока часы не продвинутся на d
+	holdsFor := func(future *workerpool.Future[int], d time.Duration) {
+		t.Helper()
+		clock.Advance(d - time.Second)
+		time.Sleep(10 * time.Millisecond)
+		if isDone(future)() {
+			t.Fatalf("job %d ran while the circuit was open", future.ID())
+		}
+		advanceUntil(t, clock, time.Second, "probe", isDone(future))
+	}
+
+	// Две ошибки подряд размыкают цепь
+	for i := 0; i < 2; i++ {
+		f := submit(i)
+		advanceUntil(t, clock, time.Millisecond, "failing job", isDone(f))
+	}
+	if state := pool.CircuitState(); state != workerpool.CircuitOpen {
+		t.Fatalf("state after 2 failures = %v, want open", state)
+	}
+
+	// После Cooldown пробное задание снова падает, и цепь опять размыкается
+	probe := submit(2)
+	holdsFor(probe, 10*time.Second)
+	if probe.Err() == nil || pool.CircuitState() != workerpool.CircuitOpen {
+		t.Fatalf("failed probe: err %v, state %v; want an error and open", probe.Err(), pool.CircuitState())
+	}
+
+	// Успешная проба замыкает цепь
+	failing.Store(false)
+	probe = submit(3)
+	holdsFor(probe, 10*time.Second)
+	if probe.Err() != nil || pool.CircuitState() != workerpool.CircuitClosed {
+		t.Fatalf("successful probe: err %v, state %v; want nil and closed", probe.Err(), pool.CircuitState())
+	}
+	f := submit(4)
+	advanceUntil(t, clock, time.Millisecond, "job after recovery", isDone(f))
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := "[closed->open open->half-open half-open->open open->half-open half-open->closed]"
+	if got := fmt.Sprint(transitions); got != want {
+		t.Errorf("transitions = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCapacityLimitsInflightCost(t *testing.T) {
+	const capacity = 5
+	var running, peak atomic.Int64
+	pool := NewPool[int, int](
+		// Задание — его стоимость: обработчик учитывает стоимость выполняющихся заданий
+		WithHandler(func(ctx context.Context, cost int) (int, error) {
+			now := running.Add(int64(cost))
+			for {
+				seen := peak.Load()
+				if now <= seen || peak.CompareAndSwap(seen, now) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-int64(cost))
+			return cost, nil
+		}),
+		WithInitialWorkers(4),
+		WithCapacity(capacity),
+	)
+	defer pool.Shutdown(context.Background())
+
+	if err := pool.SendJobCost(capacity+1, capacity+1); err == nil {
+		t.Fatal("job costlier than the whole capacity was accepted")
+	}
+
+	var wg sync.WaitGroup
+	var admitted atomic.Int32
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 25; i++ {
+				cost := 1 + (g+i)%3
+				// Отклонённое задание повторяется, пока не освободится ёмкость
+				for pool.SendJobCost(cost, cost) != nil {
+					if n := pool.inflightCost.Load(); n > capacity {
+						t.Errorf("in-flight cost %d exceeds capacity %d", n, capacity)
+					}
+					time.Sleep(100 * time.Microsecond)
+				}
+				admitted.Add(1)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := pool.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if n := admitted.Load(); n != 100 {
+		t.Errorf("admitted %d jobs, want 100", n)
+	}
+	if n := peak.Load(); n > capacity {
+		t.Errorf("peak running cost %d exceeds capacity %d", n, capacity)
+	}
+	if n := pool.inflightCost.Load(); n != 0 {
+		t.Errorf("in-flight cost after Wait = %d, want 0", n)
+	}
+}
@@ -0,0 +1,134 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock — источник времени пула. По умолчанию используется системное время;
+// в тестах его заменяет управляемый вручную workerpooltest.Clock, чтобы проверять
+// таймауты, паузы WithRetry и простой воркеров без настоящих ожиданий.
+type Clock interface {
+	Now() time.Time
+	// NewTimer создаёт таймер, который пошлёт время в канал C через d
+	NewTimer(d time.Duration) Timer
+	// AfterFunc вызывает f в отдельной горутине через d
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer — таймер Clock, повторяющий поведение time.Timer.
+// У таймеров AfterFunc канал C равен nil.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// WithClock задаёт источник времени пула: таймауты заданий, паузы WithRetry и Sleep,
+// простой воркеров, отложенные и периодические задания, сроки TTL, WithRateLimit,
+// WithWorkerSpawnRate и WithSlowStart, остывание автомата защиты, проверки сторожа
+// и автомасштабирования, а также все отметки времени в событиях, истории и Stats.
+// Выборки памяти WithMemoryGuard и проверки WithGovernor идут по системному времени.
+func WithClock(clock Clock) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+// realClock — системное время.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer { return realTimer{time.AfterFunc(d, f)} }
+
+type realTimer struct{ *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
+
+type clockKey struct{}
+
+// Sleep ждёт d по часам пула, выполняющего задание, или до отмены ctx.
+// В обработчиках стоит использовать его вместо time.Sleep: с WithClock имитация работы
+// длится, пока тест не продвинет часы, а не настоящее время. Вне задания Sleep ждёт
+// по системному времени. Возвращает ошибку ctx, если тот отменили раньше.
+func Sleep(ctx context.Context, d time.Duration) error {
+	clock, ok := ctx.Value(clockKey{}).(Clock)
+	if !ok {
+		clock = realClock{}
+	}
+	timer := clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// withClock передаёт обработчику часы пула для Sleep. Системные часы не передаются:
+// Sleep и так использует их по умолчанию.
+func (p *Pool[T, R]) withClock(ctx context.Context) context.Context {
+	if _, ok := p.clock.(realClock); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, clockKey{}, p.clock)
+}
+
+// withDeadline — context.WithDeadline по часам пула.
+func (p *Pool[T, R]) withDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	if _, ok := p.clock.(realClock); ok {
+		return context.WithDeadline(parent, deadline)
+	}
+	return newClockContext(parent, p.clock, deadline)
+}
+
+// clockContext — контекст со сроком, который отслеживается по Clock, а не по системному времени.
+// По истечении срока Err возвращает context.DeadlineExceeded, как у context.WithDeadline.
+type clockContext struct {
+	context.Context
+	deadline time.Time
+	done     chan struct{}
+	expired  atomic.Bool
+	once     sync.Once
+}
+
+func newClockContext(parent context.Context, clock Clock, deadline time.Time) (context.Context, context.CancelFunc) {
+	inner, cancel := context.WithCancel(parent)
+	c := &clockContext{Context: inner, deadline: deadline, done: make(chan struct{})}
+	// Собственный канал Done нужен, чтобы дочерние контексты брали ошибку из Err,
+	// а не из внутреннего cancelCtx, который знает только о context.Canceled
+	stopWatch := context.AfterFunc(inner, func() { c.once.Do(func() { close(c.done) }) })
+	timer := clock.AfterFunc(deadline.Sub(clock.Now()), func() {
+		c.expired.Store(true)
+		cancel()
+	})
+	return c, func() {
+		timer.Stop()
+		stopWatch()
+		cancel()
+		c.once.Do(func() { close(c.done) })
+	}
+}
+
+func (c *clockContext) Deadline() (time.Time, bool) { return c.deadline, true }
+
+func (c *clockContext) Done() <-chan struct{} { return c.done }
+
+func (c *clockContext) Err() error {
+	select {
+	case <-c.done:
+	default:
+		return nil
+	}
+	if c.expired.Load() {
+		return context.DeadlineExceeded
+	}
+	return c.Context.Err()
+}
@@ -0,0 +1,250 @@
+package workerpool_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestSleepFollowsPoolClock(t *testing.T) {
+	const jobs, workers, delay = 200, 4, 500 * time.Millisecond
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	var done atomic.Int32
+	pool := workerpool.NewPool[string, string](
+		workerpool.WithHandler(func(ctx context.Context, job string) (string, error) {
+			if err := workerpool.Sleep(ctx, delay); err != nil {
+				return "", err
+			}
+			done.Add(1)
+			return job + " done", nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithInitialWorkers(workers),
+		workerpool.WithBufferSize(jobs),
+	)
+	defer pool.Shutdown(context.Background())
+
+	started := time.Now()
+	for i := 0; i < jobs; i++ {
+		if err := pool.SendJob(fmt.Sprintf("Task %d", i)); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	// Часы продвигаются, как только хотя бы один воркер ждёт в Sleep
+	for int(done.Load()) < jobs {
+		if time.Since(started) > 2*time.Second {
+			t.Fatalf("%d of %d jobs done", done.Load(), jobs)
+		}
+		if clock.Timers() == 0 {
+			time.Sleep(time.Microsecond)
+			continue
+		}
+		clock.Advance(delay)
+	}
+
+	// По часам пула прошло не меньше jobs/workers пауз, а по настоящим — доли секунды
+	if elapsed := clock.Now().Sub(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)); elapsed < jobs/workers*delay {
+		t.Errorf("pool clock advanced %v, want at least %v", elapsed, jobs/workers*delay)
+	}
+}
+
+func TestEchoHandler(t *testing.T) {
+	pool := workerpooltest.NewSynchronousPool(workerpooltest.Echo[int])
+	defer pool.Shutdown(context.Background())
+
+	for i := 0; i < 1000; i++ {
+		future, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		if err := future.Err(); err != nil || future.Result() != i {
+			t.Fatalf("job %d result = %d, %v", i, future.Result(), err)
+		}
+	}
+}
+
+// advanceUntil продвигает часы шагами step, пока не выполнится cond, и возвращает,
+// на сколько продвинулись часы. По настоящему времени ожидание ограничено секундами.
+func advanceUntil(t *testing.T, clock *workerpooltest.Clock, step time.Duration, what string, cond func() bool) time.Duration {
+	t.Helper()
+	start, deadline := clock.Now(), time.Now().Add(2*time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s: not reached after advancing the clock by %v", what, clock.Now().Sub(start))
+		}
+		clock.Advance(step)
+		time.Sleep(time.Millisecond)
+	}
+	return clock.Now().Sub(start)
+}
+
+func isDone[R any](f *workerpool.Future[R]) func() bool {
+	return func() bool {
+		select {
+		case <-f.Done():
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+func TestJobTTLFollowsPoolClock(t *testing.T) {
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	release := make(chan struct{})
+	pool := workerpool.NewPool[string, string](
+		workerpool.WithHandler(func(ctx context.Context, job string) (string, error) {
+			<-release
+			return job, nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	busy, err := pool.Submit("busy")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	queued, err := pool.SubmitWithTTL("queued", time.Minute)
+	if err != nil {
+		t.Fatalf("SubmitWithTTL: %v", err)
+	}
+
+	// Срок истекает по часам пула, хотя по настоящим прошли миллисекунды
+	clock.Advance(2 * time.Minute)
+	close(release)
+	advanceUntil(t, clock, 0, "queued job finished", isDone(queued))
+	if err := queued.Err(); !errors.Is(err, workerpool.ErrJobExpired) {
+		t.Errorf("queued job error = %v, want ErrJobExpired", err)
+	}
+	advanceUntil(t, clock, 0, "busy job finished", isDone(busy))
+	if err := busy.Err(); err != nil {
+		t.Errorf("busy job error = %v", err)
+	}
+}
+
+func TestRateLimitFollowsPoolClock(t *testing.T) {
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	pool := workerpool.NewPool[int, int](
+		workerpool.WithHandler(workerpooltest.Echo[int]),
+		workerpool.WithClock(clock),
+		workerpool.WithRateLimit(1.0/60, 1), // одно задание в минуту
+		workerpool.WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	var futures []*workerpool.Future[int]
+	for i := 0; i < 3; i++ {
+		future, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		futures = append(futures, future)
+	}
+	elapsed := advanceUntil(t, clock, 10*time.Second, "all jobs done", func() bool {
+		return isDone(futures[0])() && isDone(futures[1])() && isDone(futures[2])()
+	})
+	if elapsed < 2*time.Minute {
+		t.Errorf("three jobs at one per minute took %v on the pool clock, want at least 2m", elapsed)
+	}
+}
+
+func TestCircuitBreakerCooldownFollowsPoolClock(t *testing.T) {
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	pool := workerpool.NewPool[string, string](
+		workerpool.WithHandler(func(ctx context.Context, job string) (string, error) {
+			if job == "fail" {
+				return "", errors.New("boom")
+			}
+			return job, nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithCircuitBreaker(workerpool.CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Hour}),
+		workerpool.WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	failed, err := pool.Submit("fail")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	advanceUntil(t, clock, 0, "failing job finished", isDone(failed))
+	if state := pool.CircuitState(); state != workerpool.CircuitOpen {
+		t.Fatalf("circuit state = %v, want open", state)
+	}
+
+	probe, err := pool.Submit("ok")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	elapsed := advanceUntil(t, clock, 10*time.Minute, "probe job finished", isDone(probe))
+	if elapsed < time.Hour {
+		t.Errorf("probe ran after %v on the pool clock, want at least the 1h cooldown", elapsed)
+	}
+	if state := pool.CircuitState(); state != workerpool.CircuitClosed {
+		t.Errorf("circuit state after probe = %v, want closed", state)
+	}
+}
+
+func TestWatchdogFollowsPoolClock(t *testing.T) {
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	release := make(chan struct{})
+	stalls := make(chan workerpool.StalledJob, 1)
+	pool := workerpool.NewPool[string, string](
+		workerpool.WithHandler(func(ctx context.Context, job string) (string, error) {
+			<-release
+			return job, nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithWatchdog(workerpool.WatchdogConfig{
+			Threshold: time.Minute,
+			OnStall:   func(s workerpool.StalledJob) { stalls <- s },
+		}),
+		workerpool.WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+	defer close(release)
+
+	if err := pool.SendJob("stuck"); err != nil {
+		t.Fatalf("SendJob: %v", err)
+	}
+	var stall workerpool.StalledJob
+	advanceUntil(t, clock, 15*time.Second, "stall reported", func() bool {
+		select {
+		case stall = <-stalls:
+			return true
+		default:
+			return false
+		}
+	})
+	if stall.Running <= time.Minute {
+		t.Errorf("stalled job running for %v, want more than the 1m threshold", stall.Running)
+	}
+}
+
+func TestWorkerSpawnRateFollowsPoolClock(t *testing.T) {
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	pool := workerpool.NewPool[int, int](
+		workerpool.WithHandler(workerpooltest.Echo[int]),
+		workerpool.WithClock(clock),
+		workerpool.WithWorkerSpawnRate(1.0/60), // воркер в минуту
+	)
+	defer pool.Shutdown(context.Background())
+
+	for i := 0; i < 3; i++ {
+		go pool.AddWorker()
+	}
+	elapsed := advanceUntil(t, clock, 10*time.Second, "workers online", func() bool {
+		return pool.Stats().Workers == 3
+	})
+	if elapsed < 2*time.Minute {
+		t.Errorf("three workers at one per minute came online in %v on the pool clock, want at least 2m", elapsed)
+	}
+}
@@ -0,0 +1,50 @@
+package workerpool
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Codec кодирует задания в байты и обратно для журнала WithPersistence
+// и внешних очередей (PushJob, Consume). Пул передаёт в Encode и Decode указатель на задание.
+type Codec interface {
+	Encode(job any) ([]byte, error)
+	Decode(data []byte, job any) error
+}
+
+// JSONCodec кодирует задания в JSON. Используется по умолчанию.
+type JSONCodec struct{}
+
+func (JSONCodec) Encode(job any) ([]byte, error) {
+	return json.Marshal(job)
+}
+
+func (JSONCodec) Decode(data []byte, job any) error {
+	return json.Unmarshal(data, job)
+}
+
+// GobCodec кодирует задания через encoding/gob. В отличие от JSON, gob сохраняет
+// конкретный тип значения в интерфейсе, поэтому пул с заданиями интерфейсного типа
+// получает обратно задания исходных типов — их нужно заранее зарегистрировать через gob.Register.
+type GobCodec struct{}
+
+func (GobCodec) Encode(job any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(job); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobCodec) Decode(data []byte, job any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(job)
+}
+
+// WithCodec задаёт кодек заданий для WithPersistence и Consume (по умолчанию JSONCodec).
+// Отправители PushJobWithCodec должны использовать тот же кодек.
+func WithCodec(codec Codec) Option {
+	return func(c *config) {
+		c.codec = codec
+	}
+}
@@ -0,0 +1,70 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/gob"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// shape — интерфейсный тип заданий: после кодека задания должны сохранить конкретный тип.
+type shape interface{ Area() float64 }
+
+type square struct{ Side float64 }
+
+func (s square) Area() float64 { return s.Side * s.Side }
+
+type rect struct{ W, H float64 }
+
+func (r rect) Area() float64 { return r.W * r.H }
+
+func init() {
+	gob.Register(square{})
+	gob.Register(rect{})
+}
+
+func TestGobCodecKeepsConcreteTypes(t *testing.T) {
+	ctx := context.Background()
+	q := NewMemoryQueue(2)
+	for _, job := range []shape{square{Side: 3}, rect{W: 2, H: 5}} {
+		if err := PushJobWithCodec(ctx, q, GobCodec{}, job); err != nil {
+			t.Fatalf("PushJobWithCodec: %v", err)
+		}
+	}
+
+	results := make(chan string, 2)
+	pool := NewPool[shape, float64](WithHandler(func(ctx context.Context, job shape) (float64, error) {
+		results <- fmt.Sprintf("%T %v", job, job.Area())
+		return job.Area(), nil
+	}), WithCodec(GobCodec{}), WithInitialWorkers(1))
+	defer pool.Shutdown(ctx)
+	go pool.Consume(ctx, q)
+	defer q.Close()
+
+	for _, want := range []string{"workerpool.square 9", "workerpool.rect 10"} {
+		select {
+		case got := <-results:
+			if got != want {
+				t.Errorf("decoded job = %s, want %s", got, want)
+			}
+		case <-time.After(testTimeout):
+			t.Fatalf("job %s was not consumed", want)
+		}
+	}
+}
+
+func TestJSONCodecRoundTrip(t *testing.T) {
+	in := rect{W: 1.5, H: 4}
+	data, err := JSONCodec{}.Encode(&in)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if string(data) != `{"W":1.5,"H":4}` {
+		t.Errorf("Encode = %s", data)
+	}
+	var out rect
+	if err := (JSONCodec{}).Decode(data, &out); err != nil || out != in {
+		t.Errorf("Decode = %+v, %v; want %+v", out, err, in)
+	}
+}
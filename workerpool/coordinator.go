@@ -0,0 +1,218 @@
+package workerpool
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Coordinator — общий бюджет параллелизма для нескольких пулов одного процесса, например
+// пулов разных классов заданий, работающих с одной базой данных. Одновременно во всех пулах
+// Coordinator выполняется не больше limit заданий; свободные места достаются пулам
+// пропорционально их весам WithCoordinator, а пул без работы отдаёт свою долю остальным.
+// Пулы разных типов заданий подключаются к одному Coordinator одинаково.
+type Coordinator struct {
+	limit int
+
+	mu      sync.Mutex
+	active  int
+	members []*coordinated
+}
+
+// coordinated — участие одного пула в Coordinator. Поля, кроме name, weight и shutdown, защищены Coordinator.mu.
+type coordinated struct {
+	name     string
+	weight   int
+	shutdown func(ctx context.Context) error
+
+	active  int
+	waiters list.List // chan struct{}, закрывается при выдаче места
+}
+
+// CoordinatorStats — показатели Coordinator.
+type CoordinatorStats struct {
+	Limit  int
+	Active int
+	Pools  []CoordinatedPoolStats
+}
+
+// CoordinatedPoolStats — показатели одного пула Coordinator.
+type CoordinatedPoolStats struct {
+	Name    string
+	Weight  int
+	Share   int // гарантированная доля limit по весу
+	Active  int // выполняющиеся задания
+	Waiting int // задания, ждущие места в бюджете
+}
+
+// NewCoordinator создаёт Coordinator с общим лимитом limit одновременно выполняемых заданий.
+func NewCoordinator(limit int) *Coordinator {
+	if limit < 1 {
+		panic("workerpool: NewCoordinator requires a positive limit")
+	}
+	return &Coordinator{limit: limit}
+}
+
+// WithCoordinator подключает пул к Coordinator под именем name с весом weight: при конкуренции
+// пул получает долю limit, пропорциональную весу. Воркер, взявший задание, ждёт места в общем
+// бюджете так же, как единиц WithConcurrencyUnits, и это ожидание не входит в таймаут задания.
+// В пакетном режиме пакет занимает одно место. Остановленный пул выходит из Coordinator.
+func WithCoordinator(c *Coordinator, name string, weight int) Option {
+	return func(cfg *config) {
+		if weight < 1 {
+			panic("workerpool: WithCoordinator requires a positive weight")
+		}
+		cfg.coordinator = c
+		cfg.coordinated = &coordinated{name: name, weight: weight}
+	}
+}
+
+// join регистрирует пул в Coordinator.
+func (c *Coordinator) join(m *coordinated) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.members = append(c.members, m)
+}
+
+// leave исключает остановленный пул из Coordinator; его доля переходит остальным.
+func (c *Coordinator) leave(m *coordinated) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, member := range c.members {
+		if member == m {
+			c.members = append(c.members[:i], c.members[i+1:]...)
+			break
+		}
+	}
+	c.grantLocked()
+}
+
+// acquire ждёт места в бюджете для пула m. Возвращает ошибку ctx, если тот отменён раньше.
+func (c *Coordinator) acquire(ctx context.Context, m *coordinated) error {
+	c.mu.Lock()
+	ready := make(chan struct{})
+	elem := m.waiters.PushBack(ready)
+	c.grantLocked()
+	c.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		select {
+		case <-ready:
+			// Место выдано одновременно с отменой — возвращаем его
+			c.active--
+			m.active--
+		default:
+			m.waiters.Remove(elem)
+		}
+		c.grantLocked()
+		return ctx.Err()
+	}
+}
+
+func (c *Coordinator) release(m *coordinated) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.active--
+	m.active--
+	c.grantLocked()
+}
+
+// grantLocked раздаёт свободные места ожидающим: каждое следующее место достаётся пулу
+// с наименьшим числом выполняющихся заданий на единицу веса. Вызывается под c.mu.
+func (c *Coordinator) grantLocked() {
+	for c.active < c.limit {
+		var next *coordinated
+		for _, m := range c.members {
+			if m.waiters.Len() == 0 {
+				continue
+			}
+			// m.active/m.weight < next.active/next.weight без деления
+			if next == nil || m.active*next.weight < next.active*m.weight {
+				next = m
+			}
+		}
+		if next == nil {
+			return
+		}
+		elem := next.waiters.Front()
+		next.waiters.Remove(elem)
+		close(elem.Value.(chan struct{}))
+		next.active++
+		c.active++
+	}
+}
+
+// Stats возвращает текущие показатели Coordinator.
+func (c *Coordinator) Stats() CoordinatorStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	total := 0
+	for _, m := range c.members {
+		total += m.weight
+	}
+	s := CoordinatorStats{Limit: c.limit, Active: c.active, Pools: make([]CoordinatedPoolStats, len(c.members))}
+	for i, m := range c.members {
+		s.Pools[i] = CoordinatedPoolStats{
+			Name:    m.name,
+			Weight:  m.weight,
+			Share:   max(1, c.limit*m.weight/total),
+			Active:  m.active,
+			Waiting: m.waiters.Len(),
+		}
+	}
+	return s
+}
+
+// Shutdown параллельно останавливает все пулы Coordinator через их Shutdown и возвращает
+// объединённую ошибку пулов (nil, если все остановились без ошибок).
+func (c *Coordinator) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	members := append([]*coordinated(nil), c.members...)
+	c.mu.Unlock()
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+	for _, m := range members {
+		wg.Add(1)
+		go func(m *coordinated) {
+			defer wg.Done()
+			if err := m.shutdown(ctx); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("pool %q: %w", m.name, err))
+				mu.Unlock()
+			}
+		}(m)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// coordinatorAcquire занимает место в бюджете WithCoordinator перед вызовом обработчика.
+func (p *Pool[T, R]) coordinatorAcquire(ctx context.Context) error {
+	if p.coordinator == nil {
+		return nil
+	}
+	return p.coordinator.acquire(ctx, p.coordinated)
+}
+
+// coordinatorRelease возвращает место, занятое coordinatorAcquire.
+func (p *Pool[T, R]) coordinatorRelease() {
+	if p.coordinator != nil {
+		p.coordinator.release(p.coordinated)
+	}
+}
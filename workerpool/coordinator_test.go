@@ -0,0 +1,81 @@
+package workerpool
+
+import (
+	"context"
+	"testing"
+)
+
+// blockUntil — обработчик, ждущий закрытия release или отмены задания.
+func blockUntil[T any](release <-chan struct{}) Handler[T, T] {
+	return func(ctx context.Context, job T) (T, error) {
+		select {
+		case <-release:
+			return job, nil
+		case <-ctx.Done():
+			return job, ctx.Err()
+		}
+	}
+}
+
+func TestCoordinatorSharesBudget(t *testing.T) {
+	const limit = 4
+	coord := NewCoordinator(limit)
+	holdRelease, release := make(chan struct{}), make(chan struct{})
+	// Пулы разных типов заданий делят один бюджет
+	hold := NewPool[int, int](WithHandler(blockUntil[int](holdRelease)), WithInitialWorkers(limit), WithCoordinator(coord, "hold", 1))
+	reports := NewPool[string, string](WithHandler(blockUntil[string](release)), WithInitialWorkers(limit), WithCoordinator(coord, "reports", 3))
+	emails := NewPool[int, int](WithHandler(blockUntil[int](release)), WithInitialWorkers(limit), WithCoordinator(coord, "emails", 1))
+	// Если тест прервётся раньше, ShutdownNow не даст зависнуть на заданиях, ждущих release
+	defer hold.ShutdownNow()
+	defer reports.ShutdownNow()
+	defer emails.ShutdownNow()
+
+	pools := func() map[string]CoordinatedPoolStats {
+		byName := map[string]CoordinatedPoolStats{}
+		for _, s := range coord.Stats().Pools {
+			byName[s.Name] = s
+		}
+		return byName
+	}
+	// Пул hold занимает весь бюджет, и задания остальных пулов ждут места, заняв воркеров
+	for i := 0; i < limit; i++ {
+		hold.SendJob(i)
+	}
+	eventually(t, "budget taken", func() bool { return pools()["hold"].Active == limit })
+	for i := 0; i < limit; i++ {
+		reports.SendJob("report")
+		emails.SendJob(i)
+	}
+	eventually(t, "jobs waiting for the budget", func() bool {
+		s := pools()
+		return s["reports"].Waiting == limit && s["emails"].Waiting == limit
+	})
+	if s := pools(); s["reports"].Share != 2 || s["emails"].Share != 1 {
+		t.Errorf("shares = %d and %d, want 2 and 1 by weight", s["reports"].Share, s["emails"].Share)
+	}
+
+	// Освободившиеся места делятся по весам 3:1, а общий лимит не превышается
+	close(holdRelease)
+	eventually(t, "budget handed over", func() bool {
+		s := pools()
+		return s["hold"].Active == 0 && s["reports"].Active+s["emails"].Active == limit
+	})
+	if s := pools(); s["reports"].Active != 3 || s["emails"].Active != 1 {
+		t.Errorf("active jobs = %d reports and %d emails, want 3 and 1", s["reports"].Active, s["emails"].Active)
+	}
+	if n := coord.Stats().Active; n != limit {
+		t.Errorf("Coordinator active = %d, want %d", n, limit)
+	}
+
+	// Shutdown останавливает все пулы, и их доли освобождаются
+	close(release)
+	if err := coord.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Coordinator.Shutdown: %v", err)
+	}
+	if hold.State() != Closed || reports.State() != Closed || emails.State() != Closed {
+		t.Errorf("states after Shutdown = %v, %v, %v; want all Closed", hold.State(), reports.State(), emails.State())
+	}
+	if s := coord.Stats(); len(s.Pools) != 0 || s.Active != 0 {
+		t.Errorf("Stats after Shutdown = %+v, want no pools and no active jobs", s)
+	}
+}
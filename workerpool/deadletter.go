@@ -0,0 +1,76 @@
+package workerpool
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// DeadLetter — задание, которое пул не смог обработать.
+type DeadLetter struct {
+	Job      any       // исходное задание
+	Err      error     // последняя ошибка обработчика или ErrQueueFull
+	Attempts int       // число сделанных попыток (0 — задание не попало в очередь)
+	FailedAt time.Time // время, когда задание признано недоставленным
+}
+
+// DeadLetterHandler принимает недоставленные задания: например, пишет их в журнал
+// или сохраняет для повторной отправки.
+type DeadLetterHandler interface {
+	HandleDeadLetter(DeadLetter)
+}
+
+// DeadLetterFunc позволяет использовать обычную функцию как DeadLetterHandler.
+type DeadLetterFunc func(DeadLetter)
+
+// HandleDeadLetter вызывает f(dl).
+func (f DeadLetterFunc) HandleDeadLetter(dl DeadLetter) {
+	f(dl)
+}
+
+// WithDeadLetter направляет в h задания, завершившиеся ошибкой после всех повторов,
+// и задания, отклонённые из-за переполненной очереди (отправитель при этом всё равно получает ErrQueueFull).
+// h вызывается в горутине воркера или отправителя, поэтому должен быстро возвращать управление.
+func WithDeadLetter(h DeadLetterHandler) Option {
+	return func(c *config) {
+		c.deadLetters = h
+	}
+}
+
+// DeadLetterQueue — очередь недоставленных заданий в памяти, из которой их можно вычитать.
+// Когда буфер заполнен, новые записи отбрасываются, чтобы не блокировать пул.
+type DeadLetterQueue struct {
+	ch      chan DeadLetter
+	dropped atomic.Int64
+}
+
+// NewDeadLetterQueue создаёт очередь недоставленных заданий на size записей.
+func NewDeadLetterQueue(size int) *DeadLetterQueue {
+	return &DeadLetterQueue{ch: make(chan DeadLetter, size)}
+}
+
+// HandleDeadLetter добавляет запись в очередь без ожидания.
+func (q *DeadLetterQueue) HandleDeadLetter(dl DeadLetter) {
+	select {
+	case q.ch <- dl:
+	default:
+		q.dropped.Add(1)
+	}
+}
+
+// C возвращает канал, из которого читаются недоставленные задания.
+func (q *DeadLetterQueue) C() <-chan DeadLetter {
+	return q.ch
+}
+
+// Dropped возвращает число записей, отброшенных из-за переполнения очереди.
+func (q *DeadLetterQueue) Dropped() int64 {
+	return q.dropped.Load()
+}
+
+// deadLetter передаёт задание обработчику недоставленных, если он задан.
+func (p *Pool[T, R]) deadLetter(job T, err error, attempts int) {
+	if p.deadLetters == nil {
+		return
+	}
+	p.deadLetters.HandleDeadLetter(DeadLetter{Job: job, Err: err, Attempts: attempts, FailedAt: p.clock.Now()})
+}
@@ -0,0 +1,56 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDeadLetters(t *testing.T) {
+	errBoom := errors.New("boom")
+	dead := NewDeadLetterQueue(1)
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			return "", errBoom
+		}),
+		WithRetry(RetryPolicy{MaxAttempts: 2, Backoff: func(int) time.Duration { return time.Microsecond }}),
+		WithDeadLetter(dead),
+		WithBufferSize(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	// Задание, не поместившееся в очередь, уходит в недоставленные с ErrQueueFull
+	if err := pool.SendJob("queued"); err != nil {
+		t.Fatalf("SendJob: %v", err)
+	}
+	if err := pool.SendJob("overflow"); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("SendJob into a full queue = %v, want ErrQueueFull", err)
+	}
+	if dl := <-dead.C(); dl.Job != "overflow" || !errors.Is(dl.Err, ErrQueueFull) || dl.Attempts != 0 {
+		t.Errorf("overflow dead letter = %+v", dl)
+	}
+
+	// Задание, упавшее после всех повторов, уходит с последней ошибкой и числом попыток
+	pool.AddWorker()
+	select {
+	case dl := <-dead.C():
+		if dl.Job != "queued" || !errors.Is(dl.Err, errBoom) || dl.Attempts != 2 || dl.FailedAt.IsZero() {
+			t.Errorf("failed job dead letter = %+v", dl)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("failed job did not reach dead letters")
+	}
+}
+
+func TestDeadLetterQueueDropsWhenFull(t *testing.T) {
+	q := NewDeadLetterQueue(1)
+	q.HandleDeadLetter(DeadLetter{Job: 1})
+	q.HandleDeadLetter(DeadLetter{Job: 2})
+	if n := q.Dropped(); n != 1 {
+		t.Errorf("Dropped = %d, want 1", n)
+	}
+	if dl := <-q.C(); dl.Job != 1 {
+		t.Errorf("kept dead letter = %v, want the first one", dl.Job)
+	}
+}
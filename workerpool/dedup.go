@@ -0,0 +1,83 @@
+package workerpool
+
+import "errors"
+
+// WithDedupInFlight распространяет дедупликацию SendJobWithKey и SubmitWithKey
+// на выполняющиеся задания: пока задание с ключом в работе, новые задания с тем же
+// ключом тоже объединяются с ним. Без опции объединяются только задания, ещё ждущие в очереди.
+func WithDedupInFlight() Option {
+	return func(c *config) {
+		c.dedupInFlight = true
+	}
+}
+
+// SendJobWithKey ставит задание в очередь с ключом дедупликации. Если задание с тем же
+// ключом уже ждёт в очереди, новое не ставится, а вызов возвращает nil.
+func (p *Pool[T, R]) SendJobWithKey(job T, key string) error {
+	_, err := p.SubmitWithKey(job, key)
+	return err
+}
+
+// SubmitWithKey — то же, что SendJobWithKey, но возвращает Future. При объединении
+// с уже поставленным заданием возвращается его Future, так что все отправители
+// получают один и тот же результат.
+func (p *Pool[T, R]) SubmitWithKey(job T, key string) (*Future[R], error) {
+	t := &task[T, R]{job: job, cost: 1, key: key, future: newFuture[R]()}
+	if err := p.enqueue(t); err != nil {
+		return nil, err
+	}
+	// При объединении enqueue подменяет Future на Future уже поставленного задания
+	return t.future, nil
+}
+
+// SubmitSingleFlight — аналог golang.org/x/sync/singleflight поверх пула: одновременные
+// отправки с одним ключом разделяют одно выполнение, и все получают один и тот же Future.
+// В отличие от SubmitWithKey, объединение действует, пока задание не завершится,
+// а не только пока оно ждёт в очереди, независимо от WithDedupInFlight.
+// Пока задание выполняется, с ним объединяются и отправки SubmitWithKey с тем же ключом.
+// После завершения задания следующая отправка с ключом снова вызывает обработчик.
+func (p *Pool[T, R]) SubmitSingleFlight(job T, key string) (*Future[R], error) {
+	if key == "" {
+		return nil, errors.New("single-flight key must not be empty")
+	}
+	t := &task[T, R]{job: job, cost: 1, key: key, singleFlight: true, future: newFuture[R]()}
+	if err := p.enqueue(t); err != nil {
+		return nil, err
+	}
+	return t.future, nil
+}
+
+// coalesceLocked объединяет задание с уже принятым заданием с тем же ключом.
+// Возвращает true, если объединение произошло. Вызывается под p.mu.
+func (p *Pool[T, R]) coalesceLocked(t *task[T, R]) bool {
+	if t.key == "" {
+		return false
+	}
+	existing, exists := p.keys[t.key]
+	if !exists {
+		return false
+	}
+	t.id = existing.id
+	t.future = existing.future
+	t.merged = true
+	return true
+}
+
+// rememberKeyLocked запоминает ключ принятого задания. Вызывается под p.mu.
+func (p *Pool[T, R]) rememberKeyLocked(t *task[T, R]) {
+	if t.key == "" {
+		return
+	}
+	if p.keys == nil {
+		p.keys = make(map[string]*task[T, R])
+	}
+	p.keys[t.key] = t
+}
+
+// forgetKeyLocked освобождает ключ задания, чтобы следующие задания с ним снова принимались.
+// Вызывается под p.mu.
+func (p *Pool[T, R]) forgetKeyLocked(t *task[T, R]) {
+	if t.key != "" && p.keys[t.key] == t {
+		delete(p.keys, t.key)
+	}
+}
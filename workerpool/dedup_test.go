@@ -0,0 +1,132 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSubmitSingleFlight(t *testing.T) {
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			if calls.Add(1) == 1 {
+				close(started)
+				<-release
+			}
+			return "loaded " + job, nil
+		}),
+		WithInitialWorkers(4),
+	)
+	defer pool.Shutdown(context.Background())
+
+	first, err := pool.SubmitSingleFlight("user", "user:42")
+	if err != nil {
+		t.Fatalf("SubmitSingleFlight: %v", err)
+	}
+	<-started
+
+	// Пока первое задание выполняется, отправки с тем же ключом объединяются с ним
+	const callers = 8
+	futures := make([]*Future[string], callers)
+	var wg sync.WaitGroup
+	for i := range futures {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			f, err := pool.SubmitSingleFlight("user", "user:42")
+			if err != nil {
+				t.Errorf("SubmitSingleFlight: %v", err)
+				return
+			}
+			futures[i] = f
+		}(i)
+	}
+	wg.Wait()
+	close(release)
+
+	for i, f := range futures {
+		if f != first {
+			t.Errorf("caller %d got a separate future", i)
+			continue
+		}
+		if value, err := await(t, f); err != nil || value != "loaded user" {
+			t.Errorf("caller %d result = %q, %v", i, value, err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("handler called %d times, want 1", n)
+	}
+
+	// После завершения ключ свободен, и следующая отправка выполняется заново
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := pool.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	again, err := pool.SubmitSingleFlight("user", "user:42")
+	if err != nil {
+		t.Fatalf("SubmitSingleFlight after completion: %v", err)
+	}
+	if _, err := await(t, again); err != nil {
+		t.Fatalf("job failed: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("handler called %d times after the key was released, want 2", n)
+	}
+}
+
+func TestSubmitWithKeyCoalescesQueuedJobs(t *testing.T) {
+	for _, inFlight := range []bool{false, true} {
+		started, release := make(chan struct{}, 4), make(chan struct{})
+		opts := []Option{WithHandler(func(ctx context.Context, job string) (string, error) {
+			started <- struct{}{}
+			<-release
+			return job, nil
+		})}
+		if inFlight {
+			opts = append(opts, WithDedupInFlight())
+		}
+		pool := NewPool[string, string](opts...)
+
+		// Пока задание ждёт в очереди, отправки с тем же ключом объединяются с ним
+		first, err := pool.SubmitWithKey("first", "k")
+		if err != nil {
+			t.Fatalf("SubmitWithKey: %v", err)
+		}
+		if second, err := pool.SubmitWithKey("second", "k"); err != nil || second != first {
+			t.Fatalf("queued duplicate = %p, %v; want the first future", second, err)
+		}
+		if err := pool.SendJobWithKey("third", "k"); err != nil {
+			t.Fatalf("SendJobWithKey: %v", err)
+		}
+		other, err := pool.SubmitWithKey("other", "other")
+		if err != nil || other == first {
+			t.Fatalf("job with another key = %p, %v; want a separate future", other, err)
+		}
+		if n := pool.QueueLen(); n != 2 {
+			t.Fatalf("QueueLen = %d, want 2", n)
+		}
+
+		// Выполняющееся задание объединяется с новыми только с WithDedupInFlight
+		pool.AddWorker()
+		<-started
+		running, err := pool.SubmitWithKey("running", "k")
+		if err != nil {
+			t.Fatalf("SubmitWithKey while running: %v", err)
+		}
+		if merged := running == first; merged != inFlight {
+			t.Errorf("in-flight dedup %t: job merged with the running one = %t", inFlight, merged)
+		}
+		close(release)
+		if value, err := await(t, first); err != nil || value != "first" {
+			t.Errorf("coalesced result = %q, %v; want first", value, err)
+		}
+		if err := pool.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown: %v", err)
+		}
+	}
+}
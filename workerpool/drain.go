@@ -0,0 +1,43 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+)
+
+// ShutdownMode определяет, что Shutdown делает с заданиями, ещё ждущими в очереди.
+type ShutdownMode int
+
+const (
+	// ShutdownDrain — обработать всю очередь, затем остановиться (по умолчанию).
+	ShutdownDrain ShutdownMode = iota
+	// ShutdownDiscard — дождаться только выполняющихся заданий, очередь отбросить.
+	ShutdownDiscard
+)
+
+func (m ShutdownMode) String() string {
+	switch m {
+	case ShutdownDrain:
+		return "drain"
+	case ShutdownDiscard:
+		return "discard"
+	default:
+		return fmt.Sprintf("ShutdownMode(%d)", int(m))
+	}
+}
+
+// WithShutdownMode задаёт поведение Shutdown для заданий, ждущих в очереди.
+// Режим действует и на группы пула. Drain всегда дообрабатывает очередь независимо от режима.
+func WithShutdownMode(mode ShutdownMode) Option {
+	return func(c *config) {
+		c.shutdownMode = mode
+	}
+}
+
+// Drain останавливает пул, предварительно обработав все задания, ещё ждущие в очереди,
+// — как Shutdown в режиме ShutdownDrain, но независимо от WithShutdownMode.
+// До истечения ctx ни одно принятое задание не теряется: воркеры завершаются, лишь когда очередь пуста.
+// Если ctx истекает раньше, возвращается *ShutdownError с числом необработанных заданий.
+func (p *Pool[T, R]) Drain(ctx context.Context) error {
+	return p.shutdown(ctx, ShutdownDrain)
+}
@@ -0,0 +1,62 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestShutdownModes(t *testing.T) {
+	// run ставит три задания в пул с режимом mode, пока единственный воркер занят первым,
+	// и останавливает пул через stop, отпуская воркера, когда в очереди останется queued заданий.
+	// Возвращает число выполненных заданий.
+	run := func(mode ShutdownMode, stop func(*Pool[int, int]) error, queued int) (int32, []*Future[int]) {
+		var done atomic.Int32
+		started, release := make(chan struct{}, 3), make(chan struct{})
+		pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+			started <- struct{}{}
+			<-release
+			done.Add(1)
+			return job, nil
+		}), WithShutdownMode(mode), WithInitialWorkers(1))
+		var futures []*Future[int]
+		for i := 0; i < 3; i++ {
+			future, err := pool.Submit(i)
+			if err != nil {
+				t.Fatalf("Submit: %v", err)
+			}
+			futures = append(futures, future)
+		}
+		<-started
+		stopped := make(chan error, 1)
+		go func() { stopped <- stop(pool) }()
+		eventually(t, "shutdown started", func() bool { return pool.State() == Draining && pool.QueueLen() == queued })
+		close(release)
+		if err := <-stopped; err != nil {
+			t.Fatalf("%v shutdown: %v", mode, err)
+		}
+		return done.Load(), futures
+	}
+	shutdown := func(p *Pool[int, int]) error { return p.Shutdown(context.Background()) }
+	drain := func(p *Pool[int, int]) error { return p.Drain(context.Background()) }
+
+	// В режиме discard выполняющееся задание доделывается, а очередь отбрасывается
+	done, futures := run(ShutdownDiscard, shutdown, 0)
+	if done != 1 {
+		t.Errorf("discard mode ran %d jobs, want only the running one", done)
+	}
+	for _, future := range futures[1:] {
+		if _, err := await(t, future); !errors.Is(err, ErrJobDropped) {
+			t.Errorf("discarded job %d error = %v, want ErrJobDropped", future.ID(), err)
+		}
+	}
+
+	// Drain дообрабатывает очередь при любом режиме, как и Shutdown по умолчанию
+	if done, _ := run(ShutdownDiscard, drain, 2); done != 3 {
+		t.Errorf("Drain in discard mode ran %d jobs, want 3", done)
+	}
+	if done, _ := run(ShutdownDrain, shutdown, 2); done != 3 {
+		t.Errorf("drain mode ran %d jobs, want 3", done)
+	}
+}
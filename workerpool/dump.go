@@ -0,0 +1,199 @@
+package workerpool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// dumpRecent — сколько последних завершённых заданий попадает в StateDump.
+const dumpRecent = 20
+
+// StateDump — снимок состояния пула для отладки.
+type StateDump struct {
+	BufferSize     int          `json:"buffer_size"`
+	Capacity       int64        `json:"capacity"`
+	StrictShutdown bool         `json:"strict_shutdown"`
+	State          string       `json:"state"`
+	Workers        []WorkerDump `json:"workers"`
+	Queued         int          `json:"queued"`
+	Reserved       int          `json:"reserved"`
+	Pending        int          `json:"pending"`
+	InflightCost   int64        `json:"inflight_cost"`
+
+	// Recent — последние завершённые задания от давних к новым; пусто без WithHistory
+	Recent []OutcomeDump `json:"recent"`
+	Stats  StatsDump     `json:"stats"`
+}
+
+// WorkerDump — состояние воркера в StateDump.
+type WorkerDump struct {
+	ID           int    `json:"id"`
+	Status       string `json:"status"`
+	CurrentJobID JobID  `json:"current_job_id,omitempty"`
+	Processed    uint64 `json:"processed"`
+	BusyTime     string `json:"busy_time"`
+	Uptime       string `json:"uptime"`
+}
+
+// OutcomeDump — итог завершённого задания в StateDump.
+type OutcomeDump struct {
+	ID       JobID     `json:"id"`
+	Type     string    `json:"type,omitempty"`
+	Status   string    `json:"status"`
+	Attempts int       `json:"attempts"`
+	Duration string    `json:"duration"`
+	Error    string    `json:"error,omitempty"`
+	Finished time.Time `json:"finished"`
+}
+
+// StatsDump — показатели Stats в StateDump.
+type StatsDump struct {
+	Processed    uint64 `json:"processed"`
+	Failed       uint64 `json:"failed"`
+	NoOp         uint64 `json:"no_op"`
+	Panics       uint64 `json:"panics"`
+	AvgLatency   string `json:"avg_latency"`
+	P50Latency   string `json:"p50_latency"`
+	P95Latency   string `json:"p95_latency"`
+	P99Latency   string `json:"p99_latency"`
+	AvgQueueWait string `json:"avg_queue_wait"`
+	P95QueueWait string `json:"p95_queue_wait"`
+}
+
+// snapshot собирает StateDump. Состояние пула и воркеров берётся за один короткий захват
+// блокировки, показатели и история — под их собственными блокировками.
+func (p *Pool[T, R]) snapshot() StateDump {
+	now := p.clock.Now()
+	p.mu.Lock()
+	d := StateDump{
+		BufferSize:     p.bufferSize,
+		Capacity:       p.capacity,
+		StrictShutdown: p.strictShutdown,
+		State:          p.state.String(),
+		Queued:         p.queuedLocked(),
+		Reserved:       p.reserved,
+		Pending:        p.pending,
+	}
+	for _, worker := range p.workers {
+		info := worker.info(now)
+		d.Workers = append(d.Workers, WorkerDump{
+			ID:           info.ID,
+			Status:       info.Status.String(),
+			CurrentJobID: info.CurrentJobID,
+			Processed:    info.Processed,
+			BusyTime:     info.BusyTime.String(),
+			Uptime:       info.Uptime.String(),
+		})
+	}
+	p.mu.Unlock()
+
+	d.InflightCost = p.inflightCost.Load()
+	sort.Slice(d.Workers, func(i, j int) bool { return d.Workers[i].ID < d.Workers[j].ID })
+
+	for _, r := range p.History(dumpRecent) {
+		d.Recent = append(d.Recent, OutcomeDump{
+			ID:       r.ID,
+			Type:     r.Type,
+			Status:   r.Status.String(),
+			Attempts: r.Attempts,
+			Duration: r.Duration.String(),
+			Error:    r.Error,
+			Finished: r.Finished,
+		})
+	}
+
+	s := p.Stats()
+	d.Stats = StatsDump{
+		Processed:    s.Processed,
+		Failed:       s.Failed,
+		NoOp:         s.NoOp,
+		Panics:       p.PanicCounter(),
+		AvgLatency:   s.AvgLatency.String(),
+		P50Latency:   s.P50Latency.String(),
+		P95Latency:   s.P95Latency.String(),
+		P99Latency:   s.P99Latency.String(),
+		AvgQueueWait: s.AvgQueueWait.String(),
+		P95QueueWait: s.P95QueueWait.String(),
+	}
+	return d
+}
+
+// DumpState записывает в w читаемый отчёт о пуле: конфигурацию, состояние каждого воркера,
+// очередь, последние завершённые задания (с WithHistory) и показатели Stats.
+// Полезно приложить к баг-репорту во время инцидента.
+func (p *Pool[T, R]) DumpState(w io.Writer) error {
+	d := p.snapshot()
+
+	var workers strings.Builder
+	for _, worker := range d.Workers {
+		fmt.Fprintf(&workers, "#%d %s", worker.ID, worker.Status)
+		if worker.CurrentJobID != 0 {
+			fmt.Fprintf(&workers, " job=%d", worker.CurrentJobID)
+		}
+		fmt.Fprintf(&workers, " processed=%d busy=%s uptime=%s\n", worker.Processed, worker.BusyTime, worker.Uptime)
+	}
+	if len(d.Workers) == 0 {
+		workers.WriteString("(none)\n")
+	}
+
+	var recent strings.Builder
+	for _, r := range d.Recent {
+		fmt.Fprintf(&recent, "#%d %s attempts=%d duration=%s", r.ID, r.Status, r.Attempts, r.Duration)
+		if r.Type != "" {
+			fmt.Fprintf(&recent, " type=%s", r.Type)
+		}
+		if r.Error != "" {
+			fmt.Fprintf(&recent, " error=%q", r.Error)
+		}
+		recent.WriteString("\n")
+	}
+	if len(d.Recent) == 0 {
+		recent.WriteString("(none; enable WithHistory to record outcomes)\n")
+	}
+
+	_, err := fmt.Fprintf(w, `== Config ==
+buffer size:     %d
+capacity:        %d
+strict shutdown: %t
+
+== State ==
+state:   %s
+pending: %d
+
+== Workers ==
+%s
+== Queue ==
+queued:        %d
+reserved:      %d
+inflight cost: %d
+
+== Recent jobs ==
+%s
+== Stats ==
+processed:      %d
+failed:         %d
+no-op:          %d
+panics:         %d
+latency:        avg %s, p50 %s, p95 %s, p99 %s
+queue wait:     avg %s, p95 %s
+`, d.BufferSize, d.Capacity, d.StrictShutdown,
+		d.State, d.Pending,
+		workers.String(),
+		d.Queued, d.Reserved, d.InflightCost,
+		recent.String(),
+		d.Stats.Processed, d.Stats.Failed, d.Stats.NoOp, d.Stats.Panics,
+		d.Stats.AvgLatency, d.Stats.P50Latency, d.Stats.P95Latency, d.Stats.P99Latency,
+		d.Stats.AvgQueueWait, d.Stats.P95QueueWait)
+	return err
+}
+
+// DumpStateJSON записывает тот же снимок, что и DumpState, в формате JSON.
+func (p *Pool[T, R]) DumpStateJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p.snapshot())
+}
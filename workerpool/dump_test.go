@@ -0,0 +1,64 @@
+package workerpool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDumpState(t *testing.T) {
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			if job == 3 {
+				return 0, errors.New("boom")
+			}
+			return job, nil
+		}),
+		WithInitialWorkers(2),
+		WithHistory(10),
+	)
+	defer pool.Shutdown(context.Background())
+
+	for i := 1; i <= 4; i++ {
+		future, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		await(t, future)
+	}
+	eventually(t, "history recorded", func() bool { return len(pool.History(0)) == 4 })
+
+	var text bytes.Buffer
+	if err := pool.DumpState(&text); err != nil {
+		t.Fatalf("DumpState: %v", err)
+	}
+	for _, want := range []string{
+		"== Config ==", "== State ==", "== Workers ==", "== Queue ==", "== Recent jobs ==", "== Stats ==",
+		"#0 idle", "#1 idle", `error="boom"`, "processed:      4", "failed:         1",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("dump lacks %q:\n%s", want, text.String())
+		}
+	}
+
+	var js bytes.Buffer
+	if err := pool.DumpStateJSON(&js); err != nil {
+		t.Fatalf("DumpStateJSON: %v", err)
+	}
+	var d StateDump
+	if err := json.Unmarshal(js.Bytes(), &d); err != nil {
+		t.Fatalf("invalid JSON dump: %v\n%s", err, js.String())
+	}
+	if len(d.Workers) != 2 || d.Workers[0].Status != "idle" {
+		t.Errorf("workers = %+v, want two idle workers", d.Workers)
+	}
+	if len(d.Recent) != 4 {
+		t.Errorf("got %d recent outcomes, want 4", len(d.Recent))
+	}
+	if d.Stats.Processed != 4 || d.Stats.Failed != 1 {
+		t.Errorf("stats = %+v, want 4 processed and 1 failed", d.Stats)
+	}
+}
@@ -0,0 +1,149 @@
+package workerpool
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// errGroupIdleTimeout — сколько воркер ErrGroup ждёт новой функции, прежде чем завершиться.
+const errGroupIdleTimeout = time.Second
+
+// ErrGroup — замена golang.org/x/sync/errgroup.Group поверх пула: функции из Go выполняются
+// воркерами пула, поэтому на них действуют WithRetry, WithMetricsObserver, перехват паник
+// и другие опции, а код на errgroup переносится без переписывания.
+// Воркеры запускаются по требованию, как в WithMaxWorkers, не больше SetLimit одновременно.
+type ErrGroup struct {
+	pool   *Pool[func() error, struct{}]
+	cancel context.CancelCauseFunc
+
+	// sem ограничивает число незавершённых функций (nil — без ограничения)
+	sem chan struct{}
+	wg  sync.WaitGroup
+
+	errOnce sync.Once
+	err     error
+}
+
+// NewErrGroup создаёт ErrGroup с пулом, настроенным опциями opts. Обработчик задаёт сам ErrGroup.
+func NewErrGroup(opts ...Option) *ErrGroup {
+	base := []Option{
+		WithHandler(func(ctx context.Context, f func() error) (struct{}, error) {
+			return struct{}{}, f()
+		}),
+		WithMaxWorkers(math.MaxInt32),
+		WithWorkerIdleTimeout(errGroupIdleTimeout),
+	}
+	return &ErrGroup{pool: NewPool[func() error, struct{}](append(base, opts...)...)}
+}
+
+// NewErrGroupWithContext — аналог errgroup.WithContext: возвращает ErrGroup и производный
+// от ctx контекст, который отменяется, когда первая функция вернёт ошибку или вернётся Wait.
+func NewErrGroupWithContext(ctx context.Context, opts ...Option) (*ErrGroup, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := NewErrGroup(opts...)
+	g.cancel = cancel
+	return g, ctx
+}
+
+// Pool возвращает пул ErrGroup, например для Stats или Subscribe.
+func (g *ErrGroup) Pool() *Pool[func() error, struct{}] {
+	return g.pool
+}
+
+// SetLimit ограничивает число одновременно незавершённых функций группы значением n;
+// отрицательное n снимает ограничение. Как и в errgroup, менять ограничение, пока функции
+// выполняются, нельзя — тогда SetLimit паникует.
+func (g *ErrGroup) SetLimit(n int) {
+	if len(g.sem) != 0 {
+		panic("workerpool: ErrGroup.SetLimit called while functions are running")
+	}
+	workers := math.MaxInt32
+	if n < 0 {
+		g.sem = nil
+	} else {
+		g.sem = make(chan struct{}, n)
+		workers = max(1, n)
+	}
+	g.pool.mu.Lock()
+	g.pool.maxWorkers = workers
+	g.pool.mu.Unlock()
+}
+
+// Go выполняет f в пуле. При ограничении SetLimit вызов ждёт, пока завершится одна из
+// запущенных функций; если очередь пула заполнена, ждёт места в ней.
+// Первая ненулевая ошибка функций возвращается из Wait и отменяет контекст NewErrGroupWithContext.
+func (g *ErrGroup) Go(f func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.wg.Add(1)
+	future, err := g.pool.SubmitContext(context.Background(), f)
+	g.track(future, err)
+}
+
+// TryGo выполняет f в пуле, только если это можно сделать без ожидания: ограничение SetLimit
+// не исчерпано и в очереди пула есть место. Возвращает, была ли функция запущена.
+func (g *ErrGroup) TryGo(f func() error) bool {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	future, err := g.pool.Submit(f)
+	if err != nil {
+		g.done()
+		return false
+	}
+	g.wg.Add(1)
+	g.track(future, nil)
+	return true
+}
+
+// Wait ждёт завершения всех функций группы и возвращает первую ненулевую ошибку.
+// Пул после Wait продолжает работать: группу можно использовать снова.
+func (g *ErrGroup) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(g.err)
+	}
+	return g.err
+}
+
+// track дожидается итога функции и учитывает его в группе.
+func (g *ErrGroup) track(future *Future[struct{}], err error) {
+	if err != nil {
+		// Пул остановлен — функция не будет выполнена
+		g.fail(err)
+		g.done()
+		g.wg.Done()
+		return
+	}
+	go func() {
+		defer g.wg.Done()
+		if err := future.Err(); err != nil {
+			g.fail(err)
+		}
+		g.done()
+	}()
+}
+
+// fail запоминает первую ошибку группы и отменяет её контекст.
+func (g *ErrGroup) fail(err error) {
+	g.errOnce.Do(func() {
+		g.err = err
+		if g.cancel != nil {
+			g.cancel(err)
+		}
+	})
+}
+
+// done освобождает место в ограничении SetLimit.
+func (g *ErrGroup) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+}
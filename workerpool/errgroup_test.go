@@ -0,0 +1,114 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestErrGroup(t *testing.T) {
+	boom := errors.New("boom")
+	g, ctx := NewErrGroupWithContext(context.Background())
+	defer g.Pool().Shutdown(context.Background())
+
+	var ran atomic.Int32
+	for i := 0; i < 5; i++ {
+		g.Go(func() error {
+			ran.Add(1)
+			return nil
+		})
+	}
+	// Ошибка одной функции отменяет контекст группы, и остальные видят это через ctx
+	g.Go(func() error { return boom })
+	g.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err := g.Wait(); !errors.Is(err, boom) {
+		t.Fatalf("Wait = %v, want the first error", err)
+	}
+	if n := ran.Load(); n != 5 {
+		t.Errorf("%d functions ran, want all 5", n)
+	}
+	if cause := context.Cause(ctx); !errors.Is(cause, boom) {
+		t.Errorf("context cause = %v, want the first error", cause)
+	}
+
+	// Без ошибок Wait всё равно отменяет контекст, как errgroup
+	g, ctx = NewErrGroupWithContext(context.Background())
+	defer g.Pool().Shutdown(context.Background())
+	g.Go(func() error { return nil })
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait = %v, want nil", err)
+	}
+	if ctx.Err() == nil {
+		t.Error("context not cancelled after Wait")
+	}
+}
+
+func TestErrGroupSetLimit(t *testing.T) {
+	g := NewErrGroup()
+	defer g.Pool().Shutdown(context.Background())
+	g.SetLimit(2)
+
+	var (
+		mu            sync.Mutex
+		running, peak int
+	)
+	release := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		g.Go(func() error {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+			<-release
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	// Ограничение исчерпано: TryGo отказывается, Go ждёт, а менять ограничение нельзя
+	if g.TryGo(func() error { return nil }) {
+		t.Error("TryGo over the limit started the function")
+	}
+	started := make(chan struct{})
+	go func() {
+		g.Go(func() error { return nil })
+		close(started)
+	}()
+	select {
+	case <-started:
+		t.Fatal("Go over the limit did not wait")
+	case <-time.After(20 * time.Millisecond):
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("SetLimit with running functions did not panic")
+			}
+		}()
+		g.SetLimit(4)
+	}()
+
+	close(release)
+	<-started
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+	if peak > 2 {
+		t.Errorf("%d functions ran at once, want at most 2", peak)
+	}
+	if n := g.Pool().Stats().Workers; n > 2 {
+		t.Errorf("pool started %d workers, want at most the limit of 2", n)
+	}
+	if !g.TryGo(func() error { return nil }) {
+		t.Error("TryGo after Wait did not start the function")
+	}
+	g.Wait()
+}
@@ -0,0 +1,119 @@
+package workerpool
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventType — этап жизненного цикла задания.
+type EventType int
+
+const (
+	EventEnqueued  EventType = iota // задание принято в очередь
+	EventStarted                    // воркер начал обработку
+	EventSucceeded                  // обработчик завершился без ошибки
+	EventFailed                     // обработчик завершился ошибкой (после всех повторов)
+	EventRetried                    // попытка упала, задание будет повторено
+	EventCancelled                  // задание отменено через Cancel, пока ждало в очереди
+	EventDropped                    // задание отброшено из очереди: остановка пула или вытеснение
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventEnqueued:
+		return "enqueued"
+	case EventStarted:
+		return "started"
+	case EventSucceeded:
+		return "succeeded"
+	case EventFailed:
+		return "failed"
+	case EventRetried:
+		return "retried"
+	case EventCancelled:
+		return "cancelled"
+	case EventDropped:
+		return "dropped"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// Event — событие жизненного цикла задания.
+type Event struct {
+	Type    EventType
+	JobID   JobID
+	Job     any
+	Attempt int   // номер попытки для EventRetried, EventSucceeded и EventFailed
+	Err     error // ошибка для EventFailed, EventRetried и EventDropped
+	Time    time.Time
+}
+
+// subscription — подписчик Subscribe.
+type subscription struct {
+	id int
+	fn func(Event)
+}
+
+// Subscribe регистрирует функцию, получающую события всех заданий пула,
+// и возвращает функцию отписки. fn вызывается синхронно в горутине, где произошло событие
+// (отправителя или воркера), поэтому должна быстро возвращать управление.
+func (p *Pool[T, R]) Subscribe(fn func(Event)) (unsubscribe func()) {
+	p.subMu.Lock()
+	defer p.subMu.Unlock()
+
+	p.nextSub++
+	id := p.nextSub
+	subs := append(p.loadSubs(), subscription{id: id, fn: fn})
+	p.subs.Store(&subs)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.subMu.Lock()
+			defer p.subMu.Unlock()
+
+			var kept []subscription
+			for _, s := range p.loadSubs() {
+				if s.id != id {
+					kept = append(kept, s)
+				}
+			}
+			p.subs.Store(&kept)
+		})
+	}
+}
+
+// loadSubs возвращает копию списка подписчиков.
+func (p *Pool[T, R]) loadSubs() []subscription {
+	if subs := p.subs.Load(); subs != nil {
+		return append([]subscription(nil), *subs...)
+	}
+	return nil
+}
+
+// emit рассылает событие задания t подписчикам. Вызывается вне p.mu.
+// Итоговые события заодно попадают в историю WithHistory и WithAuditSink.
+func (p *Pool[T, R]) emit(typ EventType, t *task[T, R], attempt int, err error) {
+	switch typ {
+	case EventSucceeded, EventFailed, EventCancelled, EventDropped:
+		p.audit(typ, t, attempt, err, p.clock.Now())
+	}
+	subs := p.subs.Load()
+	if subs == nil || len(*subs) == 0 {
+		return
+	}
+	ev := Event{Type: typ, JobID: t.id, Job: t.job, Attempt: attempt, Err: err, Time: p.clock.Now()}
+	for _, s := range *subs {
+		s.fn(ev)
+	}
+}
+
+// eventHub — подписчики событий; copy-on-write, чтобы рассылка не брала блокировок.
+type eventHub struct {
+	subMu   sync.Mutex
+	nextSub int
+	subs    atomic.Pointer[[]subscription]
+}
@@ -0,0 +1,91 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	var mu sync.Mutex
+	events := map[JobID][]EventType{}
+	attempts := 0
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			if job == "flaky" {
+				mu.Lock()
+				attempts++
+				first := attempts == 1
+				mu.Unlock()
+				if first {
+					return "", errors.New("try again")
+				}
+			}
+			if job == "bad" {
+				return "", errors.New("boom")
+			}
+			return job, nil
+		}),
+		WithRetry(RetryPolicy{MaxAttempts: 2, Backoff: func(int) time.Duration { return 0 }}),
+	)
+	unsubscribe := pool.Subscribe(func(e Event) {
+		mu.Lock()
+		events[e.JobID] = append(events[e.JobID], e.Type)
+		mu.Unlock()
+	})
+
+	ok, _ := pool.Submit("ok")
+	flaky, _ := pool.Submit("flaky")
+	bad, _ := pool.Submit("bad")
+	cancelled, _ := pool.Submit("cancelled")
+	pool.Cancel(cancelled.ID())
+	pool.AddWorker()
+	for _, f := range []*Future[string]{ok, flaky, bad} {
+		await(t, f)
+	}
+	// На паузе задание остаётся в очереди и отбрасывается при остановке
+	pool.Pause()
+	dropped, _ := pool.Submit("dropped")
+	pool.ShutdownNow()
+	unsubscribe()
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := map[JobID][]EventType{
+		ok.ID():        {EventEnqueued, EventStarted, EventSucceeded},
+		flaky.ID():     {EventEnqueued, EventStarted, EventRetried, EventSucceeded},
+		bad.ID():       {EventEnqueued, EventStarted, EventRetried, EventFailed},
+		cancelled.ID(): {EventEnqueued, EventCancelled},
+		dropped.ID():   {EventEnqueued, EventDropped},
+	}
+	for id, types := range want {
+		if !slices.Equal(events[id], types) {
+			t.Errorf("job %d events = %v, want %v", id, events[id], types)
+		}
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]))
+	defer pool.ShutdownNow()
+	var mu sync.Mutex
+	var got []EventType
+	unsubscribe := pool.Subscribe(func(e Event) {
+		mu.Lock()
+		got = append(got, e.Type)
+		mu.Unlock()
+	})
+
+	pool.SendJob(1)
+	unsubscribe()
+	unsubscribe()
+	pool.SendJob(2)
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.Equal(got, []EventType{EventEnqueued}) {
+		t.Errorf("events = %v, want only the event before unsubscribe", got)
+	}
+}
@@ -0,0 +1,42 @@
+package workerpool_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+)
+
+// Пул с собственным обработчиком вместо встроенной заглушки.
+func ExampleNewPool() {
+	pool := workerpool.NewPool[string, string](
+		workerpool.WithHandler(func(ctx context.Context, job string) (string, error) {
+			return strings.ToUpper(job), nil
+		}),
+		workerpool.WithInitialWorkers(2),
+	)
+
+	var mu sync.Mutex
+	var results []string
+	pool.OnResult(func(res workerpool.Result[string, string]) {
+		mu.Lock()
+		defer mu.Unlock()
+		results = append(results, res.Value)
+	})
+	for _, job := range []string{"alpha", "beta", "gamma"} {
+		if err := pool.SendJob(job); err != nil {
+			fmt.Println("SendJob:", err)
+		}
+	}
+	// Shutdown дожидается обработки всех принятых заданий
+	if err := pool.Shutdown(context.Background()); err != nil {
+		fmt.Println("Shutdown:", err)
+	}
+
+	sort.Strings(results)
+	fmt.Println(results)
+	// Output: [ALPHA BETA GAMMA]
+}
@@ -0,0 +1,101 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFailedFast — пул в режиме WithFailFast перестал принимать задания после ошибки обработчика.
+var ErrFailedFast = errors.New("pool stopped accepting jobs after a handler error")
+
+// JobError — ошибка обработчика вместе с заданием, на котором она произошла.
+type JobError struct {
+	ID  JobID
+	Job any
+	Err error
+}
+
+func (e *JobError) Error() string {
+	return fmt.Sprintf("job %d: %v", e.ID, e.Err)
+}
+
+func (e *JobError) Unwrap() error {
+	return e.Err
+}
+
+// WithErrorCollection включает сбор ошибок обработчика для Errors: сохраняются первые max
+// ошибок (max меньше 1 — все). Ошибки заданий, завершившихся после всех повторов, собираются
+// в порядке завершения. Для долгоживущего пула, обрабатывающего задания пачками,
+// сбор начинают заново через ResetErrors.
+func WithErrorCollection(max int) Option {
+	return func(c *config) {
+		c.collectErrors = true
+		c.maxErrors = max
+	}
+}
+
+// Errors возвращает собранные ошибки обработчика (*JobError). Без WithErrorCollection — nil.
+func (p *Pool[T, R]) Errors() []error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.errs) == 0 {
+		return nil
+	}
+	return append([]error(nil), p.errs...)
+}
+
+// FirstError возвращает первую ошибку обработчика (*JobError) с момента создания пула
+// или последнего ResetErrors, nil — если ошибок не было. Работает и без WithErrorCollection.
+func (p *Pool[T, R]) FirstError() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.firstErr == nil {
+		return nil
+	}
+	return p.firstErr
+}
+
+// ResetErrors очищает собранные ошибки и FirstError. Остановку приёма заданий
+// в режиме WithFailFast сбросить нельзя.
+func (p *Pool[T, R]) ResetErrors() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.errs = nil
+	p.firstErr = nil
+}
+
+// recordFailure запоминает ошибку обработчика для FirstError, Errors и WithFailFast.
+func (p *Pool[T, R]) recordFailure(t *task[T, R], err error) {
+	jobErr := &JobError{ID: t.id, Job: t.job, Err: err}
+
+	p.mu.Lock()
+	if p.firstErr == nil {
+		p.firstErr = jobErr
+	}
+	if p.collectErrors && (p.maxErrors < 1 || len(p.errs) < p.maxErrors) {
+		p.errs = append(p.errs, jobErr)
+	}
+	p.mu.Unlock()
+
+	if !p.failFast {
+		return
+	}
+	p.failOnce.Do(func() {
+		p.mu.Lock()
+		p.failErr = err
+		p.mu.Unlock()
+		close(p.failed)
+	})
+}
+
+// failedFastLocked возвращает ErrFailedFast, если пул в режиме WithFailFast уже встретил
+// ошибку обработчика. Вызывается под p.mu.
+func (p *Pool[T, R]) failedFastLocked() error {
+	if p.failErr == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrFailedFast, p.failErr)
+}
@@ -0,0 +1,86 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// failNegative — обработчик, отклоняющий отрицательные задания.
+func failNegative(ctx context.Context, job int) (int, error) {
+	if job < 0 {
+		return 0, fmt.Errorf("negative job %d", job)
+	}
+	return job, nil
+}
+
+func TestErrorCollection(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(failNegative), WithErrorCollection(2), WithInitialWorkers(1))
+	defer pool.Shutdown(context.Background())
+
+	submit := func(jobs ...int) {
+		t.Helper()
+		for _, job := range jobs {
+			future, err := pool.Submit(job)
+			if err != nil {
+				t.Fatalf("Submit: %v", err)
+			}
+			await(t, future)
+		}
+	}
+	submit(1, -1, 2, -2, -3)
+
+	// Сохраняются только первые две ошибки, каждая вместе со своим заданием
+	errs := pool.Errors()
+	if len(errs) != 2 {
+		t.Fatalf("Errors = %v, want 2 errors", errs)
+	}
+	for i, want := range []int{-1, -2} {
+		var jobErr *JobError
+		if !errors.As(errs[i], &jobErr) || jobErr.Job != want {
+			t.Errorf("Errors()[%d] = %v, want the error of job %d", i, errs[i], want)
+		}
+	}
+	if err := pool.FirstError(); err != errs[0] {
+		t.Errorf("FirstError = %v, want %v", err, errs[0])
+	}
+
+	// После ResetErrors сбор начинается заново
+	pool.ResetErrors()
+	if errs, first := pool.Errors(), pool.FirstError(); errs != nil || first != nil {
+		t.Fatalf("after ResetErrors Errors = %v, FirstError = %v; want nil", errs, first)
+	}
+	submit(3, -4)
+	var jobErr *JobError
+	if err := pool.FirstError(); !errors.As(err, &jobErr) || jobErr.Job != -4 {
+		t.Errorf("FirstError after ResetErrors = %v, want the error of job -4", err)
+	}
+	if errs := pool.Errors(); len(errs) != 1 {
+		t.Errorf("Errors after ResetErrors = %v, want 1 error", errs)
+	}
+}
+
+func TestFailFastRejectsJobs(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(failNegative), WithFailFast(), WithInitialWorkers(1))
+	defer pool.Shutdown(context.Background())
+
+	ok, err := pool.Submit(1)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if _, err := await(t, ok); err != nil {
+		t.Fatalf("job 1: %v", err)
+	}
+	failed, err := pool.Submit(-1)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	_, handlerErr := await(t, failed)
+
+	// Первая же ошибка останавливает приём заданий, и ResetErrors этого не отменяет
+	pool.ResetErrors()
+	if _, err := pool.Submit(2); !errors.Is(err, ErrFailedFast) || !errors.Is(err, handlerErr) {
+		t.Errorf("Submit after a handler error = %v, want ErrFailedFast wrapping %v", err, handlerErr)
+	}
+}
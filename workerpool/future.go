@@ -0,0 +1,51 @@
+package workerpool
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrJobDropped получают задания, которые остались в очереди при остановке пула и не были обработаны.
+var ErrJobDropped = errors.New("job dropped: pool shut down before processing")
+
+// Future — отложенный результат задания, отправленного через Submit.
+type Future[R any] struct {
+	// id атомарен: Handoff меняет его на ID в пуле-преемнике, пока владелец Future может читать ID
+	id    atomic.Uint64
+	done  chan struct{}
+	value R
+	err   error
+}
+
+func newFuture[R any]() *Future[R] {
+	return &Future[R]{done: make(chan struct{})}
+}
+
+// ID возвращает идентификатор задания, например для Pool.Cancel.
+func (f *Future[R]) ID() JobID {
+	return JobID(f.id.Load())
+}
+
+// Done возвращает канал, который закрывается после завершения задания.
+func (f *Future[R]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Result ждёт завершения задания и возвращает его результат.
+func (f *Future[R]) Result() R {
+	<-f.done
+	return f.value
+}
+
+// Err ждёт завершения задания и возвращает ошибку обработчика.
+func (f *Future[R]) Err() error {
+	<-f.done
+	return f.err
+}
+
+// resolve сохраняет итог задания и будит ожидающих. Вызывается ровно один раз.
+func (f *Future[R]) resolve(value R, err error) {
+	f.value = value
+	f.err = err
+	close(f.done)
+}
@@ -0,0 +1,54 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSubmitFuture(t *testing.T) {
+	boom := errors.New("boom")
+	release := make(chan struct{})
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			<-release
+			if job == "fail" {
+				return "", boom
+			}
+			return job + " done", nil
+		}),
+		WithInitialWorkers(2),
+	)
+	defer pool.Shutdown(context.Background())
+
+	ok, err := pool.Submit("ok")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	failed, err := pool.Submit("fail")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if ok.ID() == 0 || ok.ID() == failed.ID() {
+		t.Errorf("job IDs = %d and %d, want distinct non-zero", ok.ID(), failed.ID())
+	}
+
+	// До завершения задания Done не закрыт
+	select {
+	case <-ok.Done():
+		t.Fatal("Done closed before the job finished")
+	default:
+	}
+	close(release)
+
+	if value, err := await(t, ok); err != nil || value != "ok done" {
+		t.Errorf("ok job = %q, %v", value, err)
+	}
+	if _, err := await(t, failed); !errors.Is(err, boom) {
+		t.Errorf("failed job error = %v, want %v", err, boom)
+	}
+	// Result и Err можно читать повторно
+	if !errors.Is(failed.Err(), boom) || ok.Result() != "ok done" {
+		t.Error("repeated Result/Err returned different values")
+	}
+}
@@ -0,0 +1,217 @@
+package workerpool
+
+import (
+	"context"
+	"math"
+	"runtime"
+	runtimemetrics "runtime/metrics"
+	"sync"
+	"time"
+)
+
+// GovernorConfig задаёт пороги WithGovernor. Нулевой порог не проверяется.
+type GovernorConfig struct {
+	// MaxHeapInUse — порог занятой кучи в байтах (runtime.MemStats.HeapInuse)
+	MaxHeapInUse uint64
+
+	// MaxSchedLatency — порог задержки планировщика (90-й процентиль времени, которое
+	// готовые к работе горутины ждут свободного P). Рост задержки означает, что
+	// GOMAXPROCS процессоров уже не справляются с нагрузкой.
+	MaxSchedLatency time.Duration
+
+	// MinConcurrency — ниже этого параллелизм не снижается (по умолчанию 1)
+	MinConcurrency int
+
+	// Interval — период замеров (по умолчанию 500 мс)
+	Interval time.Duration
+}
+
+// WithGovernor включает регулятор нагрузки: пул периодически замеряет кучу и загрузку
+// планировщика и, пока пороги превышены, вдвое снижает число одновременно выполняемых
+// заданий на каждом замере, а после спада давления возвращает его по одному.
+// Воркеры при этом не снимаются — лишние просто ждут, не беря заданий из очереди.
+// Помогает не упасть с OOM во время всплеска крупных заданий.
+func WithGovernor(cfg GovernorConfig) Option {
+	return func(c *config) {
+		if cfg.MinConcurrency < 1 {
+			cfg.MinConcurrency = 1
+		}
+		if cfg.Interval <= 0 {
+			cfg.Interval = defaultAutoscaleInterval
+		}
+		c.governor = &governor{cfg: cfg, changed: make(chan struct{}), freed: make(chan struct{})}
+	}
+}
+
+// governor ограничивает параллелизм по замерам WithGovernor.
+type governor struct {
+	cfg GovernorConfig
+
+	mu     sync.Mutex
+	limit  int // допустимое число заданий в работе (0 — без ограничения)
+	active int
+
+	// changed закрывается при смене ограничения, freed — при возврате разрешения
+	changed chan struct{}
+	freed   chan struct{}
+
+	// prev — прошлый снимок гистограммы задержек планировщика для подсчёта разницы
+	prev []uint64
+}
+
+// runGovernor периодически пересматривает ограничение до остановки пула.
+func (p *Pool[T, R]) runGovernor() {
+	g := p.governor
+	ticker := time.NewTicker(g.cfg.Interval)
+	defer ticker.Stop()
+
+	samples := []runtimemetrics.Sample{{Name: "/sched/latencies:seconds"}}
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+
+		var heap uint64
+		if g.cfg.MaxHeapInUse > 0 {
+			var m runtime.MemStats
+			runtime.ReadMemStats(&m)
+			heap = m.HeapInuse
+		}
+		var latency time.Duration
+		if g.cfg.MaxSchedLatency > 0 {
+			runtimemetrics.Read(samples)
+			latency = g.schedLatency(samples[0].Value)
+		}
+		pressure := (g.cfg.MaxHeapInUse > 0 && heap > g.cfg.MaxHeapInUse) ||
+			(g.cfg.MaxSchedLatency > 0 && latency > g.cfg.MaxSchedLatency)
+
+		p.mu.Lock()
+		workers := p.liveWorkersLocked()
+		p.mu.Unlock()
+
+		if limit, changed := g.adjust(pressure, workers); changed {
+			p.logger.Info("governor changed concurrency limit", "limit", limit, "heap_in_use", heap, "sched_latency", latency)
+		}
+	}
+}
+
+// adjust пересчитывает ограничение по итогам замера. Возвращает новое ограничение
+// и признак того, что оно изменилось.
+func (g *governor) adjust(pressure bool, workers int) (int, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	old := g.limit
+	switch {
+	case pressure:
+		current := g.limit
+		if current == 0 {
+			current = max(g.active, workers)
+		}
+		g.limit = max(g.cfg.MinConcurrency, current/2)
+	case g.limit > 0:
+		g.limit++
+		if g.limit >= workers {
+			// Давление спало полностью — ограничение снимается
+			g.limit = 0
+		}
+	}
+	if g.limit == old {
+		return g.limit, false
+	}
+	close(g.changed)
+	g.changed = make(chan struct{})
+	return g.limit, true
+}
+
+// schedLatency возвращает 90-й процентиль задержки планировщика с прошлого замера.
+func (g *governor) schedLatency(v runtimemetrics.Value) time.Duration {
+	if v.Kind() != runtimemetrics.KindFloat64Histogram {
+		return 0
+	}
+	h := v.Float64Histogram()
+	delta := make([]uint64, len(h.Counts))
+	var total uint64
+	for i, c := range h.Counts {
+		delta[i] = c
+		if i < len(g.prev) {
+			delta[i] -= g.prev[i]
+		}
+		total += delta[i]
+	}
+	g.prev = append(g.prev[:0], h.Counts...)
+	if total == 0 {
+		return 0
+	}
+
+	threshold := uint64(math.Ceil(float64(total) * 0.9))
+	var seen uint64
+	for i, c := range delta {
+		seen += c
+		if seen >= threshold {
+			// Верхняя граница корзины; у последней корзины она бесконечна
+			bound := h.Buckets[i+1]
+			if math.IsInf(bound, 1) {
+				bound = h.Buckets[i]
+			}
+			return time.Duration(bound * float64(time.Second))
+		}
+	}
+	return 0
+}
+
+// acquire ждёт, пока ограничение позволит взять задание. Возвращает канал,
+// закрывающийся при следующей смене ограничения: воркер, ждущий задание
+// с разрешением на руках, должен тогда вернуть его и получить заново.
+// Второе значение false, если ожидание прервано ctx или stop.
+func (g *governor) acquire(ctx context.Context, stop <-chan struct{}) (<-chan struct{}, bool) {
+	for {
+		g.mu.Lock()
+		if g.limit == 0 || g.active < g.limit {
+			g.active++
+			changed := g.changed
+			g.mu.Unlock()
+			return changed, true
+		}
+		changed, freed := g.changed, g.freed
+		g.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-freed:
+		case <-ctx.Done():
+			return nil, false
+		case <-stop:
+			return nil, false
+		}
+	}
+}
+
+func (g *governor) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.active--
+	if g.limit > 0 {
+		close(g.freed)
+		g.freed = make(chan struct{})
+	}
+}
+
+// governorAcquire ждёт разрешения регулятора нагрузки перед тем, как воркер возьмёт задание.
+// Без WithGovernor возвращает nil-канал, который никогда не закрывается.
+func (p *Pool[T, R]) governorAcquire(ctx context.Context, stop <-chan struct{}) (<-chan struct{}, bool) {
+	if p.governor == nil {
+		return nil, true
+	}
+	return p.governor.acquire(ctx, stop)
+}
+
+// governorRelease возвращает разрешение, полученное через governorAcquire.
+func (p *Pool[T, R]) governorRelease() {
+	if p.governor != nil {
+		p.governor.release()
+	}
+}
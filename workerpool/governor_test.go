@@ -0,0 +1,88 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGovernorAdjust(t *testing.T) {
+	g := &governor{cfg: GovernorConfig{MinConcurrency: 2}, changed: make(chan struct{}), freed: make(chan struct{})}
+	const workers = 8
+
+	// Под давлением ограничение вдвое снижается на каждом замере, но не ниже минимума
+	for _, want := range []int{4, 2, 2} {
+		if limit, _ := g.adjust(true, workers); limit != want {
+			t.Fatalf("limit under pressure = %d, want %d", limit, want)
+		}
+	}
+	// После спада давления ограничение растёт по одному и снимается, дойдя до числа воркеров
+	for _, want := range []int{3, 4, 5, 6, 7, 0} {
+		if limit, _ := g.adjust(false, workers); limit != want {
+			t.Fatalf("limit after pressure = %d, want %d", limit, want)
+		}
+	}
+	changed := g.changed
+	if _, ok := g.adjust(false, workers); ok {
+		t.Error("adjust without pressure or limit reported a change")
+	}
+	select {
+	case <-changed:
+		t.Error("changed closed although the limit stayed the same")
+	default:
+	}
+}
+
+func TestGovernorThrottlesUnderPressure(t *testing.T) {
+	var (
+		mu            sync.Mutex
+		running, peak int
+	)
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return job, nil
+	}), WithInitialWorkers(4), WithGovernor(GovernorConfig{
+		// Куча всегда больше одного байта, так что давление не спадает
+		MaxHeapInUse:   1,
+		MinConcurrency: 2,
+		Interval:       time.Millisecond,
+	}))
+	defer pool.Shutdown(context.Background())
+
+	eventually(t, "limit lowered to the minimum", func() bool {
+		pool.governor.mu.Lock()
+		defer pool.governor.mu.Unlock()
+		return pool.governor.limit == 2
+	})
+	var futures []*Future[int]
+	for i := 0; i < 12; i++ {
+		future, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		futures = append(futures, future)
+	}
+	for _, future := range futures {
+		if _, err := await(t, future); err != nil {
+			t.Fatalf("job %d: %v", future.ID(), err)
+		}
+	}
+
+	// Воркеры не снимаются, но одновременно выполняется не больше MinConcurrency заданий
+	if n := pool.Stats().Workers; n != 4 {
+		t.Errorf("workers = %d, want all 4 kept", n)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if peak > 2 {
+		t.Errorf("%d jobs ran at once, want at most 2", peak)
+	}
+}
@@ -0,0 +1,105 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Group возвращает именованную группу пула — отдельную очередь со своими воркерами,
+// буфером и при желании обработчиком, у которой общий с пулом жизненный цикл:
+// Shutdown и ShutdownNow пула останавливают и все его группы.
+//
+// При первом вызове группа создаётся с опциями opts поверх унаследованных от пула
+// обработчика WithHandler (если он задан) и логгера; при последующих возвращается уже созданная, а opts игнорируются.
+// Группа — обычный *Pool, поэтому воркеры в неё добавляются через AddWorker или WithInitialWorkers.
+// Группа, созданная после начала остановки пула, сразу остановлена.
+func (p *Pool[T, R]) Group(name string, opts ...Option) *Pool[T, R] {
+	p.mu.Lock()
+	if g, exists := p.groups[name]; exists {
+		p.mu.Unlock()
+		return g
+	}
+	p.mu.Unlock()
+
+	inherited := []Option{WithLogger(p.logger.With("group", name))}
+	if p.hasHandler {
+		inherited = append(inherited, WithHandler(p.handler))
+	}
+	g := NewPool[T, R](append(inherited, opts...)...)
+
+	p.mu.Lock()
+	if existing, exists := p.groups[name]; exists {
+		// Группу успели создать параллельно
+		p.mu.Unlock()
+		g.ShutdownNow()
+		return existing
+	}
+	if p.groups == nil {
+		p.groups = make(map[string]*Pool[T, R])
+	}
+	p.groups[name] = g
+	stopping := p.state == Draining || p.state == Closed
+	p.mu.Unlock()
+
+	if stopping {
+		g.ShutdownNow()
+	}
+	return g
+}
+
+// Groups возвращает имена групп пула в алфавитном порядке.
+func (p *Pool[T, R]) Groups() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := make([]string, 0, len(p.groups))
+	for name := range p.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// groupList возвращает группы пула с их именами.
+func (p *Pool[T, R]) groupList() map[string]*Pool[T, R] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	groups := make(map[string]*Pool[T, R], len(p.groups))
+	for name, g := range p.groups {
+		groups[name] = g
+	}
+	return groups
+}
+
+// shutdownGroups параллельно останавливает группы пула в режиме mode.
+// Возвращаемый канал получает объединённую ошибку групп (nil, если все остановились без ошибок).
+func (p *Pool[T, R]) shutdownGroups(ctx context.Context, mode ShutdownMode) <-chan error {
+	result := make(chan error, 1)
+	groups := p.groupList()
+
+	go func() {
+		var (
+			mu   sync.Mutex
+			errs []error
+			wg   sync.WaitGroup
+		)
+		for name, g := range groups {
+			wg.Add(1)
+			go func(name string, g *Pool[T, R]) {
+				defer wg.Done()
+				if err := g.shutdown(ctx, mode); err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("group %q: %w", name, err))
+					mu.Unlock()
+				}
+			}(name, g)
+		}
+		wg.Wait()
+		result <- errors.Join(errs...)
+	}()
+	return result
+}
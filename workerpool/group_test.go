@@ -0,0 +1,76 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGroupInheritsHandler(t *testing.T) {
+	pool := NewPool[string, string](WithHandler(echo[string]))
+	defer pool.Shutdown(context.Background())
+
+	group := pool.Group("reports", WithInitialWorkers(1))
+	future, err := group.Submit("quarterly")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if value, err := await(t, future); err != nil || value != "quarterly" {
+		t.Errorf("group result = %q, %v", value, err)
+	}
+}
+
+func TestGroupWithoutHandler(t *testing.T) {
+	pool := NewPool[string, string](WithNamedHandler("echo", echo[string]))
+	defer pool.Shutdown(context.Background())
+
+	// Пул без WithHandler не даёт группе обработчика, и задания без типа отклоняются сразу
+	group := pool.Group("reports", WithInitialWorkers(1))
+	if err := group.SendJob("plain"); !errors.Is(err, ErrNoHandler) {
+		t.Errorf("group SendJob error = %v, want ErrNoHandler", err)
+	}
+	if _, err := group.Submit("plain"); !errors.Is(err, ErrNoHandler) {
+		t.Errorf("group Submit error = %v, want ErrNoHandler", err)
+	}
+
+	// Собственный обработчик группы по-прежнему действует
+	own := pool.Group("own", WithHandler(echo[string]), WithInitialWorkers(1))
+	future, err := own.Submit("plain")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if value, err := await(t, future); err != nil || value != "plain" {
+		t.Errorf("group result = %q, %v", value, err)
+	}
+}
+
+func TestShutdownStopsGroups(t *testing.T) {
+	pool := NewPool[string, string](WithHandler(echo[string]))
+	reports := pool.Group("reports", WithInitialWorkers(1))
+	if again := pool.Group("reports"); again != reports {
+		t.Fatal("second Group call returned a new pool")
+	}
+	pool.Group("audit")
+	if names := pool.Groups(); len(names) != 2 || names[0] != "audit" || names[1] != "reports" {
+		t.Fatalf("Groups = %v, want [audit reports]", names)
+	}
+
+	future, err := reports.Submit("queued")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if value, err := await(t, future); err != nil || value != "queued" {
+		t.Fatalf("group job after Shutdown = %q, %v", value, err)
+	}
+	if err := reports.SendJob("late"); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("group SendJob after pool Shutdown = %v, want ErrPoolClosed", err)
+	}
+
+	// Группа, созданная после остановки пула, сразу остановлена
+	if err := pool.Group("late").SendJob("late"); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("SendJob to a group created after Shutdown = %v, want ErrPoolClosed", err)
+	}
+}
@@ -0,0 +1,105 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Handoff передаёт работу пулу-преемнику target, например с новой версией обработчика
+// при перезагрузке конфигурации, не теряя заданий. Пул перестаёт принимать задания, забирает
+// из очереди ещё не начатые, ждёт завершения выполняющихся и ставит забранные в очередь target
+// в порядке их приёма, сохраняя тип, приоритет, арендатора, партицию, теги и метаданные.
+// Future переданных заданий завершатся, когда их обработает target, а Future.ID сменится
+// на ID задания в target. После Handoff пул остановлен, как после Shutdown.
+//
+// Если ctx истекает, пока выполняющиеся задания не завершились, их контексты отменяются,
+// но ждущие задания всё равно передаются. В target задания ждут места в очереди, пока не истёк
+// ctx; задания, которые target не принял (не дождались места или тот начал останавливаться),
+// завершаются с ErrJobDropped, и Handoff возвращает ошибку с их числом.
+// Группы пула (Group) не передаются, а останавливаются, как при Shutdown.
+func (p *Pool[T, R]) Handoff(ctx context.Context, target *Pool[T, R]) error {
+	if target == p {
+		return errors.New("cannot hand off jobs to the pool itself")
+	}
+	if !target.IsRunning() {
+		return fmt.Errorf("cannot hand off jobs: target %w", ErrPoolClosed)
+	}
+	if _, ok := p.beginShutdown(); !ok {
+		return fmt.Errorf("cannot hand off jobs: %w (%s)", ErrPoolClosed, p.State())
+	}
+	groupsDone := p.shutdownGroups(ctx, p.shutdownMode)
+
+	// Забираем очередь до остановки воркеров, чтобы они не начали ждущие задания
+	tasks := p.takeQueued()
+	p.unpause()
+	p.closeTokens()
+
+	stopped := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(stopped)
+	}()
+	var err error
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		err = fmt.Errorf("handoff: running jobs interrupted: %w", ctx.Err())
+		p.cancelWorkers()
+		<-stopped
+	}
+	// Задания личных очередей завершившихся воркеров возвращаются в общую очередь
+	tasks = append(tasks, p.takeQueued()...)
+	p.finishShutdown()
+
+	// Задания передаются после завершения выполняющихся: так в target не нарушится
+	// порядок партиций
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].id < tasks[j].id })
+	lost := 0
+	for _, t := range tasks {
+		if p.transfer(ctx, t, target) != nil {
+			lost++
+		}
+	}
+	if lost > 0 {
+		err = errors.Join(err, fmt.Errorf("handoff: %d of %d queued jobs were not accepted by target: %w", lost, len(tasks), ErrJobDropped))
+	}
+	if groupErr := <-groupsDone; groupErr != nil {
+		err = errors.Join(err, groupErr)
+	}
+	return err
+}
+
+// transfer ставит забранное из очереди задание t в очередь target, ожидая места не дольше ctx.
+func (p *Pool[T, R]) transfer(ctx context.Context, t *task[T, R], target *Pool[T, R]) error {
+	moved := &task[T, R]{
+		job:       t.job,
+		cost:      t.cost,
+		weight:    t.weight,
+		priority:  t.priority,
+		key:       t.key,
+		tenant:    t.tenant,
+		kind:      t.kind,
+		handler:   t.handler,
+		partition: t.partition,
+		tags:      t.tags,
+		timeout:   t.timeout,
+		ttl:       t.ttl,
+		submitCtx: t.submitCtx,
+		metadata:  t.metadata,
+		future:    t.future,
+	}
+	if err := target.enqueueWait(ctx, moved); err != nil {
+		p.logger.Error("failed to hand off job", "job", t.job, "id", t.id, "error", err)
+		p.traceFinished(t, 0, ErrJobDropped)
+		p.emit(EventDropped, t, 0, ErrJobDropped)
+		t.finish(*new(R), ErrJobDropped)
+		p.publishResult(t, *new(R), ErrJobDropped)
+		return err
+	}
+	// Теперь задание журналирует target
+	p.unpersist(t)
+	p.traceFinished(t, 0, nil)
+	return nil
+}
@@ -0,0 +1,98 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHandoffMovesQueuedJobs(t *testing.T) {
+	// Без воркеров задания остаются в очереди до Handoff
+	source := NewPool[string, string](WithHandler(echo[string]))
+	target := NewPool[string, string](WithHandler(func(ctx context.Context, job string) (string, error) {
+		return "target " + job, nil
+	}), WithInitialWorkers(1))
+	defer target.Shutdown(context.Background())
+
+	var futures []*Future[string]
+	for _, job := range []string{"a", "b", "c"} {
+		future, err := source.Submit(job)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		futures = append(futures, future)
+	}
+
+	// Владельцы Future читают ID, пока Handoff меняет его на ID в target
+	var readers sync.WaitGroup
+	for _, future := range futures {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-future.Done():
+					return
+				default:
+					_ = future.ID()
+				}
+			}
+		}()
+	}
+
+	if err := source.Handoff(context.Background(), target); err != nil {
+		t.Fatalf("Handoff: %v", err)
+	}
+	for i, future := range futures {
+		value, err := await(t, future)
+		if err != nil || value != "target "+[]string{"a", "b", "c"}[i] {
+			t.Errorf("job %d result = %q, %v", i, value, err)
+		}
+	}
+	readers.Wait()
+	if state := source.State(); state != Closed {
+		t.Errorf("source state = %v, want closed", state)
+	}
+}
+
+func TestHandoffStopsWaitingForTargetOnContext(t *testing.T) {
+	source := NewPool[int, int](WithHandler(echo[int]))
+	target := NewPool[int, int](WithHandler(echo[int]), WithBufferSize(1), WithInitialWorkers(1))
+	defer target.Shutdown(context.Background())
+
+	// Очередь target занята и не разбирается, поэтому переданным заданиям некуда встать
+	if err := target.Pause(); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	if err := target.SendJob(0); err != nil {
+		t.Fatalf("SendJob: %v", err)
+	}
+	var futures []*Future[int]
+	for i := 1; i <= 2; i++ {
+		future, err := source.Submit(i)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		futures = append(futures, future)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- source.Handoff(ctx, target) }()
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrJobDropped) {
+			t.Errorf("Handoff error = %v, want ErrJobDropped", err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("Handoff kept waiting for room in target after ctx expired")
+	}
+	for _, future := range futures {
+		if _, err := await(t, future); !errors.Is(err, ErrJobDropped) {
+			t.Errorf("job %d error = %v, want ErrJobDropped", future.ID(), err)
+		}
+	}
+}
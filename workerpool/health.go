@@ -0,0 +1,87 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrUnhealthy оборачивает причины, по которым Healthy считает пул нездоровым.
+var ErrUnhealthy = errors.New("pool is unhealthy")
+
+// HealthConfig задаёт пороги Healthy.
+type HealthConfig struct {
+	// MaxQueueFill — допустимая доля заполнения очереди от 0 до 1 (по умолчанию 0.9)
+	MaxQueueFill float64
+
+	// MaxJobAge — дольше этого задание считается зависшим (0 — не проверяется)
+	MaxJobAge time.Duration
+}
+
+// WithHealthCheck задаёт пороги для Healthy и HealthHandler.
+func WithHealthCheck(cfg HealthConfig) Option {
+	return func(c *config) {
+		if cfg.MaxQueueFill <= 0 || cfg.MaxQueueFill > 1 {
+			cfg.MaxQueueFill = 0.9
+		}
+		c.health = &cfg
+	}
+}
+
+// Healthy проверяет состояние пула и возвращает nil, если пул здоров, или ошибку,
+// оборачивающую ErrUnhealthy, с перечнем причин: пул остановлен, нет живых воркеров
+// (в режиме WithMaxWorkers их отсутствие без заданий нормально), очередь заполнена
+// выше порога или какое-то задание выполняется дольше MaxJobAge.
+// Пороги задаются через WithHealthCheck.
+func (p *Pool[T, R]) Healthy() error {
+	cfg := HealthConfig{MaxQueueFill: 0.9}
+	if p.health != nil {
+		cfg = *p.health
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var problems []error
+	if p.state == Draining || p.state == Closed {
+		problems = append(problems, fmt.Errorf("pool is %s", p.state))
+	}
+	queued := p.queuedLocked()
+	live := p.liveWorkersLocked()
+	if live == 0 && (p.maxWorkers == 0 || queued > 0) {
+		problems = append(problems, errors.New("no live workers"))
+	}
+	if p.bufferSize > 0 {
+		if fill := float64(queued+p.reserved) / float64(p.bufferSize); fill > cfg.MaxQueueFill {
+			problems = append(problems, fmt.Errorf("queue is %.0f%% full", fill*100))
+		}
+	}
+	if cfg.MaxJobAge > 0 {
+		now := p.clock.Now()
+		for _, worker := range p.workers {
+			if age := now.Sub(worker.jobStarted); worker.working && age > cfg.MaxJobAge {
+				problems = append(problems, fmt.Errorf("worker %d has been running job %d for %v", worker.ID, worker.currentID, age.Round(time.Millisecond)))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrUnhealthy, errors.Join(problems...))
+}
+
+// HealthHandler возвращает http.Handler для проверки готовности, например /healthz
+// в Kubernetes: 200 и "ok", если Healthy возвращает nil, иначе 503 с текстом ошибки.
+func (p *Pool[T, R]) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := p.Healthy(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, err)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+}
@@ -0,0 +1,89 @@
+package workerpool_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestHealthy(t *testing.T) {
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	release := make(chan struct{})
+	pool := workerpool.NewPool[int, int](
+		workerpool.WithHandler(func(ctx context.Context, job int) (int, error) {
+			<-release
+			return job, nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithBufferSize(4),
+		workerpool.WithHealthCheck(workerpool.HealthConfig{MaxQueueFill: 0.5, MaxJobAge: time.Minute}),
+	)
+	defer pool.Shutdown(context.Background())
+
+	// unhealthy проверяет, что Healthy и HealthHandler сообщают о причине reason
+	unhealthy := func(reason string) {
+		t.Helper()
+		err := pool.Healthy()
+		if !errors.Is(err, workerpool.ErrUnhealthy) || !strings.Contains(err.Error(), reason) {
+			t.Fatalf("Healthy = %v, want ErrUnhealthy with %q", err, reason)
+		}
+		rec := httptest.NewRecorder()
+		pool.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		if rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), reason) {
+			t.Fatalf("HealthHandler = %d %q, want 503 with %q", rec.Code, rec.Body.String(), reason)
+		}
+	}
+
+	unhealthy("no live workers")
+	pool.AddWorker()
+	if err := pool.Healthy(); err != nil {
+		t.Fatalf("Healthy with an idle worker: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	pool.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok\n" {
+		t.Fatalf("HealthHandler = %d %q, want 200 ok", rec.Code, rec.Body.String())
+	}
+
+	// Задание, выполняющееся дольше MaxJobAge по часам пула, считается зависшим
+	future, err := pool.Submit(1)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	advanceUntil(t, clock, time.Millisecond, "job started", func() bool { return pool.Stats().BusyWorkers == 1 })
+	if err := pool.Healthy(); err != nil {
+		t.Fatalf("Healthy with a fresh job: %v", err)
+	}
+	clock.Advance(2 * time.Minute)
+	unhealthy("has been running job")
+
+	// Очередь, заполненная выше MaxQueueFill, тоже делает пул нездоровым
+	for i := 0; i < 3; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	unhealthy("queue is 75% full")
+	close(release)
+	advanceUntil(t, clock, time.Millisecond, "queue drained", isDone(future))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := pool.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if err := pool.Healthy(); err != nil {
+		t.Fatalf("Healthy after the queue drained: %v", err)
+	}
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	unhealthy("pool is closed")
+}
@@ -0,0 +1,145 @@
+package workerpool
+
+import (
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// maxAuditErrorLen — длина текста ошибки в AuditRecord, после которой он обрезается.
+const maxAuditErrorLen = 512
+
+// AuditRecord — запись о завершённом задании для History и AuditSink.
+type AuditRecord struct {
+	ID       JobID
+	Type     string        // тип задания из SubmitNamed (пустой — без типа)
+	Status   EventType     // EventSucceeded, EventFailed, EventCancelled или EventDropped
+	Attempts int           // число попыток обработчика (0 — задание не выполнялось)
+	Duration time.Duration // время обработки со всеми повторами
+	Error    string        // текст итоговой ошибки, обрезанный до 512 байт (пустой — без ошибки)
+	Enqueued time.Time
+	Finished time.Time
+}
+
+// AuditSink получает запись о каждом завершённом задании, например для записи в журнал аудита.
+// Record вызывается синхронно в горутине, где задание завершилось, поэтому должен быстро
+// возвращать управление.
+type AuditSink interface {
+	Record(AuditRecord)
+}
+
+// AuditSinkFunc позволяет использовать функцию как AuditSink.
+type AuditSinkFunc func(AuditRecord)
+
+// Record реализует AuditSink.
+func (f AuditSinkFunc) Record(r AuditRecord) { f(r) }
+
+// WithHistory хранит записи о size последних завершённых заданиях для History и JobHistory,
+// чтобы поддержка могла ответить на вопрос «что стало с заданием X?» без поиска по логам.
+func WithHistory(size int) Option {
+	return func(c *config) {
+		if size > 0 {
+			c.historySize = size
+		}
+	}
+}
+
+// WithAuditSink передаёт записи о завершённых заданиях в sink, независимо от WithHistory.
+func WithAuditSink(sink AuditSink) Option {
+	return func(c *config) {
+		c.auditSink = sink
+	}
+}
+
+// history — кольцевой буфер последних записей WithHistory.
+type history struct {
+	mu      sync.Mutex
+	records []AuditRecord
+	next    int
+}
+
+// History возвращает до n последних записей о завершённых заданиях в порядке завершения
+// (n меньше 1 — все сохранённые). Без WithHistory — nil.
+func (p *Pool[T, R]) History(n int) []AuditRecord {
+	h := &p.history
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	total := len(h.records)
+	if n < 1 || n > total {
+		n = total
+	}
+	if n == 0 {
+		return nil
+	}
+	records := make([]AuditRecord, 0, n)
+	// Когда буфер заполнен, самая давняя запись лежит на позиции next
+	start := 0
+	if total == p.historySize {
+		start = h.next
+	}
+	for i := total - n; i < total; i++ {
+		records = append(records, h.records[(start+i)%total])
+	}
+	return records
+}
+
+// JobHistory возвращает запись о завершённом задании id, если она ещё хранится в WithHistory.
+func (p *Pool[T, R]) JobHistory(id JobID) (AuditRecord, bool) {
+	h := &p.history
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, r := range h.records {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return AuditRecord{}, false
+}
+
+// audit записывает итог задания t в историю и AuditSink.
+func (p *Pool[T, R]) audit(status EventType, t *task[T, R], attempts int, err error, now time.Time) {
+	if p.historySize == 0 && p.auditSink == nil {
+		return
+	}
+	r := AuditRecord{
+		ID:       t.id,
+		Type:     t.kind,
+		Status:   status,
+		Attempts: attempts,
+		Duration: t.latency,
+		Enqueued: t.enqueued,
+		Finished: now,
+	}
+	if err != nil {
+		r.Error = truncateError(err.Error())
+	}
+
+	if p.historySize > 0 {
+		h := &p.history
+		h.mu.Lock()
+		if len(h.records) < p.historySize {
+			h.records = append(h.records, r)
+		} else {
+			h.records[h.next] = r
+			h.next = (h.next + 1) % p.historySize
+		}
+		h.mu.Unlock()
+	}
+	if p.auditSink != nil {
+		p.auditSink.Record(r)
+	}
+}
+
+// truncateError обрезает текст ошибки до maxAuditErrorLen байт, не разрывая символы.
+func truncateError(s string) string {
+	if len(s) <= maxAuditErrorLen {
+		return s
+	}
+	cut := maxAuditErrorLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "…"
+}
@@ -0,0 +1,99 @@
+package workerpool_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestHistory(t *testing.T) {
+	const took = 2 * time.Second
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := workerpooltest.NewClock(start)
+	var (
+		mu      sync.Mutex
+		audited []workerpool.AuditRecord
+	)
+	// Каждая попытка длится took по часам пула, отрицательные задания падают с длинной ошибкой
+	pool := workerpool.NewPool[int, int](
+		workerpool.WithHandler(func(ctx context.Context, job int) (int, error) {
+			if err := workerpool.Sleep(ctx, took); err != nil {
+				return 0, err
+			}
+			if job < 0 {
+				return 0, errors.New(strings.Repeat("ошибка ", 100))
+			}
+			return job, nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithRetry(workerpool.RetryPolicy{MaxAttempts: 2, Backoff: func(int) time.Duration { return 0 }}),
+		workerpool.WithHistory(3),
+		workerpool.WithAuditSink(workerpool.AuditSinkFunc(func(r workerpool.AuditRecord) {
+			mu.Lock()
+			audited = append(audited, r)
+			mu.Unlock()
+		})),
+	)
+	defer pool.ShutdownNow()
+
+	// Без воркеров задания ждут в очереди, и одно из них отменяется, не начавшись
+	var futures []*workerpool.Future[int]
+	for _, job := range []int{1, 2, -3, 4} {
+		future, err := pool.Submit(job)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		futures = append(futures, future)
+	}
+	pool.Cancel(futures[1].ID())
+	pool.AddWorker()
+	for _, future := range futures {
+		advanceUntil(t, clock, took, "job finished", isDone(future))
+	}
+
+	// Хранятся три последние записи, а запись об отменённом задании, завершившемся первым, вытеснена
+	records := pool.History(0)
+	var statuses []workerpool.EventType
+	for _, r := range records {
+		statuses = append(statuses, r.Status)
+	}
+	want := []workerpool.EventType{workerpool.EventSucceeded, workerpool.EventFailed, workerpool.EventSucceeded}
+	if !slices.Equal(statuses, want) {
+		t.Fatalf("History statuses = %v, want %v", statuses, want)
+	}
+	if last := pool.History(1); len(last) != 1 || last[0].ID != futures[3].ID() {
+		t.Errorf("History(1) = %+v, want the record of the last job", last)
+	}
+	if _, ok := pool.JobHistory(futures[1].ID()); ok {
+		t.Error("JobHistory kept a record evicted from the history")
+	}
+
+	failed, ok := pool.JobHistory(futures[2].ID())
+	if !ok {
+		t.Fatal("JobHistory lost the failed job")
+	}
+	if failed.Attempts != 2 || failed.Duration != 2*took || !failed.Enqueued.Equal(start) || failed.Finished.Before(start.Add(3*took)) {
+		t.Errorf("failed job record = %+v, want 2 attempts over %v", failed, 2*took)
+	}
+	if len(failed.Error) > 512+len("…") || !utf8.ValidString(failed.Error) || !strings.HasSuffix(failed.Error, "…") {
+		t.Errorf("error text of %d bytes = %q, want it cut to 512 bytes on a rune boundary", len(failed.Error), failed.Error)
+	}
+
+	// AuditSink получает все записи, в том числе об отменённом задании
+	mu.Lock()
+	defer mu.Unlock()
+	if len(audited) != 4 {
+		t.Fatalf("AuditSink got %d records, want 4", len(audited))
+	}
+	if r := audited[0]; r.ID != futures[1].ID() || r.Status != workerpool.EventCancelled || r.Attempts != 0 {
+		t.Errorf("first audit record = %+v, want the cancelled job with no attempts", r)
+	}
+}
@@ -0,0 +1,56 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrJobRejected — задание отклонено хуком WithSubmitHook и не попало в очередь.
+var ErrJobRejected = errors.New("job rejected by submit hook")
+
+// SubmitHook проверяет или дополняет задание перед постановкой в очередь: возвращает
+// задание, которое будет поставлено (исходное или изменённое), или ошибку, чтобы отклонить его.
+type SubmitHook[T any] func(job T) (T, error)
+
+// WithSubmitHook добавляет хуки, через которые проходит каждое отправленное задание
+// до постановки в очередь: проверка обязательных полей, значения по умолчанию,
+// переписывание содержимого. Хуки вызываются по порядку в горутине отправителя, каждый
+// получает результат предыдущего. Ошибка хука останавливает цепочку, и отправка возвращает
+// её, обёрнутую в ErrJobRejected. Задания, восстановленные из журнала WithPersistence,
+// хуки уже проходили и повторно не проверяются.
+func WithSubmitHook[T any](hooks ...SubmitHook[T]) Option {
+	return func(c *config) {
+		for _, h := range hooks {
+			c.submitHooks = append(c.submitHooks, h)
+		}
+	}
+}
+
+// submitHooks проверяет типы хуков WithSubmitHook.
+func submitHooks[T any](hooks []any) []SubmitHook[T] {
+	typed := make([]SubmitHook[T], 0, len(hooks))
+	for _, h := range hooks {
+		hook, ok := h.(SubmitHook[T])
+		if !ok {
+			panic(fmt.Sprintf("workerpool: submit hook type %T does not match pool type %T", h, SubmitHook[T](nil)))
+		}
+		typed = append(typed, hook)
+	}
+	return typed
+}
+
+// runSubmitHooks пропускает задание через хуки WithSubmitHook.
+func (p *Pool[T, R]) runSubmitHooks(t *task[T, R]) error {
+	if t.walKey != 0 {
+		// Задание из журнала уже проходило хуки при первой отправке
+		return nil
+	}
+	for _, hook := range p.hooks {
+		job, err := hook(t.job)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrJobRejected, err)
+		}
+		t.job = job
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSubmitHooks(t *testing.T) {
+	errEmpty := errors.New("empty job")
+	pool := NewPool[string, string](
+		WithHandler(echo[string]),
+		// Хуки вызываются по порядку, и каждый получает результат предыдущего
+		WithSubmitHook(
+			func(job string) (string, error) { return strings.TrimSpace(job), nil },
+			func(job string) (string, error) {
+				if job == "" {
+					return "", errEmpty
+				}
+				return job, nil
+			},
+		),
+		WithSubmitHook(func(job string) (string, error) { return strings.ToUpper(job), nil }),
+		WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	future, err := pool.Submit("  report ")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if got, err := await(t, future); err != nil || got != "REPORT" {
+		t.Errorf("rewritten job = %q, %v; want REPORT", got, err)
+	}
+
+	// Отклонённое задание не попадает в очередь
+	for _, send := range []func() error{
+		func() error { return pool.SendJob("   ") },
+		func() error { _, err := pool.Submit(""); return err },
+	} {
+		if err := send(); !errors.Is(err, ErrJobRejected) || !errors.Is(err, errEmpty) {
+			t.Errorf("sending an empty job = %v, want ErrJobRejected wrapping the hook error", err)
+		}
+	}
+	if err := pool.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if stats := pool.Stats(); stats.Processed != 1 || stats.QueueLen != 0 {
+		t.Errorf("processed %d jobs with %d queued, want only the accepted one", stats.Processed, stats.QueueLen)
+	}
+}
+
+func TestSubmitHookTypeMismatchPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewPool with a hook of another job type did not panic")
+		}
+	}()
+	NewPool[string, string](WithHandler(echo[string]), WithSubmitHook(func(job int) (int, error) { return job, nil }))
+}
@@ -0,0 +1,25 @@
+package workerpool
+
+import "context"
+
+// WithSynchronousMode включает синхронный режим для тестов: SendJob, Submit и другие способы
+// отправки выполняют задание прямо в вызывающей горутине и возвращаются после его завершения.
+// Воркеры, очередь и ограничения параллелизма в этом режиме не участвуют, а порядок
+// выполнения совпадает с порядком вызовов — результат теста не зависит от планировщика.
+// Партиции SendJobForPartition не ждут друг друга: задания отправителя и так выполняются по порядку.
+func WithSynchronousMode() Option {
+	return func(c *config) {
+		c.synchronous = true
+	}
+}
+
+// runInline выполняет задание синхронного режима в горутине отправителя.
+func (p *Pool[T, R]) runInline(t *task[T, R]) {
+	ctx := context.Background()
+	if p.batch != nil {
+		p.runBatch(ctx, []*task[T, R]{t})
+	} else {
+		p.run(ctx, t)
+	}
+	p.jobDone(t)
+}
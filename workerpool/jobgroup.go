@@ -0,0 +1,111 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrGroupCancelled — задание не добавлено в группу, потому что она отменена через JobGroup.Cancel.
+var ErrGroupCancelled = errors.New("job group cancelled")
+
+// JobGroup — набор связанных заданий пула с общим ожиданием завершения, как у sync.WaitGroup:
+// задания добавляются через Submit, а Wait ждёт, пока завершатся все, и собирает их итоги.
+// JobGroup безопасен для одновременного использования из нескольких горутин.
+type JobGroup[T, R any] struct {
+	pool *Pool[T, R]
+
+	mu        sync.Mutex
+	members   []groupMember[T, R]
+	cancelled bool
+}
+
+type groupMember[T, R any] struct {
+	job    T
+	future *Future[R]
+}
+
+// NewGroup создаёт пустую группу заданий пула.
+func (p *Pool[T, R]) NewGroup() *JobGroup[T, R] {
+	return &JobGroup[T, R]{pool: p}
+}
+
+// Submit ставит задание в очередь пула как участника группы.
+// Задание, которое не удалось поставить в очередь, в группу не входит.
+// После Cancel возвращается ErrGroupCancelled.
+func (g *JobGroup[T, R]) Submit(job T) error {
+	g.mu.Lock()
+	cancelled := g.cancelled
+	g.mu.Unlock()
+	if cancelled {
+		return ErrGroupCancelled
+	}
+
+	future, err := g.pool.Submit(job)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.cancelled {
+		// Группу отменили, пока задание ставилось в очередь
+		g.pool.Cancel(future.ID())
+		return ErrGroupCancelled
+	}
+	g.members = append(g.members, groupMember[T, R]{job: job, future: future})
+	return nil
+}
+
+// Cancel отменяет группу: её ждущие задания убираются из очереди, у выполняющихся
+// отменяется контекст, а новые задания группа больше не принимает. Отменённые задания
+// завершаются с context.Canceled, и Wait возвращает эти ошибки вместе с остальными.
+// Возвращает число заданий, которые ещё не завершились к моменту отмены.
+func (g *JobGroup[T, R]) Cancel() int {
+	g.mu.Lock()
+	g.cancelled = true
+	members := append([]groupMember[T, R](nil), g.members...)
+	g.mu.Unlock()
+
+	n := 0
+	for _, m := range members {
+		if g.pool.Cancel(m.future.ID()) {
+			n++
+		}
+	}
+	return n
+}
+
+// Len возвращает число заданий в группе.
+func (g *JobGroup[T, R]) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return len(g.members)
+}
+
+// Wait ждёт завершения всех заданий, добавленных в группу к моменту вызова, и возвращает
+// их результаты в порядке Submit вместе с объединённой ошибкой (*JobError для каждого
+// неудачного задания, nil — если все успешны). Результат неудачного задания — нулевое значение R.
+// Если ctx отменяется раньше, возвращается ошибка ctx, а задания продолжают выполняться.
+func (g *JobGroup[T, R]) Wait(ctx context.Context) ([]R, error) {
+	g.mu.Lock()
+	members := append([]groupMember[T, R](nil), g.members...)
+	g.mu.Unlock()
+
+	results := make([]R, len(members))
+	var errs []error
+	for i, m := range members {
+		select {
+		case <-m.future.Done():
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if err := m.future.Err(); err != nil {
+			errs = append(errs, &JobError{ID: m.future.ID(), Job: m.job, Err: err})
+			continue
+		}
+		results[i] = m.future.Result()
+	}
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,91 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestJobGroupWait(t *testing.T) {
+	release := make(chan struct{})
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+		if job < 0 {
+			return 0, errors.New("negative job")
+		}
+		return job * 10, nil
+	}), WithInitialWorkers(3))
+	defer pool.Shutdown(context.Background())
+
+	group := pool.NewGroup()
+	for _, job := range []int{1, 2, -3, 4} {
+		if err := group.Submit(job); err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	}
+	if n := group.Len(); n != 4 {
+		t.Errorf("Len = %d, want 4", n)
+	}
+
+	// Пока задания не завершены, Wait ограничен своим ctx
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := group.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait with blocked jobs = %v, want DeadlineExceeded", err)
+	}
+
+	// Результаты идут в порядке Submit, а ошибки собираются с заданиями, на которых произошли
+	close(release)
+	results, err := group.Wait(context.Background())
+	if want := []int{10, 20, 0, 40}; !slices.Equal(results, want) {
+		t.Errorf("results = %v, want %v", results, want)
+	}
+	var jobErr *JobError
+	if !errors.As(err, &jobErr) || jobErr.Job != -3 {
+		t.Errorf("Wait error = %v, want the *JobError of job -3", err)
+	}
+}
+
+func TestJobGroupCancel(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}), WithInitialWorkers(1))
+	defer pool.Shutdown(context.Background())
+
+	group, other := pool.NewGroup(), pool.NewGroup()
+	for _, job := range []int{1, 2, 3} {
+		if err := group.Submit(job); err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	}
+	eventually(t, "first job started", func() bool { return pool.Stats().BusyWorkers == 1 })
+	if err := other.Submit(4); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	// Отмена прерывает выполняющееся задание группы и убирает ждущие, не трогая чужих
+	if n := group.Cancel(); n != 3 {
+		t.Errorf("Cancel = %d, want 3 unfinished jobs", n)
+	}
+	if err := group.Submit(5); !errors.Is(err, ErrGroupCancelled) {
+		t.Errorf("Submit after Cancel = %v, want ErrGroupCancelled", err)
+	}
+	if _, err := group.Wait(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait after Cancel = %v, want context.Canceled", err)
+	}
+	if n := group.Cancel(); n != 0 {
+		t.Errorf("repeated Cancel = %d, want 0", n)
+	}
+	eventually(t, "other group's job started", func() bool { return pool.Stats().BusyWorkers == 1 })
+	if n := other.Len(); n != 1 || pool.QueueLen() != 0 {
+		t.Errorf("other group has %d jobs with %d queued, want its job running", n, pool.QueueLen())
+	}
+	other.Cancel()
+}
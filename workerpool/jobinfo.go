@@ -0,0 +1,56 @@
+package workerpool
+
+import (
+	"context"
+	"time"
+)
+
+// Job — сведения о выполняемом задании, доступные обработчику через JobInfo(ctx).
+type Job struct {
+	ID       JobID
+	Type     string    // тип задания из SubmitNamed (пустой — без типа)
+	Enqueued time.Time // время постановки в очередь
+	Attempt  int       // номер текущей попытки, начиная с 1 (растёт при повторах WithRetry)
+
+	// Metadata — метки, переданные в SubmitWithMetadata (nil, если не заданы).
+	// Обработчик не должен их изменять: карта общая для всех попыток.
+	Metadata map[string]string
+
+	// handler — обработчик задания из SubmitWith (Handler[T, R] или nil). Лежит в сведениях
+	// о задании, а не отдельным значением контекста, чтобы задание, отправленное из такого
+	// обработчика с его ctx, не унаследовало чужой обработчик
+	handler any
+}
+
+type jobInfoKey struct{}
+
+// JobInfo возвращает сведения о задании из контекста, переданного обработчику.
+// Номер попытки годится для ключей идемпотентности, время постановки — для контроля SLA.
+// В пакетном режиме (WithBatchHandler) сведения недоступны, и возвращается false.
+func JobInfo(ctx context.Context) (Job, bool) {
+	info, ok := ctx.Value(jobInfoKey{}).(Job)
+	return info, ok
+}
+
+// SubmitWithMetadata — то же, что Submit, но прикрепляет к заданию метки,
+// которые обработчик получит через JobInfo(ctx).Metadata.
+func (p *Pool[T, R]) SubmitWithMetadata(job T, metadata map[string]string) (*Future[R], error) {
+	t := &task[T, R]{job: job, cost: 1, metadata: metadata, future: newFuture[R]()}
+	if err := p.enqueue(t); err != nil {
+		return nil, err
+	}
+	return t.future, nil
+}
+
+// withJobInfo добавляет в контекст сведения о попытке attempt задания t,
+// включая обработчик задания из SubmitWith.
+func withJobInfo[T, R any](ctx context.Context, t *task[T, R], attempt int) context.Context {
+	return context.WithValue(ctx, jobInfoKey{}, Job{
+		ID:       t.id,
+		Type:     t.kind,
+		Enqueued: t.enqueued,
+		Attempt:  attempt,
+		Metadata: t.metadata,
+		handler:  t.handler,
+	})
+}
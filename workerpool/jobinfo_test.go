@@ -0,0 +1,63 @@
+package workerpool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestJobInfo(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := workerpooltest.NewClock(start)
+	var mu sync.Mutex
+	var seen []workerpool.Job
+	pool := workerpool.NewPool[string, string](
+		workerpool.WithHandler(func(ctx context.Context, job string) (string, error) {
+			info, ok := workerpool.JobInfo(ctx)
+			if !ok {
+				return "", errors.New("no job info")
+			}
+			mu.Lock()
+			seen = append(seen, info)
+			mu.Unlock()
+			if info.Attempt == 1 {
+				return "", errors.New("try again")
+			}
+			return info.Metadata["tenant"], nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithRetry(workerpool.RetryPolicy{MaxAttempts: 3, Backoff: func(int) time.Duration { return 0 }}),
+	)
+	defer pool.Shutdown(context.Background())
+
+	future, err := pool.SubmitWithMetadata("report", map[string]string{"tenant": "acme"})
+	if err != nil {
+		t.Fatalf("SubmitWithMetadata: %v", err)
+	}
+	clock.Advance(time.Minute)
+	pool.AddWorker()
+	advanceUntil(t, clock, time.Millisecond, "job done", isDone(future))
+	if got, err := future.Result(), future.Err(); err != nil || got != "acme" {
+		t.Fatalf("result = %q, %v; want acme", got, err)
+	}
+
+	// Каждая попытка видит тот же ID, время постановки и метки, но свой номер
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) != 2 {
+		t.Fatalf("handler ran %d times, want 2", len(seen))
+	}
+	for i, info := range seen {
+		if info.ID != future.ID() || !info.Enqueued.Equal(start) || info.Attempt != i+1 || info.Type != "" {
+			t.Errorf("attempt %d JobInfo = %+v, want job %d enqueued at %v", i+1, info, future.ID(), start)
+		}
+	}
+	if _, ok := workerpool.JobInfo(context.Background()); ok {
+		t.Error("JobInfo outside a handler reported ok")
+	}
+}
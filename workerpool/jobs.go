@@ -0,0 +1,151 @@
+package workerpool
+
+import (
+	"context"
+	"time"
+)
+
+// JobID — идентификатор задания в пуле.
+type JobID uint64
+
+// SubmitWithTimeout — то же, что Submit, но выполнение задания ограничено временем d:
+// по его истечении контекст обработчика отменяется с context.DeadlineExceeded.
+// Время ожидания в очереди в это ограничение не входит.
+func (p *Pool[T, R]) SubmitWithTimeout(job T, d time.Duration) (*Future[R], error) {
+	future := newFuture[R]()
+	if err := p.enqueue(&task[T, R]{job: job, cost: 1, timeout: d, future: future}); err != nil {
+		return nil, err
+	}
+	return future, nil
+}
+
+// Cancel отменяет задание по ID. Задание, ещё ждущее в очереди, убирается из неё,
+// а его Future завершается с context.Canceled; у выполняющегося задания отменяется контекст.
+// Возвращает false, если задания с таким ID нет — оно уже завершилось или не существовало.
+func (p *Pool[T, R]) Cancel(id JobID) bool {
+	p.mu.Lock()
+	t, exists := p.tasks[id]
+	if !exists {
+		p.mu.Unlock()
+		return false
+	}
+	removed := p.cancelLocked(t)
+	p.mu.Unlock()
+
+	if removed {
+		p.finishRemoved(t, context.Canceled)
+	}
+	return true
+}
+
+// cancelLocked убирает задание из очереди и возвращает true — тогда вызывающий завершает
+// его через finishRemoved после снятия p.mu. У уже взятого воркером задания отменяется контекст.
+func (p *Pool[T, R]) cancelLocked(t *task[T, R]) bool {
+	if p.removeQueuedLocked(t) {
+		return true
+	}
+	// Задание уже взято воркером
+	t.cancelled = true
+	if t.cancel != nil {
+		t.cancel()
+	}
+	return false
+}
+
+// finishRemoved завершает задание, убранное из очереди до начала выполнения, с ошибкой err.
+func (p *Pool[T, R]) finishRemoved(t *task[T, R], err error) {
+	p.traceFinished(t, 0, err)
+	p.emit(EventCancelled, t, 0, err)
+	t.finish(*new(R), err)
+	p.publishResult(t, *new(R), err)
+	p.jobDone(t)
+}
+
+// removeQueuedLocked убирает задание из очереди вместе с его жетоном.
+// Возвращает false, если задания в очереди уже нет. Вызывается под p.mu.
+func (p *Pool[T, R]) removeQueuedLocked(t *task[T, R]) bool {
+	if p.removeHeldLocked(t) || p.removeTagHeldLocked(t) || p.removePinnedLocked(t) {
+		// У отложенных заданий партиций и тегов и заданий личных очередей нет жетона
+		p.signalSpaceLocked()
+		return true
+	}
+	if !p.queue.remove(t) {
+		return false
+	}
+	// Забираем жетон убранного задания, если его ещё не взял воркер;
+	// иначе воркер просто не найдёт задания в очереди
+	select {
+	case <-p.tokens:
+	default:
+		if p.heldTokens > 0 {
+			p.heldTokens--
+		}
+	}
+	p.signalSpaceLocked()
+	return true
+}
+
+// SubmitContext — то же, что Submit, но обработчик получает значения и срок ctx отправителя:
+// идентификатор запроса, данные авторизации и трассировку из HTTP-обработчика.
+// Контекст обработчика по-прежнему отменяется вместе с воркером, а отмена ctx после
+// постановки задания на него не влияет — истекает только срок. Если очередь заполнена,
+// вызов ждёт места, как SendJobContext, пока ctx не отменён.
+func (p *Pool[T, R]) SubmitContext(ctx context.Context, job T) (*Future[R], error) {
+	t := &task[T, R]{job: job, cost: 1, submitCtx: ctx, future: newFuture[R]()}
+	if err := p.enqueueWait(ctx, t); err != nil {
+		return nil, err
+	}
+	return t.future, nil
+}
+
+// SubmitAndWait ставит задание в очередь и ждёт его результата, превращая пул
+// в ограничитель параллелизма для синхронных путей, например HTTP-обработчиков.
+// Значения и срок ctx передаются обработчику, как в SubmitContext. Если ctx отменяется
+// раньше, чем задание завершится, задание отменяется через Cancel и возвращается ошибка ctx.
+func (p *Pool[T, R]) SubmitAndWait(ctx context.Context, job T) (R, error) {
+	future, err := p.SubmitContext(ctx, job)
+	if err != nil {
+		return *new(R), err
+	}
+	select {
+	case <-future.Done():
+		return future.Result(), future.Err()
+	case <-ctx.Done():
+		// Результат больше никто не ждёт — не тратим на задание воркера
+		p.Cancel(future.ID())
+		return *new(R), ctx.Err()
+	}
+}
+
+// jobContext создаёт контекст выполнения задания с учётом его таймаута и срока
+// контекста отправителя и запоминает функцию отмены для Cancel.
+func (p *Pool[T, R]) jobContext(parent context.Context, t *task[T, R]) (context.Context, context.CancelFunc) {
+	var deadline time.Time
+	if t.timeout > 0 {
+		deadline = p.clock.Now().Add(t.timeout)
+	}
+	if t.submitCtx != nil {
+		parent = valuesContext{Context: parent, values: t.submitCtx}
+		if d, ok := t.submitCtx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+			deadline = d
+		}
+	}
+
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+	if !deadline.IsZero() {
+		ctx, cancel = p.withDeadline(parent, deadline)
+	} else {
+		ctx, cancel = context.WithCancel(parent)
+	}
+
+	p.mu.Lock()
+	t.cancel = cancel
+	if t.cancelled {
+		cancel()
+	}
+	p.mu.Unlock()
+	return ctx, cancel
+}
@@ -0,0 +1,176 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSubmitWithTimeout(t *testing.T) {
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			if job == "fast" {
+				return job, nil
+			}
+			<-ctx.Done()
+			return "", ctx.Err()
+		}),
+		WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	slow, err := pool.SubmitWithTimeout("slow", 20*time.Millisecond)
+	if err != nil {
+		t.Fatalf("SubmitWithTimeout: %v", err)
+	}
+	if _, err := await(t, slow); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("slow job error = %v, want DeadlineExceeded", err)
+	}
+	fast, err := pool.SubmitWithTimeout("fast", time.Minute)
+	if err != nil {
+		t.Fatalf("SubmitWithTimeout: %v", err)
+	}
+	if value, err := await(t, fast); err != nil || value != "fast" {
+		t.Errorf("fast job = %q, %v", value, err)
+	}
+}
+
+func TestCancelQueuedAndRunningJobs(t *testing.T) {
+	started := make(chan struct{})
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			close(started)
+			<-ctx.Done()
+			return "", ctx.Err()
+		}),
+		WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	running, err := pool.Submit("running")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	<-started
+	queued, err := pool.Submit("queued")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	// Ждущее задание убирается из очереди, не дойдя до обработчика
+	if !pool.Cancel(queued.ID()) {
+		t.Fatal("Cancel of a queued job = false")
+	}
+	if _, err := await(t, queued); !errors.Is(err, context.Canceled) {
+		t.Errorf("queued job error = %v, want context.Canceled", err)
+	}
+	// У выполняющегося задания отменяется контекст
+	if !pool.Cancel(running.ID()) {
+		t.Fatal("Cancel of a running job = false")
+	}
+	if _, err := await(t, running); !errors.Is(err, context.Canceled) {
+		t.Errorf("running job error = %v, want context.Canceled", err)
+	}
+	// Завершённое задание отменить уже нельзя
+	eventually(t, "finished job forgotten", func() bool { return !pool.Cancel(running.ID()) })
+	if pool.QueueLen() != 0 {
+		t.Errorf("QueueLen = %d, want 0", pool.QueueLen())
+	}
+}
+
+func TestSubmitContext(t *testing.T) {
+	type requestKey struct{}
+	release := make(chan struct{})
+	pool := NewPool[string, string](WithHandler(func(ctx context.Context, job string) (string, error) {
+		if job == "deadline" {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}
+		<-release
+		// Отмена ctx отправителя после постановки задания на обработчик не влияет
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		id, _ := ctx.Value(requestKey{}).(string)
+		return job + " for " + id, nil
+	}), WithBufferSize(1))
+	defer pool.Shutdown(context.Background())
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), requestKey{}, "req-1"))
+	future, err := pool.SubmitContext(ctx, "report")
+	if err != nil {
+		t.Fatalf("SubmitContext: %v", err)
+	}
+	cancel()
+
+	// Очередь заполнена: SubmitContext ждёт места, пока ctx не отменён
+	short, stop := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer stop()
+	if _, err := pool.SubmitContext(short, "late"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("SubmitContext to a full queue = %v, want DeadlineExceeded", err)
+	}
+
+	pool.AddWorker()
+	close(release)
+	if got, err := await(t, future); err != nil || got != "report for req-1" {
+		t.Fatalf("result = %q, %v; want report for req-1", got, err)
+	}
+
+	// Срок ctx отправителя ограничивает выполнение задания
+	deadline, stop := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer stop()
+	future, err = pool.SubmitContext(deadline, "deadline")
+	if err != nil {
+		t.Fatalf("SubmitContext: %v", err)
+	}
+	if _, err := await(t, future); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("job past the sender deadline = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestSubmitAndWait(t *testing.T) {
+	boom := errors.New("boom")
+	started := make(chan struct{}, 1)
+	pool := NewPool[string, string](WithHandler(func(ctx context.Context, job string) (string, error) {
+		switch job {
+		case "fail":
+			return "", boom
+		case "slow":
+			started <- struct{}{}
+			<-ctx.Done()
+			return "", ctx.Err()
+		}
+		return job + " done", nil
+	}))
+	defer pool.Shutdown(context.Background())
+
+	// Без воркеров задание ждёт в очереди, и по отмене ctx его оттуда убирают
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := pool.SubmitAndWait(ctx, "queued"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("SubmitAndWait without workers = %v, want DeadlineExceeded", err)
+	}
+	if n := pool.QueueLen(); n != 0 {
+		t.Errorf("QueueLen after the caller gave up = %d, want 0", n)
+	}
+
+	pool.AddWorker()
+	if got, err := pool.SubmitAndWait(context.Background(), "report"); err != nil || got != "report done" {
+		t.Errorf("SubmitAndWait = %q, %v; want report done", got, err)
+	}
+	if _, err := pool.SubmitAndWait(context.Background(), "fail"); !errors.Is(err, boom) {
+		t.Errorf("SubmitAndWait of a failing job = %v, want the handler error", err)
+	}
+
+	// Отмена ctx во время выполнения прерывает задание
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	if _, err := pool.SubmitAndWait(ctx, "slow"); !errors.Is(err, context.Canceled) {
+		t.Errorf("SubmitAndWait cancelled mid-job = %v, want context.Canceled", err)
+	}
+	eventually(t, "slow job interrupted", func() bool { return pool.Stats().BusyWorkers == 0 })
+}
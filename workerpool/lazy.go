@@ -0,0 +1,100 @@
+package workerpool
+
+import (
+	"time"
+)
+
+// defaultWorkerIdleTimeout — время простоя, после которого воркер завершается в режиме WithMaxWorkers,
+// если WithWorkerIdleTimeout не задан.
+const defaultWorkerIdleTimeout = 30 * time.Second
+
+// WithMaxWorkers включает ленивый запуск воркеров: вместо заранее добавленных воркеров пул
+// сам запускает горутину, когда приходит задание и свободных воркеров нет, но не больше n
+// одновременно. Простаивающие воркеры завершаются по WithWorkerIdleTimeout (по умолчанию 30 с),
+// поэтому в сервисах с всплесками нагрузки между всплесками не висят лишние горутины.
+// AddWorker по-прежнему доступен и не ограничивается n.
+func WithMaxWorkers(n int) Option {
+	return func(c *config) {
+		if n > 0 {
+			c.maxWorkers = n
+		}
+	}
+}
+
+// WithWorkerIdleTimeout задаёт, сколько воркер может ждать задания, прежде чем завершиться.
+// Действует на всех воркеров пула, в том числе добавленных через AddWorker.
+func WithWorkerIdleTimeout(d time.Duration) Option {
+	return func(c *config) {
+		if d > 0 {
+			c.workerIdleTimeout = d
+		}
+	}
+}
+
+// spawnNeededLocked считает, сколько воркеров нужно запустить для ждущих заданий,
+// и резервирует под них места. Вызывается под p.mu.
+func (p *Pool[T, R]) spawnNeededLocked() int {
+	if p.maxWorkers == 0 || p.paused {
+		return 0
+	}
+	live, idle := p.spawning, p.spawning
+	for _, worker := range p.workers {
+		if !worker.removed {
+			live++
+			if !worker.working {
+				idle++
+			}
+		}
+	}
+	n := min(p.maxWorkers-live, p.queue.len()-idle)
+	if n <= 0 {
+		return 0
+	}
+	p.spawning += n
+	return n
+}
+
+// spawnWorkers запускает n воркеров, зарезервированных в spawnNeededLocked.
+// Запуск идёт в отдельной горутине, чтобы WithWorkerSpawnRate не задерживал отправителя.
+func (p *Pool[T, R]) spawnWorkers(n int) {
+	if n == 0 {
+		return
+	}
+	go func() {
+		for i := 0; i < n; i++ {
+			p.AddWorker()
+
+			p.mu.Lock()
+			p.spawning--
+			p.mu.Unlock()
+		}
+	}()
+}
+
+// idleWait перезапускает таймер простоя воркера и возвращает его канал (nil — без таймаута).
+func (p *Pool[T, R]) idleWait(timer Timer) <-chan time.Time {
+	if timer == nil {
+		return nil
+	}
+	if !timer.Stop() {
+		select {
+		case <-timer.C():
+		default:
+		}
+	}
+	timer.Reset(p.workerIdleTimeout)
+	return timer.C()
+}
+
+// retireIdle снимает воркера id, простоявшего WithWorkerIdleTimeout.
+// Возвращает false, если в очереди появились задания и воркеру стоит остаться.
+func (p *Pool[T, R]) retireIdle(id int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.queue.len() > 0 {
+		return false
+	}
+	p.logger.Debug("stopping idle worker", "worker", id)
+	return p.retireLocked(id)
+}
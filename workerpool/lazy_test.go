@@ -0,0 +1,68 @@
+package workerpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestMaxWorkersSpawnOnDemand(t *testing.T) {
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	release := make(chan struct{})
+	pool := workerpool.NewPool[int, int](
+		workerpool.WithHandler(func(ctx context.Context, job int) (int, error) {
+			<-release
+			return job, nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithMaxWorkers(3),
+		workerpool.WithWorkerIdleTimeout(time.Minute),
+	)
+	defer pool.Shutdown(context.Background())
+
+	if n := pool.Stats().Workers; n != 0 {
+		t.Fatalf("Workers before any job = %d, want 0", n)
+	}
+	// Воркеры запускаются под задания, но не больше WithMaxWorkers
+	for i := 0; i < 5; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for pool.Stats().Workers < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Workers = %d, want 3 for 5 queued jobs", pool.Stats().Workers)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if n := pool.Stats().Workers; n != 3 {
+		t.Fatalf("Workers = %d, want at most 3", n)
+	}
+
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := pool.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	// Простаивающие воркеры завершаются только по истечении WithWorkerIdleTimeout на часах пула
+	elapsed := advanceUntil(t, clock, time.Second, "idle workers stopped", func() bool { return pool.Stats().Workers == 0 })
+	if elapsed < time.Minute {
+		t.Errorf("idle workers stopped after %v, want at least the 1m idle timeout", elapsed)
+	}
+
+	// Новое задание снова запускает воркера
+	future, err := pool.Submit(7)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	advanceUntil(t, clock, time.Millisecond, "job after idle stop", isDone(future))
+	if got := future.Result(); got != 7 {
+		t.Errorf("result = %d, want 7", got)
+	}
+}
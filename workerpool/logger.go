@@ -0,0 +1,22 @@
+package workerpool
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithLogger задаёт логгер для событий пула: запуска и остановки воркеров,
+// обработки, повторов и ошибок заданий. По умолчанию события не пишутся никуда.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// discardHandler — slog.Handler, отбрасывающий все записи.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
@@ -0,0 +1,65 @@
+package workerpool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// syncBuffer — буфер для slog, в который пишут несколько воркеров.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWithLogger(t *testing.T) {
+	var out syncBuffer
+	logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			if job == "bad" {
+				return "", errors.New("boom")
+			}
+			return job, nil
+		}),
+		WithLogger(logger),
+		WithInitialWorkers(1),
+	)
+	for _, job := range []string{"good", "bad"} {
+		if err := pool.SendJob(job); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	log := out.String()
+	for _, want := range []string{
+		`msg="worker started"`,
+		`msg="processing job"`,
+		`msg="job failed"`,
+		`error=boom`,
+		`msg="worker stopped"`,
+	} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log has no %s:\n%s", want, log)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package workerpool
+
+import (
+	"errors"
+	"runtime"
+	"time"
+)
+
+// ErrMemoryPressure возвращается SendJob, когда куча процесса превысила порог WithMemoryGuard.
+var ErrMemoryPressure = errors.New("job rejected: memory pressure")
+
+// memSampleInterval — период опроса runtime.ReadMemStats для WithMemoryGuard.
+// ReadMemStats останавливает мир, поэтому вызывать его на каждое задание слишком дорого.
+const memSampleInterval = 500 * time.Millisecond
+
+// guardMemory периодически обновляет флаг нехватки памяти до вызова Shutdown.
+func (p *Pool[T, R]) guardMemory() {
+	ticker := time.NewTicker(memSampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.sampleMemory()
+		}
+	}
+}
+
+// sampleMemory сравнивает текущий размер кучи с порогом WithMemoryGuard.
+func (p *Pool[T, R]) sampleMemory() {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	p.memoryPressure.Store(m.HeapAlloc > p.maxHeapBytes)
+}
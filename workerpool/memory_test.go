@@ -0,0 +1,45 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMemoryGuardRejectsUnderPressure(t *testing.T) {
+	// Порог заведомо выше кучи теста: фоновый опрос сам флаг не взведёт
+	pool := NewPool[int, int](
+		WithHandler(echo[int]),
+		WithInitialWorkers(1),
+		WithMemoryGuard(math.MaxUint64),
+	)
+	defer pool.Shutdown(context.Background())
+
+	if err := pool.SendJob(1); err != nil {
+		t.Fatalf("SendJob without pressure: %v", err)
+	}
+
+	pool.memoryPressure.Store(true)
+	if err := pool.SendJob(2); !errors.Is(err, ErrMemoryPressure) {
+		t.Fatalf("SendJob under pressure error = %v, want ErrMemoryPressure", err)
+	}
+	if _, err := pool.Submit(3); !errors.Is(err, ErrMemoryPressure) {
+		t.Fatalf("Submit under pressure error = %v, want ErrMemoryPressure", err)
+	}
+
+	pool.memoryPressure.Store(false)
+	if err := pool.SendJob(4); err != nil {
+		t.Fatalf("SendJob after pressure cleared: %v", err)
+	}
+}
+
+func TestMemoryGuardSample(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]), WithMemoryGuard(1))
+	defer pool.Shutdown(context.Background())
+
+	// Любая куча больше байта: первый же замер при создании пула взводит флаг
+	if err := pool.SendJob(1); !errors.Is(err, ErrMemoryPressure) {
+		t.Fatalf("SendJob error = %v, want ErrMemoryPressure", err)
+	}
+}
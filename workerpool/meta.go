@@ -0,0 +1,33 @@
+package workerpool
+
+// SetMeta задаёт метку пула, например владельца или назначение.
+func (p *Pool[T, R]) SetMeta(key, value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.meta == nil {
+		p.meta = make(map[string]string)
+	}
+	p.meta[key] = value
+}
+
+// Meta возвращает значение метки пула.
+func (p *Pool[T, R]) Meta(key string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	value, ok := p.meta[key]
+	return value, ok
+}
+
+// AllMeta возвращает копию всех меток пула.
+func (p *Pool[T, R]) AllMeta() map[string]string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	meta := make(map[string]string, len(p.meta))
+	for key, value := range p.meta {
+		meta[key] = value
+	}
+	return meta
+}
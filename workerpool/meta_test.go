@@ -0,0 +1,50 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestPoolMeta(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]))
+	defer pool.Shutdown(context.Background())
+
+	if _, ok := pool.Meta("owner"); ok {
+		t.Fatal("Meta on a fresh pool reported a value")
+	}
+	pool.SetMeta("owner", "billing")
+	pool.SetMeta("purpose", "invoices")
+	pool.SetMeta("owner", "payments")
+
+	if value, ok := pool.Meta("owner"); !ok || value != "payments" {
+		t.Errorf("Meta(owner) = %q, %t, want payments", value, ok)
+	}
+	all := pool.AllMeta()
+	if len(all) != 2 || all["purpose"] != "invoices" {
+		t.Errorf("AllMeta = %v", all)
+	}
+
+	// AllMeta возвращает копию: её изменение не задевает метки пула
+	all["owner"] = "someone else"
+	delete(all, "purpose")
+	if value, _ := pool.Meta("owner"); value != "payments" {
+		t.Errorf("Meta(owner) after modifying the copy = %q", value)
+	}
+	if _, ok := pool.Meta("purpose"); !ok {
+		t.Error("deleting from the copy removed the pool's metadata")
+	}
+
+	// Метки безопасно менять и читать из разных горутин
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pool.SetMeta("region", "eu")
+			pool.Meta("region")
+			pool.AllMeta()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,34 @@
+package workerpool
+
+import (
+	"fmt"
+)
+
+// Middleware оборачивает обработчик заданий дополнительным поведением —
+// журналированием, трассировкой, метриками — по аналогии с HTTP middleware.
+type Middleware[T, R any] func(next Handler[T, R]) Handler[T, R]
+
+// WithMiddleware добавляет middleware вокруг обработчика из WithHandler.
+// Первое переданное middleware оказывается внешним: для WithMiddleware(a, b)
+// задание проходит через a, затем b, затем обработчик. Несколько вызовов WithMiddleware
+// добавляют middleware в порядке вызовов. Каждая попытка по WithRetry проходит через всю цепочку;
+// на пакетный обработчик WithBatchHandler middleware не действует.
+func WithMiddleware[T, R any](mw ...Middleware[T, R]) Option {
+	return func(c *config) {
+		for _, m := range mw {
+			c.middleware = append(c.middleware, m)
+		}
+	}
+}
+
+// chainMiddleware оборачивает handler в middleware из WithMiddleware.
+func chainMiddleware[T, R any](handler Handler[T, R], middleware []any) Handler[T, R] {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		m, ok := middleware[i].(Middleware[T, R])
+		if !ok {
+			panic(fmt.Sprintf("workerpool: middleware type %T does not match pool type %T", middleware[i], Middleware[T, R](nil)))
+		}
+		handler = m(handler)
+	}
+	return handler
+}
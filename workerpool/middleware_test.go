@@ -0,0 +1,76 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	trace := func(name string) Middleware[int, int] {
+		return func(next Handler[int, int]) Handler[int, int] {
+			return func(ctx context.Context, job int) (int, error) {
+				mu.Lock()
+				calls = append(calls, name+">")
+				mu.Unlock()
+				result, err := next(ctx, job)
+				mu.Lock()
+				calls = append(calls, "<"+name)
+				mu.Unlock()
+				return result + 1, err
+			}
+		}
+	}
+
+	attempts := 0
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			mu.Lock()
+			calls = append(calls, "handler")
+			mu.Unlock()
+			if attempts++; attempts == 1 {
+				return 0, errors.New("try again")
+			}
+			return job, nil
+		}),
+		WithMiddleware(trace("a"), trace("b")),
+		WithMiddleware(trace("c")),
+		WithRetry(RetryPolicy{MaxAttempts: 2, Backoff: func(int) time.Duration { return 0 }}),
+		WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	future, err := pool.Submit(10)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if got, err := await(t, future); err != nil || got != 13 {
+		t.Fatalf("result = %d, %v; want 13, nil", got, err)
+	}
+
+	// Первая попытка неудачна и повторяется через всю цепочку
+	once := []string{"a>", "b>", "c>", "handler", "<c", "<b", "<a"}
+	mu.Lock()
+	defer mu.Unlock()
+	if want := append(slices.Clone(once), once...); !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestMiddlewareTypeMismatchPanics(t *testing.T) {
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.HasPrefix(msg, "workerpool: middleware type") {
+			t.Errorf("panic = %q, want a middleware type mismatch", msg)
+		}
+	}()
+	NewPool[int, int](WithHandler(echo[int]), WithMiddleware(func(next Handler[string, string]) Handler[string, string] {
+		return next
+	}))
+}
@@ -0,0 +1,245 @@
+package workerpool
+
+import (
+	"log/slog"
+	"time"
+)
+
+// config — настройки пула, не зависящие от типов заданий и результатов.
+// Благодаря этому опции не нужно параметризовать типами пула.
+type config struct {
+	// jobHandler — Handler[T, R] из WithHandler; тип проверяется в NewPool
+	jobHandler any
+
+	// namedHandlers — Handler[T, R] из WithNamedHandler по типу задания
+	namedHandlers map[string]any
+
+	bufferSize     int
+	initialWorkers int
+
+	strictShutdown bool
+	shutdownMode   ShutdownMode
+
+	// capacity — допустимая суммарная стоимость заданий в работе (0 — без ограничения)
+	capacity int64
+
+	// maxHeapBytes — порог кучи для WithMemoryGuard (0 — охрана выключена)
+	maxHeapBytes uint64
+
+	// maxWorkers — предел воркеров, запускаемых по требованию (0 — ленивый запуск выключен)
+	maxWorkers int
+
+	// workerIdleTimeout — время простоя, после которого воркер завершается (0 — не завершается)
+	workerIdleTimeout time.Duration
+
+	// spawnInterval — минимальный интервал между запусками воркеров (0 — без ограничения)
+	spawnInterval time.Duration
+
+	// slowStartRamp — длительность разгона для WithSlowStart (0 — выключен)
+	slowStartRamp time.Duration
+
+	// units — семафор WithConcurrencyUnits (nil — веса заданий не учитываются)
+	units *unitSemaphore
+
+	// coordinator — общий бюджет WithCoordinator (nil — пул не подключён), coordinated — участие в нём пула
+	coordinator *Coordinator
+	coordinated *coordinated
+
+	// tagLimits — ограничения WithTagLimit по тегу
+	tagLimits map[string]int
+
+	// workStealing — простаивающие воркеры забирают задания из личных очередей занятых (WithWorkStealing)
+	workStealing bool
+
+	// governor — регулятор нагрузки WithGovernor (nil — выключен)
+	governor *governor
+
+	// breaker — автомат защиты WithCircuitBreaker (nil — выключен)
+	breaker *circuitBreaker
+
+	// limiter — ограничение WithRateLimit (nil — без ограничения)
+	limiter *rateLimiter
+
+	panicHandler PanicHandler
+
+	// autoscale — правила WithAutoscale (nil — автомасштабирование выключено)
+	autoscale *AutoscaleConfig
+
+	// watchdog — сторож зависших заданий WithWatchdog (nil — выключен)
+	watchdog *WatchdogConfig
+
+	// supervised — паника воркера перехватывается (WithSupervision);
+	// maxRequeues — сколько раз задание упавшего воркера возвращается в очередь
+	supervised  bool
+	maxRequeues int
+
+	// jobTTL — срок жизни заданий в очереди (0 — без ограничения); expiredHandler получает отброшенные
+	jobTTL         time.Duration
+	expiredHandler ExpiredHandler
+
+	// retry — политика WithRetry (nil — без повторов)
+	retry *RetryPolicy
+
+	// deadLetters получает задания, завершившиеся ошибкой или не попавшие в очередь (nil — не сохраняются)
+	deadLetters DeadLetterHandler
+
+	// historySize — число записей WithHistory (0 — история не ведётся); auditSink получает все записи
+	historySize int
+	auditSink   AuditSink
+
+	// outcomeWidth и outcomeCount — окна WithOutcomeWindows (0 — итоги по окнам не считаются)
+	outcomeWidth time.Duration
+	outcomeCount int
+
+	// observer получает замеры каждого обработанного задания (nil — не задан)
+	observer MetricsObserver
+
+	logger *slog.Logger
+
+	// batchConfig — настройки WithBatchHandler (nil — пакетный режим выключен)
+	batchConfig *batchConfig
+
+	// batchTarget — целевая задержка WithAdaptiveBatching (0 — пакеты постоянного размера)
+	batchTarget time.Duration
+
+	// middleware — Middleware[T, R] из WithMiddleware, от внешнего к внутреннему
+	middleware []any
+
+	// agingInterval — шаг линейного старения приоритетов (0 — без старения);
+	// agingFunc — функция WithAgingFunc вместо линейного старения (nil — не задана)
+	agingInterval time.Duration
+	agingFunc     AgingFunc
+
+	// submitHooks — SubmitHook[T] из WithSubmitHook в порядке вызова
+	submitHooks []any
+
+	// dedupInFlight — дедупликация учитывает и выполняющиеся задания
+	dedupInFlight bool
+
+	persistence Persistence
+
+	// clock — источник времени WithClock (по умолчанию системное время)
+	clock Clock
+
+	// synchronous — задания выполняются прямо в отправляющей горутине (WithSynchronousMode)
+	synchronous bool
+
+	// codec кодирует задания для журнала и внешних очередей (по умолчанию JSONCodec)
+	codec Codec
+
+	tracer JobTracer
+
+	overflow OverflowPolicy
+
+	// health — пороги WithHealthCheck (nil — по умолчанию)
+	health *HealthConfig
+
+	// resultStream — включён поток Results; orderedResults — в порядке приёма заданий
+	resultStream   bool
+	resultBuffer   int
+	orderedResults bool
+
+	failFast        bool
+	shutdownTimeout time.Duration
+
+	// collectErrors — включён сбор ошибок WithErrorCollection, maxErrors — их предел (меньше 1 — без предела)
+	collectErrors bool
+	maxErrors     int
+
+	workerInit    func(workerID int) (any, error)
+	workerCleanup func(workerID int, state any)
+}
+
+// defaultBufferSize — размер очереди заданий без WithBufferSize.
+const defaultBufferSize = 100
+
+// Option настраивает пул при создании в NewPool.
+type Option func(*config)
+
+// WithHandler задаёт функцию, обрабатывающую каждое задание. Без неё пул принимает
+// только задания SubmitWith и именованных типов, а остальные отклоняет с ErrNoHandler.
+func WithHandler[T, R any](handler Handler[T, R]) Option {
+	return func(c *config) {
+		c.jobHandler = handler
+	}
+}
+
+// WithBufferSize задаёт, сколько заданий может ждать в очереди (по умолчанию 100).
+func WithBufferSize(size int) Option {
+	return func(c *config) {
+		if size >= 0 {
+			c.bufferSize = size
+		}
+	}
+}
+
+// WithInitialWorkers запускает n воркеров сразу при создании пула.
+func WithInitialWorkers(n int) Option {
+	return func(c *config) {
+		c.initialWorkers = n
+	}
+}
+
+// WithStrictShutdown включает строгий режим Shutdown: если в очереди остались задания,
+// а воркеры так ни разу и не были запущены, Shutdown вернёт ошибку с числом потерянных заданий.
+func WithStrictShutdown() Option {
+	return func(c *config) {
+		c.strictShutdown = true
+	}
+}
+
+// WithCapacity ограничивает суммарную стоимость принятых, но ещё не завершённых заданий.
+// Стоимость задаётся через SendJobCost; обычные задания стоят 1.
+func WithCapacity(total int) Option {
+	return func(c *config) {
+		c.capacity = int64(total)
+	}
+}
+
+// WithMemoryGuard включает отказ в приёме заданий, пока куча процесса больше maxHeapBytes.
+// Использование памяти опрашивается периодически в фоне, а не на каждый SendJob.
+func WithMemoryGuard(maxHeapBytes uint64) Option {
+	return func(c *config) {
+		c.maxHeapBytes = maxHeapBytes
+	}
+}
+
+// WithWorkerSpawnRate ограничивает скорость запуска воркеров: не более perSecond в секунду.
+// Лишние вызовы AddWorker ждут своей очереди, что сглаживает нагрузку на холодные зависимости.
+func WithWorkerSpawnRate(perSecond float64) Option {
+	return func(c *config) {
+		if perSecond > 0 {
+			c.spawnInterval = time.Duration(float64(time.Second) / perSecond)
+		}
+	}
+}
+
+// WithRateLimit ограничивает пропускную способность пула: все воркеры вместе вызывают
+// обработчик не чаще perSecond раз в секунду, допуская всплески до burst вызовов подряд.
+// Повторы по WithRetry тоже учитываются. Полезно при работе с внешними API с жёсткими квотами.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(c *config) {
+		if perSecond <= 0 {
+			return
+		}
+		if burst < 1 {
+			burst = 1
+		}
+		c.limiter = &rateLimiter{
+			interval: time.Duration(float64(time.Second) / perSecond),
+			burst:    float64(burst),
+			tokens:   float64(burst),
+		}
+	}
+}
+
+// WithSlowStart включает медленный старт: сразу после создания пула одновременно
+// обрабатывается мало заданий, а допустимый параллелизм линейно растёт до числа воркеров за время ramp.
+// Это бережёт холодные зависимости от всплеска нагрузки на старте, как slow start в TCP.
+func WithSlowStart(ramp time.Duration) Option {
+	return func(c *config) {
+		if ramp > 0 {
+			c.slowStartRamp = ramp
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPoolOptions(t *testing.T) {
+	// Без воркеров задания остаются в очереди, и её размер задаёт WithBufferSize
+	pool := NewPool[int, int](WithHandler(echo[int]), WithBufferSize(2))
+	defer pool.ShutdownNow()
+	for i := 0; i < 2; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob(%d): %v", i, err)
+		}
+	}
+	if err := pool.SendJob(2); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("SendJob over the buffer = %v, want ErrQueueFull", err)
+	}
+
+	// Без WithBufferSize очередь вмещает defaultBufferSize заданий
+	defaults := NewPool[int, int](WithHandler(echo[int]))
+	defer defaults.ShutdownNow()
+	for i := 0; i < defaultBufferSize; i++ {
+		if err := defaults.SendJob(i); err != nil {
+			t.Fatalf("SendJob(%d) with the default buffer: %v", i, err)
+		}
+	}
+	if err := defaults.SendJob(defaultBufferSize); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("SendJob over the default buffer = %v, want ErrQueueFull", err)
+	}
+
+	// Опции применяются по порядку, и последняя побеждает
+	workers := NewPool[int, int](WithHandler(echo[int]), WithInitialWorkers(1), WithInitialWorkers(3))
+	defer workers.Shutdown(context.Background())
+	if n := workers.Stats().Workers; n != 3 {
+		t.Fatalf("Workers = %d, want 3", n)
+	}
+	future, err := workers.Submit(7)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if got, err := await(t, future); err != nil || got != 7 {
+		t.Fatalf("job result = %d, %v; want 7, nil", got, err)
+	}
+}
@@ -0,0 +1,17 @@
+module github.com/Mukam21/go-worker-pool/workerpool/otel
+
+go 1.22
+
+require (
+	github.com/Mukam21/go-worker-pool v0.0.0
+	go.opentelemetry.io/otel v1.26.0
+	go.opentelemetry.io/otel/trace v1.26.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/otel/metric v1.26.0 // indirect
+)
+
+replace github.com/Mukam21/go-worker-pool => ../..
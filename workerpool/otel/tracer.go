@@ -0,0 +1,67 @@
+// Package otel трассирует задания пула workerpool через OpenTelemetry: на каждое задание
+// создаётся span от постановки в очередь до завершения, дочерний к span отправителя.
+// Пакет вынесен в отдельный модуль, чтобы основной пакет не зависел от OpenTelemetry.
+package otel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName — имя библиотеки инструментирования в span.
+const instrumentationName = "github.com/Mukam21/go-worker-pool/workerpool"
+
+// Tracer реализует workerpool.JobTracer поверх OpenTelemetry.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var _ workerpool.JobTracer = (*Tracer)(nil)
+
+// NewTracer создаёт трассировщик заданий. При tp == nil используется глобальный провайдер.
+func NewTracer(tp trace.TracerProvider) *Tracer {
+	if tp == nil {
+		tp = otelapi.GetTracerProvider()
+	}
+	return &Tracer{tracer: tp.Tracer(instrumentationName)}
+}
+
+// WithTracerProvider включает трассировку заданий пула через провайдер tp.
+func WithTracerProvider(tp trace.TracerProvider) workerpool.Option {
+	return workerpool.WithTracer(NewTracer(tp))
+}
+
+// JobEnqueued начинает span задания дочерним к span отправителя из ctx.
+func (t *Tracer) JobEnqueued(ctx context.Context, job any) context.Context {
+	ctx, _ = t.tracer.Start(ctx, "workerpool.job",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attribute.String("workerpool.job", fmt.Sprint(job))),
+	)
+	return ctx
+}
+
+// JobStarted отмечает в span начало выполнения и время ожидания в очереди.
+func (t *Tracer) JobStarted(ctx context.Context, queueWait time.Duration) context.Context {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("started")
+	span.SetAttributes(attribute.Float64("workerpool.queue_wait_ms", float64(queueWait)/float64(time.Millisecond)))
+	return ctx
+}
+
+// JobFinished записывает в span время обработки и ошибку и завершает его.
+func (t *Tracer) JobFinished(ctx context.Context, processing time.Duration, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Float64("workerpool.processing_ms", float64(processing)/float64(time.Millisecond)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,113 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// WindowStats — итоги заданий, завершившихся в одном окне времени OutcomeWindows.
+type WindowStats struct {
+	Start time.Time // начало окна; окна выровнены по их длине
+
+	Succeeded uint64
+	Failed    uint64 // ошибки, кроме истечения срока
+	TimedOut  uint64 // задания, завершившиеся с context.DeadlineExceeded
+}
+
+// WithOutcomeWindows включает подсчёт итогов заданий по окнам длиной width за последние
+// count окон, например по минутам за последний час: WithOutcomeWindows(time.Minute, 60).
+// Такая разбивка показывает закономерности, которые прячут общие счётчики Stats,
+// например всплеск ошибок в начале каждого часа.
+func WithOutcomeWindows(width time.Duration, count int) Option {
+	return func(c *config) {
+		if width <= 0 || count < 1 {
+			panic("workerpool: WithOutcomeWindows requires a positive width and count")
+		}
+		c.outcomeWidth, c.outcomeCount = width, count
+	}
+}
+
+// OutcomeWindows возвращает итоги заданий по окнам WithOutcomeWindows от самого давнего
+// к текущему, включая окна без заданий. Окна до создания пула не возвращаются.
+// Без WithOutcomeWindows — nil.
+func (p *Pool[T, R]) OutcomeWindows() []WindowStats {
+	if p.outcomes == nil {
+		return nil
+	}
+	return p.outcomes.snapshot(p.clock.Now())
+}
+
+// outcomeWindows — кольцо окон итогов. Окно номер n лежит в buckets[n%len(buckets)];
+// ячейка, в которой лежит давно прошедшее окно, обнуляется при первой записи в неё,
+// поэтому кольцо не нужно продвигать по таймеру.
+type outcomeWindows struct {
+	width time.Duration
+	epoch time.Time // начало окна номер 0
+
+	mu      sync.Mutex
+	buckets []outcomeBucket
+}
+
+type outcomeBucket struct {
+	n     int64 // номер окна в ячейке (-1 — пусто)
+	stats WindowStats
+}
+
+func newOutcomeWindows(now time.Time, width time.Duration, count int) *outcomeWindows {
+	w := &outcomeWindows{width: width, epoch: now.Truncate(width), buckets: make([]outcomeBucket, count)}
+	for i := range w.buckets {
+		w.buckets[i].n = -1
+	}
+	return w
+}
+
+// index возвращает номер окна, в которое попадает момент now.
+func (w *outcomeWindows) index(now time.Time) int64 {
+	if now.Before(w.epoch) {
+		// Часы перевели назад — считаем в первом окне
+		return 0
+	}
+	return int64(now.Sub(w.epoch) / w.width)
+}
+
+// record учитывает итог задания, завершившегося в момент now.
+func (w *outcomeWindows) record(now time.Time, err error) {
+	n := w.index(now)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	b := &w.buckets[n%int64(len(w.buckets))]
+	if b.n != n {
+		*b = outcomeBucket{n: n, stats: WindowStats{Start: w.epoch.Add(time.Duration(n) * w.width)}}
+	}
+	switch {
+	case err == nil:
+		b.stats.Succeeded++
+	case errors.Is(err, context.DeadlineExceeded):
+		b.stats.TimedOut++
+	default:
+		b.stats.Failed++
+	}
+}
+
+// snapshot возвращает окна, закончившиеся не раньше len(buckets) окон до now.
+func (w *outcomeWindows) snapshot(now time.Time) []WindowStats {
+	last := w.index(now)
+	first := max(0, last-int64(len(w.buckets))+1)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	windows := make([]WindowStats, 0, last-first+1)
+	for n := first; n <= last; n++ {
+		if b := w.buckets[n%int64(len(w.buckets))]; b.n == n {
+			windows = append(windows, b.stats)
+		} else {
+			windows = append(windows, WindowStats{Start: w.epoch.Add(time.Duration(n) * w.width)})
+		}
+	}
+	return windows
+}
@@ -0,0 +1,68 @@
+package workerpool_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestOutcomeWindows(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 30, 0, time.UTC)
+	clock := workerpooltest.NewClock(start)
+	pool := workerpooltest.NewSynchronousPool(
+		func(ctx context.Context, job string) (string, error) {
+			switch job {
+			case "fail":
+				return "", errors.New("boom")
+			case "timeout":
+				return "", context.DeadlineExceeded
+			}
+			return job, nil
+		},
+		workerpool.WithClock(clock),
+		workerpool.WithOutcomeWindows(time.Minute, 60),
+	)
+	defer pool.Shutdown(context.Background())
+
+	submit := func(jobs ...string) {
+		t.Helper()
+		for _, job := range jobs {
+			if err := pool.SendJob(job); err != nil {
+				t.Fatalf("SendJob(%q): %v", job, err)
+			}
+		}
+	}
+	submit("ok", "ok", "fail")
+	clock.Advance(time.Minute)
+	submit("ok", "timeout", "timeout")
+
+	windows := pool.OutcomeWindows()
+	want := []workerpool.WindowStats{
+		{Start: start.Truncate(time.Minute), Succeeded: 2, Failed: 1},
+		{Start: start.Truncate(time.Minute).Add(time.Minute), Succeeded: 1, TimedOut: 2},
+	}
+	if len(windows) != len(want) {
+		t.Fatalf("got %d windows, want %d: %+v", len(windows), len(want), windows)
+	}
+	for i := range want {
+		if windows[i] != want[i] {
+			t.Errorf("window %d = %+v, want %+v", i, windows[i], want[i])
+		}
+	}
+
+	// Через час первые окна выходят из кольца, а пустые окна всё равно возвращаются
+	clock.Advance(time.Hour)
+	windows = pool.OutcomeWindows()
+	if len(windows) != 60 {
+		t.Fatalf("got %d windows after an hour, want 60", len(windows))
+	}
+	for i, w := range windows {
+		if w.Succeeded+w.Failed+w.TimedOut != 0 {
+			t.Errorf("window %d at %v = %+v, want empty", i, w.Start, w)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// OverflowPolicy определяет, что делать с заданием, когда очередь заполнена.
+type OverflowPolicy int
+
+const (
+	// OverflowError отклоняет новое задание с ErrQueueFull (по умолчанию).
+	OverflowError OverflowPolicy = iota
+	// OverflowDropNewest молча отбрасывает новое задание: отправка считается успешной,
+	// Future задания завершается с ErrJobDropped, а само задание уходит в WithDeadLetter.
+	OverflowDropNewest
+	// OverflowDropOldest вытесняет из очереди самое давнее задание, освобождая место новому.
+	// Future вытесненного завершается с ErrJobDropped, а само оно уходит в WithDeadLetter.
+	OverflowDropOldest
+	// OverflowBlock заставляет отправителя ждать места в очереди, как SendJobContext,
+	// пока пул не начнёт останавливаться.
+	OverflowBlock
+)
+
+func (o OverflowPolicy) String() string {
+	switch o {
+	case OverflowError:
+		return "error"
+	case OverflowDropNewest:
+		return "drop-newest"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowBlock:
+		return "block"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(o))
+	}
+}
+
+// WithOverflowPolicy задаёт поведение SendJob, Submit и прочих неблокирующих методов
+// при заполненной очереди. SendJobContext всегда ждёт места, независимо от политики.
+func WithOverflowPolicy(policy OverflowPolicy) Option {
+	return func(c *config) {
+		c.overflow = policy
+	}
+}
+
+// enqueueOverflow ставит задание в очередь, применяя политику переполнения.
+func (p *Pool[T, R]) enqueueOverflow(t *task[T, R]) error {
+	for {
+		err := p.tryEnqueue(t)
+		if !errors.Is(err, ErrQueueFull) {
+			return err
+		}
+		switch p.overflow {
+		case OverflowBlock:
+			return p.waitAndEnqueue(context.Background(), t)
+		case OverflowDropOldest:
+			if p.evictOldest() {
+				continue
+			}
+		}
+		return err
+	}
+}
+
+// evictOldest вытесняет самое давнее задание из очереди. Возвращает false, если очередь пуста.
+func (p *Pool[T, R]) evictOldest() bool {
+	p.mu.Lock()
+	t := p.queue.oldest()
+	if t == nil {
+		p.mu.Unlock()
+		return false
+	}
+	p.removeQueuedLocked(t)
+	p.mu.Unlock()
+
+	p.logger.Warn("evicting oldest job from full queue", "job", t.job, "id", t.id)
+	p.traceFinished(t, 0, ErrJobDropped)
+	p.emit(EventDropped, t, 0, ErrJobDropped)
+	p.deadLetter(t.job, ErrJobDropped, 0)
+	t.finish(*new(R), ErrJobDropped)
+	p.publishResult(t, *new(R), ErrJobDropped)
+	p.jobDone(t)
+	return true
+}
@@ -0,0 +1,91 @@
+package workerpool
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOverflowPolicies(t *testing.T) {
+	// fill создаёт пул без воркеров с очередью на два задания и заполняет её
+	fill := func(policy OverflowPolicy) (*Pool[string, string], *DeadLetterQueue, []*Future[string]) {
+		dead := NewDeadLetterQueue(4)
+		pool := NewPool[string, string](WithHandler(echo[string]), WithBufferSize(2),
+			WithOverflowPolicy(policy), WithDeadLetter(dead))
+		var futures []*Future[string]
+		for _, job := range []string{"old", "mid"} {
+			future, err := pool.Submit(job)
+			if err != nil {
+				t.Fatalf("Submit: %v", err)
+			}
+			futures = append(futures, future)
+		}
+		return pool, dead, futures
+	}
+
+	t.Run("error", func(t *testing.T) {
+		pool, _, _ := fill(OverflowError)
+		defer pool.ShutdownNow()
+		if _, err := pool.Submit("new"); !errors.Is(err, ErrQueueFull) {
+			t.Fatalf("Submit to a full queue = %v, want ErrQueueFull", err)
+		}
+	})
+
+	t.Run("drop-newest", func(t *testing.T) {
+		pool, dead, _ := fill(OverflowDropNewest)
+		defer pool.ShutdownNow()
+		future, err := pool.Submit("new")
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		if _, err := await(t, future); !errors.Is(err, ErrJobDropped) {
+			t.Errorf("dropped job error = %v, want ErrJobDropped", err)
+		}
+		if dl := <-dead.C(); dl.Job != "new" {
+			t.Errorf("dead letter job = %v, want new", dl.Job)
+		}
+		if jobs := pool.ShutdownNow(); len(jobs) != 2 || jobs[0] != "old" {
+			t.Errorf("queue = %v, want [old mid]", jobs)
+		}
+	})
+
+	t.Run("drop-oldest", func(t *testing.T) {
+		pool, dead, futures := fill(OverflowDropOldest)
+		defer pool.ShutdownNow()
+		if err := pool.SendJob("new"); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+		if _, err := await(t, futures[0]); !errors.Is(err, ErrJobDropped) {
+			t.Errorf("evicted job error = %v, want ErrJobDropped", err)
+		}
+		if dl := <-dead.C(); dl.Job != "old" {
+			t.Errorf("dead letter job = %v, want old", dl.Job)
+		}
+		if jobs := pool.ShutdownNow(); len(jobs) != 2 || jobs[0] != "mid" || jobs[1] != "new" {
+			t.Errorf("queue = %v, want [mid new]", jobs)
+		}
+	})
+
+	t.Run("block", func(t *testing.T) {
+		pool, _, futures := fill(OverflowBlock)
+		defer pool.ShutdownNow()
+		sent := make(chan error, 1)
+		go func() { sent <- pool.SendJob("new") }()
+		select {
+		case err := <-sent:
+			t.Fatalf("SendJob to a full queue returned %v instead of blocking", err)
+		case <-time.After(20 * time.Millisecond):
+		}
+		// Воркер освобождает место, и отправитель дожидается его
+		pool.AddWorker()
+		await(t, futures[1])
+		select {
+		case err := <-sent:
+			if err != nil {
+				t.Fatalf("blocked SendJob: %v", err)
+			}
+		case <-time.After(testTimeout):
+			t.Fatal("SendJob still blocked after the queue drained")
+		}
+	})
+}
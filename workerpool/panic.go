@@ -0,0 +1,54 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicHandler получает задание, значение из recover и стек горутины,
+// когда обработчик задания паникует. Воркер при этом продолжает работу.
+type PanicHandler func(job any, recovered any, stack []byte)
+
+// WithPanicHandler задаёт функцию, вызываемую при панике в обработчике задания.
+// Без неё паника только пишется в лог (WithLogger), а задание завершается с *PanicError.
+func WithPanicHandler(h PanicHandler) Option {
+	return func(c *config) {
+		c.panicHandler = h
+	}
+}
+
+// PanicError — ошибка задания, обработчик которого запаниковал.
+type PanicError struct {
+	Value any    // значение, переданное в panic
+	Stack []byte // стек горутины в момент паники
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("job handler panicked: %v", e.Value)
+}
+
+// PanicCounter возвращает число паник, перехваченных пулом за всё время его работы:
+// в обработчиках, включая пакетные, и, с WithSupervision, в самих воркерах. Упавшие задания видны и в Stats.Failed, но счётчик паник
+// позволяет тестам проверить именно их отсутствие: PanicCounter() == 0 после прогона.
+func (p *Pool[T, R]) PanicCounter() uint64 {
+	return p.panics.Load()
+}
+
+// call вызывает обработчик, превращая панику в *PanicError, чтобы она не убила воркера и весь процесс.
+func (p *Pool[T, R]) call(ctx context.Context, job T) (value R, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.panics.Add(1)
+			stack := debug.Stack()
+			err = &PanicError{Value: r, Stack: stack}
+			if p.panicHandler != nil {
+				p.panicHandler(job, r, stack)
+			} else {
+				p.logger.Error("job handler panicked", "job", job, "panic", r, "stack", string(stack))
+			}
+		}
+	}()
+
+	return p.handler(ctx, job)
+}
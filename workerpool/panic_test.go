@@ -0,0 +1,96 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPanicCounter(t *testing.T) {
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			if job < 0 {
+				panic("negative job")
+			}
+			return job, nil
+		}),
+		WithInitialWorkers(2),
+		WithPanicHandler(func(job any, recovered any, stack []byte) {}),
+	)
+	defer pool.Shutdown(context.Background())
+
+	for i := 0; i < 10; i++ {
+		future, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		if _, err := await(t, future); err != nil {
+			t.Fatalf("job %d failed: %v", i, err)
+		}
+	}
+	if n := pool.PanicCounter(); n != 0 {
+		t.Fatalf("PanicCounter after a clean run = %d, want 0", n)
+	}
+
+	for i := 1; i <= 3; i++ {
+		future, err := pool.Submit(-i)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		var panicErr *PanicError
+		if _, err := await(t, future); !errors.As(err, &panicErr) {
+			t.Fatalf("job error = %v, want *PanicError", err)
+		}
+	}
+	if n := pool.PanicCounter(); n != 3 {
+		t.Errorf("PanicCounter = %d, want 3", n)
+	}
+}
+
+func TestPanicHandlerKeepsWorkerAlive(t *testing.T) {
+	type report struct {
+		job       any
+		recovered any
+		stack     []byte
+	}
+	reports := make(chan report, 1)
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			if job == "bad" {
+				panic("bad input")
+			}
+			return job, nil
+		}),
+		WithInitialWorkers(1),
+		WithPanicHandler(func(job any, recovered any, stack []byte) {
+			reports <- report{job, recovered, stack}
+		}),
+	)
+	defer pool.Shutdown(context.Background())
+
+	bad, err := pool.Submit("bad")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	var panicErr *PanicError
+	if _, err := await(t, bad); !errors.As(err, &panicErr) || panicErr.Value != "bad input" {
+		t.Fatalf("panicking job error = %v, want *PanicError with the panic value", err)
+	}
+	r := <-reports
+	if r.job != "bad" || r.recovered != "bad input" || !strings.Contains(string(r.stack), "panic") {
+		t.Errorf("panic handler got job %v, value %v, stack %d bytes", r.job, r.recovered, len(r.stack))
+	}
+
+	// Тот же воркер продолжает обрабатывать задания
+	good, err := pool.Submit("good")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if value, err := await(t, good); err != nil || value != "good" {
+		t.Errorf("job after panic = %q, %v", value, err)
+	}
+	if n := pool.Stats().Workers; n != 1 {
+		t.Errorf("workers after panic = %d, want 1", n)
+	}
+}
@@ -0,0 +1,101 @@
+package workerpool
+
+// SendJobForPartition помещает в очередь задание с ключом партиции. Задания одной партиции
+// выполняются строго по одному и в порядке отправки, а задания разных партиций — параллельно,
+// как сообщения в партициях Kafka. Удобно для состояния отдельных сущностей: все события
+// заказа №123 обработаются по порядку.
+//
+// В очереди воркеров от каждой партиции находится не больше одного задания, остальные ждут
+// завершения предыдущего, но занимают места в буфере наравне с обычными.
+func (p *Pool[T, R]) SendJobForPartition(partition string, job T) error {
+	return p.enqueue(&task[T, R]{job: job, cost: 1, partition: partition})
+}
+
+// SubmitForPartition — то же, что SendJobForPartition, но возвращает Future с результатом задания.
+func (p *Pool[T, R]) SubmitForPartition(partition string, job T) (*Future[R], error) {
+	t := &task[T, R]{job: job, cost: 1, partition: partition, future: newFuture[R]()}
+	if err := p.enqueue(t); err != nil {
+		return nil, err
+	}
+	return t.future, nil
+}
+
+// queuedLocked возвращает число ждущих заданий, включая ждущие своей очереди в партициях
+// и тегах WithTagLimit и в личных очередях воркеров. Вызывается под p.mu.
+func (p *Pool[T, R]) queuedLocked() int {
+	return p.queue.len() + p.backlogged + p.pinnedCount
+}
+
+// holdPartitionLocked откладывает задание, если в его партиции уже есть задание в очереди
+// или в работе. Возвращает true, если задание отложено. Вызывается под p.mu.
+func (p *Pool[T, R]) holdPartitionLocked(t *task[T, R]) bool {
+	if t.partition == "" {
+		return false
+	}
+	backlog, active := p.partitions[t.partition]
+	if !active {
+		if p.partitions == nil {
+			p.partitions = make(map[string][]*task[T, R])
+		}
+		// Партиция занята самим заданием до его завершения
+		p.partitions[t.partition] = nil
+		return false
+	}
+	t.held = true
+	p.partitions[t.partition] = append(backlog, t)
+	p.backlogged++
+	return true
+}
+
+// releasePartitionLocked ставит в очередь следующее задание партиции после завершения
+// задания t или освобождает партицию. Вызывается под p.mu.
+func (p *Pool[T, R]) releasePartitionLocked(t *task[T, R]) {
+	if t.partition == "" || t.held {
+		return
+	}
+	backlog, active := p.partitions[t.partition]
+	if !active {
+		// Партиции уже сброшены в dropQueued
+		return
+	}
+	if len(backlog) == 0 {
+		delete(p.partitions, t.partition)
+		return
+	}
+	next := backlog[0]
+	backlog[0] = nil
+	p.partitions[t.partition] = backlog[1:]
+	p.backlogged--
+	next.held = false
+	p.queueLocked(next, p.clock.Now())
+	p.closeTokensIfDrainedLocked()
+}
+
+// removeHeldLocked убирает отложенное задание из очереди его партиции.
+// Возвращает false, если задание не отложено. Вызывается под p.mu.
+func (p *Pool[T, R]) removeHeldLocked(t *task[T, R]) bool {
+	if !t.held {
+		return false
+	}
+	backlog := p.partitions[t.partition]
+	for i, held := range backlog {
+		if held == t {
+			p.partitions[t.partition] = append(backlog[:i], backlog[i+1:]...)
+			p.backlogged--
+			p.closeTokensIfDrainedLocked()
+			return true
+		}
+	}
+	return false
+}
+
+// dropHeldLocked извлекает все отложенные задания партиций. Вызывается под p.mu.
+func (p *Pool[T, R]) dropHeldLocked() []*task[T, R] {
+	var tasks []*task[T, R]
+	for _, backlog := range p.partitions {
+		tasks = append(tasks, backlog...)
+	}
+	p.partitions = nil
+	p.backlogged -= len(tasks)
+	return tasks
+}
@@ -0,0 +1,79 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPartitionsRunSequentially(t *testing.T) {
+	const partitions, perPartition = 3, 5
+	var (
+		mu        sync.Mutex
+		running   = map[string]int{}
+		order     = map[string][]string{}
+		parallel  int
+		maxActive int
+	)
+	pool := NewPool[string, string](WithHandler(func(ctx context.Context, job string) (string, error) {
+		partition, _, _ := strings.Cut(job, "/")
+		mu.Lock()
+		running[partition]++
+		if running[partition] > 1 {
+			t.Errorf("partition %s runs %d jobs at once", partition, running[partition])
+		}
+		parallel++
+		maxActive = max(maxActive, parallel)
+		order[partition] = append(order[partition], job)
+		mu.Unlock()
+
+		time.Sleep(2 * time.Millisecond)
+
+		mu.Lock()
+		running[partition]--
+		parallel--
+		mu.Unlock()
+		return job, nil
+	}), WithInitialWorkers(partitions))
+	defer pool.Shutdown(context.Background())
+
+	var last *Future[string]
+	for i := 0; i < perPartition; i++ {
+		for p := 0; p < partitions; p++ {
+			future, err := pool.SubmitForPartition(fmt.Sprint("order-", p), fmt.Sprintf("order-%d/%d", p, i))
+			if err != nil {
+				t.Fatalf("SubmitForPartition: %v", err)
+			}
+			last = future
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := pool.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if got, err := await(t, last); err != nil || got != fmt.Sprintf("order-%d/%d", partitions-1, perPartition-1) {
+		t.Fatalf("last job result = %q, %v", got, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for p := 0; p < partitions; p++ {
+		partition := fmt.Sprint("order-", p)
+		if len(order[partition]) != perPartition {
+			t.Errorf("partition %s ran %d jobs, want %d", partition, len(order[partition]), perPartition)
+		}
+		for i, job := range order[partition] {
+			if want := fmt.Sprintf("%s/%d", partition, i); job != want {
+				t.Errorf("partition %s job %d = %s, want %s", partition, i, job, want)
+			}
+		}
+	}
+	// Разные партиции выполняются параллельно
+	if maxActive < 2 {
+		t.Errorf("at most %d jobs ran at once, want partitions in parallel", maxActive)
+	}
+}
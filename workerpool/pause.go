@@ -0,0 +1,92 @@
+package workerpool
+
+import "fmt"
+
+// Pause приостанавливает обработку и переводит пул в Paused: воркеры доделывают текущие
+// задания и больше не берут новых, а задания продолжают приниматься и ждут в очереди.
+// Shutdown снимает паузу, чтобы дообработать очередь. Возвращает ErrInvalidTransition,
+// если пул уже на паузе, и ErrPoolClosed, если остановка уже началась.
+func (p *Pool[T, R]) Pause() error {
+	notify := noop
+	defer func() { notify() }()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch p.state {
+	case Draining, Closed:
+		return fmt.Errorf("cannot pause: %w (%s)", ErrPoolClosed, p.state)
+	case Paused:
+		return fmt.Errorf("cannot pause: %w (already paused)", ErrInvalidTransition)
+	}
+	if p.idleTimer != nil {
+		// Переход в Idle больше не нужен: Resume сам выберет Idle или Busy
+		p.idleTimer.Stop()
+		p.idleTimer = nil
+	}
+	resume := make(chan struct{})
+	p.paused = true
+	p.resume = resume
+	p.pauseC.Store(&resume)
+	notify = p.setStateLocked(Paused)
+	return nil
+}
+
+// Resume продолжает обработку после Pause и возвращает пул в Busy или, если заданий нет, в Idle.
+// Возвращает ErrInvalidTransition, если пул не на паузе, и ErrPoolClosed, если остановка уже началась.
+func (p *Pool[T, R]) Resume() error {
+	notify := noop
+	defer func() { notify() }()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch p.state {
+	case Draining, Closed:
+		return fmt.Errorf("cannot resume: %w (%s)", ErrPoolClosed, p.state)
+	case Paused:
+	default:
+		return fmt.Errorf("cannot resume: %w (not paused)", ErrInvalidTransition)
+	}
+	p.resumeLocked()
+	if p.pending > 0 {
+		notify = p.setStateLocked(Busy)
+	} else {
+		notify = p.setStateLocked(Idle)
+	}
+	// В режиме WithMaxWorkers запускаем воркеров для накопившихся за паузу заданий
+	p.spawnWorkers(p.spawnNeededLocked())
+	return nil
+}
+
+// Paused сообщает, приостановлен ли пул.
+func (p *Pool[T, R]) Paused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.paused
+}
+
+func (p *Pool[T, R]) resumeLocked() {
+	if !p.paused {
+		return
+	}
+	p.paused = false
+	p.pauseC.Store(nil)
+	// Возвращаем жетоны, которые воркеры успели взять во время паузы
+	for ; p.heldTokens > 0; p.heldTokens-- {
+		p.tokens <- struct{}{}
+	}
+	for id := range p.affinity {
+		p.wakePinnedLocked(id)
+	}
+	close(p.resume)
+}
+
+// pauseWait возвращает канал, закрывающийся при снятии паузы, или nil, если пул не на паузе.
+// Воркер вызывает её перед каждым заданием, поэтому обходится без p.mu; пауза, наступившая
+// сразу после проверки, всё равно учитывается в startWork.
+func (p *Pool[T, R]) pauseWait() <-chan struct{} {
+	if resume := p.pauseC.Load(); resume != nil {
+		return *resume
+	}
+	return nil
+}
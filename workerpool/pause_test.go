@@ -0,0 +1,102 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPauseLetsRunningJobsFinish(t *testing.T) {
+	started, release := make(chan int, 4), make(chan struct{})
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		started <- job
+		<-release
+		return job, nil
+	}), WithInitialWorkers(2))
+	defer pool.Shutdown(context.Background())
+
+	running, err := pool.Submit(1)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	<-started
+	if err := pool.Pause(); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	queued, err := pool.Submit(2)
+	if err != nil {
+		t.Fatalf("Submit while paused: %v", err)
+	}
+
+	// Начатое до паузы задание доделывается, а второй воркер нового не берёт
+	close(release)
+	if got, err := await(t, running); err != nil || got != 1 {
+		t.Fatalf("running job = %d, %v; want 1, nil", got, err)
+	}
+	select {
+	case job := <-started:
+		t.Fatalf("job %d started while the pool was paused", job)
+	case <-time.After(20 * time.Millisecond):
+	}
+	if n := pool.QueueLen(); n != 1 {
+		t.Fatalf("QueueLen while paused = %d, want 1", n)
+	}
+
+	if err := pool.Resume(); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if got, err := await(t, queued); err != nil || got != 2 {
+		t.Fatalf("queued job after Resume = %d, %v; want 2, nil", got, err)
+	}
+}
+
+func TestShutdownNowKeepsPausedQueue(t *testing.T) {
+	for _, discard := range []bool{false, true} {
+		var ran atomic.Int32
+		opts := []Option{WithHandler(func(ctx context.Context, job int) (int, error) {
+			ran.Add(1)
+			return job, nil
+		}), WithInitialWorkers(1)}
+		if discard {
+			opts = append(opts, WithShutdownMode(ShutdownDiscard))
+		}
+		pool := NewPool[int, int](opts...)
+		// Воркер только что обработал задание и ждёт следующего, когда пул ставят на паузу
+		warmup, err := pool.Submit(-1)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		await(t, warmup)
+		ran.Store(0)
+		if err := pool.Pause(); err != nil {
+			t.Fatalf("Pause: %v", err)
+		}
+		var futures []*Future[int]
+		for i := 0; i < 8; i++ {
+			future, err := pool.Submit(i)
+			if err != nil {
+				t.Fatalf("Submit: %v", err)
+			}
+			futures = append(futures, future)
+		}
+
+		// Снятие паузы при остановке не должно отдать воркерам задания, которые отбрасываются
+		if discard {
+			if err := pool.Shutdown(context.Background()); err != nil {
+				t.Fatalf("Shutdown: %v", err)
+			}
+		} else if jobs := pool.ShutdownNow(); len(jobs) != 8 {
+			t.Errorf("ShutdownNow returned %d jobs, want 8", len(jobs))
+		}
+		if n := ran.Load(); n != 0 {
+			t.Errorf("discard %t: %d queued jobs ran during shutdown of a paused pool", discard, n)
+		}
+		for _, future := range futures {
+			if _, err := await(t, future); !errors.Is(err, ErrJobDropped) {
+				t.Errorf("discard %t: job %d error = %v, want ErrJobDropped", discard, future.ID(), err)
+			}
+		}
+	}
+}
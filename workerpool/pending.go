@@ -0,0 +1,114 @@
+package workerpool
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrJobRemoved — задание убрано из очереди через RemovePending до начала выполнения.
+var ErrJobRemoved = errors.New("job removed from queue")
+
+// PendingJob — сведения о задании, ждущем в очереди.
+type PendingJob[T any] struct {
+	ID        JobID
+	Job       T
+	Type      string    // тип задания из SubmitNamed (пустой — без типа)
+	Priority  int       // приоритет из SendJobWithPriority без учёта старения
+	Tenant    string    // ключ SendJobForTenant (пустой — общая очередь)
+	Partition string    // ключ SendJobForPartition (пустой — без партиции)
+	Tags      []string  // теги SendJobWithTags
+	Worker    int       // воркер из SubmitToWorker (-1 — любой)
+	Enqueued  time.Time // время постановки в очередь
+	Metadata  map[string]string
+}
+
+// PendingJobs возвращает задания, ждущие в очереди, в порядке их приёма: общую очередь,
+// задания, ожидающие своей очереди в партициях и тегах, и личные очереди воркеров.
+// Выполняющиеся задания в список не входят. Список — снимок: к моменту использования
+// часть заданий может уже начать выполняться.
+func (p *Pool[T, R]) PendingJobs() []PendingJob[T] {
+	p.mu.Lock()
+	tasks := p.pendingTasksLocked()
+	jobs := make([]PendingJob[T], len(tasks))
+	for i, t := range tasks {
+		jobs[i] = pendingJob(t)
+	}
+	p.mu.Unlock()
+	return jobs
+}
+
+// QueueLen возвращает число заданий, ждущих в очереди, включая отложенные в партициях
+// и тегах и личные очереди воркеров. В отличие от Stats, не собирает остальных показателей.
+func (p *Pool[T, R]) QueueLen() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.queuedLocked()
+}
+
+// Peek возвращает задание, которое следующим выдаст общая очередь, не убирая его.
+// Задания партиций, тегов и личных очередей воркеров не учитываются.
+// Возвращает false, если общая очередь пуста.
+func (p *Pool[T, R]) Peek() (PendingJob[T], bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t := p.queue.peek()
+	if t == nil {
+		return PendingJob[T]{}, false
+	}
+	return pendingJob(t), true
+}
+
+// pendingJob описывает ждущее задание. Вызывается под p.mu.
+func pendingJob[T, R any](t *task[T, R]) PendingJob[T] {
+	job := PendingJob[T]{
+		ID:        t.id,
+		Job:       t.job,
+		Type:      t.kind,
+		Priority:  t.priority,
+		Tenant:    t.tenant,
+		Partition: t.partition,
+		Tags:      t.tags,
+		Worker:    -1,
+		Enqueued:  t.enqueued,
+		Metadata:  t.metadata,
+	}
+	if t.pinned {
+		job.Worker = t.worker
+	}
+	return job
+}
+
+// pendingTasksLocked возвращает все ждущие задания пула в порядке приёма.
+func (p *Pool[T, R]) pendingTasksLocked() []*task[T, R] {
+	tasks := p.queue.list()
+	for _, backlog := range p.partitions {
+		tasks = append(tasks, backlog...)
+	}
+	for _, backlog := range p.tagBacklog {
+		tasks = append(tasks, backlog...)
+	}
+	for _, pinned := range p.affinity {
+		tasks = append(tasks, pinned...)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].id < tasks[j].id })
+	return tasks
+}
+
+// RemovePending убирает задание из очереди, если оно ещё не начало выполняться,
+// и возвращает его. Future задания завершается с ErrJobRemoved. В отличие от Cancel,
+// выполняющееся задание не затрагивается: тогда возвращается false.
+func (p *Pool[T, R]) RemovePending(id JobID) (T, bool) {
+	p.mu.Lock()
+	t, exists := p.tasks[id]
+	if !exists || !p.removeQueuedLocked(t) {
+		p.mu.Unlock()
+		return *new(T), false
+	}
+	p.mu.Unlock()
+
+	p.finishRemoved(t, ErrJobRemoved)
+	return t.job, true
+}
@@ -0,0 +1,72 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPendingJobs(t *testing.T) {
+	release := make(chan struct{})
+	// Без воркеров все задания остаются в очереди
+	pool := NewPool[string, string](WithHandler(func(ctx context.Context, job string) (string, error) {
+		<-release
+		return job, nil
+	}))
+	defer pool.Shutdown(context.Background())
+	defer close(release)
+
+	if _, ok := pool.Peek(); ok {
+		t.Error("Peek on an empty queue reported a job")
+	}
+	var futures []*Future[string]
+	for _, job := range []string{"a", "b"} {
+		future, err := pool.Submit(job)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		futures = append(futures, future)
+	}
+	// Второе задание партиции ждёт первого вне общей очереди
+	for _, job := range []string{"p1", "p2"} {
+		future, err := pool.SubmitForPartition("user-1", job)
+		if err != nil {
+			t.Fatalf("SubmitForPartition: %v", err)
+		}
+		futures = append(futures, future)
+	}
+
+	var jobs []string
+	for _, pending := range pool.PendingJobs() {
+		jobs = append(jobs, pending.Job)
+	}
+	if len(jobs) != 4 || jobs[0] != "a" || jobs[1] != "b" || jobs[2] != "p1" || jobs[3] != "p2" {
+		t.Errorf("PendingJobs = %v, want [a b p1 p2]", jobs)
+	}
+	if next, ok := pool.Peek(); !ok || next.Job != "a" || next.ID != futures[0].ID() {
+		t.Errorf("Peek = %+v, %v; want job a", next, ok)
+	}
+
+	// RemovePending убирает задание из любой очереди и завершает его Future
+	for i, want := range map[int]string{1: "b", 3: "p2"} {
+		if job, ok := pool.RemovePending(futures[i].ID()); !ok || job != want {
+			t.Errorf("RemovePending(%d) = %q, %v; want %q", futures[i].ID(), job, ok, want)
+		}
+		if _, err := await(t, futures[i]); !errors.Is(err, ErrJobRemoved) {
+			t.Errorf("removed job error = %v, want ErrJobRemoved", err)
+		}
+		if _, ok := pool.RemovePending(futures[i].ID()); ok {
+			t.Errorf("second RemovePending(%d) succeeded", futures[i].ID())
+		}
+	}
+	if n := pool.QueueLen(); n != 2 {
+		t.Errorf("QueueLen after RemovePending = %d, want 2", n)
+	}
+
+	// Выполняющееся задание RemovePending не трогает
+	pool.AddWorker()
+	eventually(t, "job started", func() bool { return pool.Stats().BusyWorkers == 1 })
+	if _, ok := pool.RemovePending(futures[0].ID()); ok {
+		t.Error("RemovePending of a running job succeeded")
+	}
+}
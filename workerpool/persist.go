@@ -0,0 +1,277 @@
+package workerpool
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Persistence — журнал принятых заданий, благодаря которому задания из очереди
+// переживают перезапуск процесса. Пул записывает задание в журнал до постановки
+// в очередь, удаляет после обработки, а при создании пула повторно ставит в очередь
+// всё, что осталось в журнале.
+type Persistence interface {
+	// Append сохраняет задание и возвращает его ключ в журнале (не 0).
+	Append(data []byte) (key uint64, err error)
+	// Delete удаляет обработанное задание из журнала.
+	Delete(key uint64) error
+	// Load возвращает все сохранённые и ещё не удалённые задания в порядке записи.
+	Load() ([]PersistedJob, error)
+}
+
+// PersistedJob — задание, сохранённое в журнале.
+type PersistedJob struct {
+	Key  uint64
+	Data []byte
+}
+
+// WithPersistence включает журналирование заданий в store. Задания кодируются кодеком
+// WithCodec (по умолчанию JSON).
+// Задания, оставшиеся в очереди при остановке пула, в журнале сохраняются и будут
+// обработаны после перезапуска.
+func WithPersistence(store Persistence) Option {
+	return func(c *config) {
+		c.persistence = store
+	}
+}
+
+// withPersistence записывает задание в журнал, выполняет enqueue и удаляет запись,
+// если задание не было принято или объединилось с уже принятым.
+func (p *Pool[T, R]) withPersistence(t *task[T, R], enqueue func() error) error {
+	if p.persistence == nil || t.walKey != 0 {
+		return enqueue()
+	}
+
+	data, err := p.codec.Encode(&t.job)
+	if err != nil {
+		return fmt.Errorf("persist job: %w", err)
+	}
+	if t.walKey, err = p.persistence.Append(data); err != nil {
+		return fmt.Errorf("persist job: %w", err)
+	}
+
+	err = enqueue()
+	if err != nil || t.merged {
+		p.unpersist(t)
+	}
+	return err
+}
+
+// unpersist удаляет задание из журнала.
+func (p *Pool[T, R]) unpersist(t *task[T, R]) {
+	if p.persistence == nil || t.walKey == 0 {
+		return
+	}
+	if err := p.persistence.Delete(t.walKey); err != nil {
+		p.logger.Error("failed to delete job from persistence", "key", t.walKey, "error", err)
+	}
+}
+
+// replay ставит в очередь задания, оставшиеся в журнале после прошлого запуска.
+// Задания ждут места в очереди, пока пул не начнёт останавливаться; отклонённые пулом
+// задания передаются WithDeadLetter и удаляются из журнала.
+func (p *Pool[T, R]) replay() {
+	jobs, err := p.persistence.Load()
+	if err != nil {
+		p.logger.Error("failed to load persisted jobs", "error", err)
+		return
+	}
+	if len(jobs) == 0 {
+		return
+	}
+	p.logger.Info("replaying persisted jobs", "count", len(jobs))
+
+	go func() {
+		for _, pj := range jobs {
+			t := &task[T, R]{cost: 1, walKey: pj.Key}
+			if err := p.codec.Decode(pj.Data, &t.job); err != nil {
+				p.logger.Error("dropping undecodable persisted job", "key", pj.Key, "error", err)
+				p.unpersist(t)
+				continue
+			}
+			err := p.enqueueWait(context.Background(), t)
+			switch {
+			case errors.Is(err, ErrPoolClosed):
+				// Пул останавливается — остаток журнала дождётся следующего запуска
+				return
+			case err != nil:
+				// Задание отклонено (нет обработчика, SubmitHook и т. п.) и при следующем
+				// запуске отклонится снова: передаём его недоставленным и продолжаем
+				p.logger.Error("dropping rejected persisted job", "key", pj.Key, "error", err)
+				p.deadLetter(t.job, err, 0)
+				p.unpersist(t)
+			}
+		}
+	}()
+}
+
+// FilePersistence — реализация Persistence в виде файла журнала, куда дописываются
+// строки о добавлении и удалении заданий. При открытии журнал сжимается до
+// необработанных заданий.
+type FilePersistence struct {
+	mu      sync.Mutex
+	file    *os.File
+	sync    bool
+	nextKey uint64
+	live    map[uint64][]byte
+}
+
+// OpenFilePersistence открывает или создаёт журнал в файле path.
+// При sync каждая запись сбрасывается на диск через fsync — надёжнее, но медленнее.
+func OpenFilePersistence(path string, sync bool) (*FilePersistence, error) {
+	live, nextKey, err := readJournal(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fp := &FilePersistence{sync: sync, nextKey: nextKey, live: live}
+	if err := fp.compact(path); err != nil {
+		return nil, err
+	}
+	return fp, nil
+}
+
+// readJournal восстанавливает необработанные задания из файла журнала.
+func readJournal(path string) (map[uint64][]byte, uint64, error) {
+	live := make(map[uint64][]byte)
+	var maxKey uint64
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return live, 1, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64<<20)
+	for scanner.Scan() {
+		op, rest, _ := strings.Cut(scanner.Text(), " ")
+		keyStr, payload, hasPayload := strings.Cut(rest, " ")
+		key, err := strconv.ParseUint(keyStr, 10, 64)
+		if err != nil || op == "A" && !hasPayload {
+			// Недописанная при сбое строка в конце журнала
+			continue
+		}
+		if key > maxKey {
+			maxKey = key
+		}
+		switch op {
+		case "A":
+			data, err := base64.StdEncoding.DecodeString(payload)
+			if err != nil {
+				continue
+			}
+			live[key] = data
+		case "D":
+			delete(live, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("read journal %s: %w", path, err)
+	}
+	return live, maxKey + 1, nil
+}
+
+// compact переписывает журнал, оставляя только необработанные задания.
+func (fp *FilePersistence) compact(path string) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, pj := range fp.sorted() {
+		fmt.Fprintf(w, "A %d %s\n", pj.Key, base64.StdEncoding.EncodeToString(pj.Data))
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	fp.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	return err
+}
+
+func (fp *FilePersistence) sorted() []PersistedJob {
+	jobs := make([]PersistedJob, 0, len(fp.live))
+	for key, data := range fp.live {
+		jobs = append(jobs, PersistedJob{Key: key, Data: data})
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Key < jobs[j].Key })
+	return jobs
+}
+
+// Append реализует Persistence.
+func (fp *FilePersistence) Append(data []byte) (uint64, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	key := fp.nextKey
+	if err := fp.writeLocked(fmt.Sprintf("A %d %s\n", key, base64.StdEncoding.EncodeToString(data))); err != nil {
+		return 0, err
+	}
+	fp.nextKey++
+	fp.live[key] = data
+	return key, nil
+}
+
+// Delete реализует Persistence.
+func (fp *FilePersistence) Delete(key uint64) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	if _, exists := fp.live[key]; !exists {
+		return nil
+	}
+	if err := fp.writeLocked(fmt.Sprintf("D %d\n", key)); err != nil {
+		return err
+	}
+	delete(fp.live, key)
+	return nil
+}
+
+// Load реализует Persistence.
+func (fp *FilePersistence) Load() ([]PersistedJob, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	return fp.sorted(), nil
+}
+
+// Close закрывает файл журнала.
+func (fp *FilePersistence) Close() error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	return fp.file.Close()
+}
+
+func (fp *FilePersistence) writeLocked(line string) error {
+	if _, err := fp.file.WriteString(line); err != nil {
+		return err
+	}
+	if fp.sync {
+		return fp.file.Sync()
+	}
+	return nil
+}
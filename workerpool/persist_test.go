@@ -0,0 +1,173 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// journalWith записывает в журнал path задания jobs так, как их оставил бы остановленный пул.
+func journalWith(t *testing.T, path string, jobs ...string) {
+	t.Helper()
+	fp, err := OpenFilePersistence(path, false)
+	if err != nil {
+		t.Fatalf("OpenFilePersistence: %v", err)
+	}
+	// Без воркеров задания остаются в очереди и в журнале
+	pool := NewPool[string, string](WithHandler(echo[string]), WithPersistence(fp))
+	for _, job := range jobs {
+		if err := pool.SendJob(job); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	pool.ShutdownNow()
+	if err := fp.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestFilePersistenceReplaysQueuedJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.wal")
+	journalWith(t, path, "a", "b", "c")
+
+	fp, err := OpenFilePersistence(path, false)
+	if err != nil {
+		t.Fatalf("OpenFilePersistence: %v", err)
+	}
+	defer fp.Close()
+	done := make(chan string, 3)
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			done <- job
+			return job, nil
+		}),
+		WithPersistence(fp),
+		WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	var replayed []string
+	for i := 0; i < 3; i++ {
+		select {
+		case job := <-done:
+			replayed = append(replayed, job)
+		case <-time.After(testTimeout):
+			t.Fatalf("%d of 3 jobs replayed", len(replayed))
+		}
+	}
+	sort.Strings(replayed)
+	if len(replayed) != 3 || replayed[0] != "a" || replayed[1] != "b" || replayed[2] != "c" {
+		t.Errorf("replayed jobs = %v, want [a b c]", replayed)
+	}
+	// Обработанные задания удаляются из журнала
+	eventually(t, "journal emptied", func() bool {
+		jobs, err := fp.Load()
+		return err == nil && len(jobs) == 0
+	})
+}
+
+func TestReplayContinuesAfterRejectedJob(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.wal")
+	journalWith(t, path, "a", "b", "c")
+
+	fp, err := OpenFilePersistence(path, false)
+	if err != nil {
+		t.Fatalf("OpenFilePersistence: %v", err)
+	}
+	defer fp.Close()
+	// Пулу без обработчика некому отдать задания: каждое отклоняется, но replay не прерывается
+	dead := NewDeadLetterQueue(3)
+	pool := NewPool[string, string](WithPersistence(fp), WithDeadLetter(dead))
+	defer pool.Shutdown(context.Background())
+
+	for i := 0; i < 3; i++ {
+		var dl DeadLetter
+		select {
+		case dl = <-dead.C():
+		case <-time.After(testTimeout):
+			t.Fatalf("%d of 3 rejected jobs reached dead letters", i)
+		}
+		if !errors.Is(dl.Err, ErrNoHandler) {
+			t.Errorf("dead letter %v error = %v, want ErrNoHandler", dl.Job, dl.Err)
+		}
+	}
+	// Отклонённые задания не остаются в журнале, чтобы не отклоняться при каждом запуске
+	eventually(t, "journal emptied", func() bool {
+		jobs, err := fp.Load()
+		return err == nil && len(jobs) == 0
+	})
+}
+
+func TestFilePersistenceCompactsOnOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.wal")
+	fp, err := OpenFilePersistence(path, true)
+	if err != nil {
+		t.Fatalf("OpenFilePersistence: %v", err)
+	}
+	var keys []uint64
+	for _, data := range []string{"a", "b", "c"} {
+		key, err := fp.Append([]byte(data))
+		if err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+		keys = append(keys, key)
+	}
+	for i := 0; i < 2; i++ {
+		if err := fp.Delete(keys[1]); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+	}
+	if err := fp.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	// Недописанная при сбое последняя строка пропускается при чтении
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("A 4")
+	f.Close()
+	if lines := journalLines(t, path); lines != 5 {
+		t.Fatalf("journal has %d lines before compaction, want 5", lines)
+	}
+
+	fp, err = OpenFilePersistence(path, false)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer fp.Close()
+	jobs, err := fp.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].Key != keys[0] || string(jobs[0].Data) != "a" || jobs[1].Key != keys[2] || string(jobs[1].Data) != "c" {
+		t.Fatalf("Load = %v, want jobs a and c", jobs)
+	}
+	// При открытии журнал переписан только с необработанными заданиями
+	if lines := journalLines(t, path); lines != 2 {
+		t.Fatalf("journal has %d lines after compaction, want 2", lines)
+	}
+	// Ключи новых заданий не совпадают с ключами старых
+	key, err := fp.Append([]byte("d"))
+	if err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if key <= keys[2] {
+		t.Fatalf("new key %d reuses a journaled key (last %d)", key, keys[2])
+	}
+}
+
+// journalLines возвращает число строк в файле журнала path.
+func journalLines(t *testing.T, path string) int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(strings.Split(strings.TrimSuffix(string(data), "\n"), "\n"))
+}
@@ -0,0 +1,191 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Pipeline связывает несколько пулов в конвейер: результаты каждой стадии становятся
+// заданиями следующей. Конвейер сам передаёт результаты между стадиями, сдерживает быстрые
+// стадии медленными (отправка ждёт места в очереди следующей стадии) и останавливает стадии по порядку.
+//
+//	pl := workerpool.NewPipeline(workerpool.WithBufferSize(50))
+//	pl.AddStage(workerpool.Stage(download), 8)
+//	pl.AddStage(workerpool.Stage(resize), 4)
+//	pl.AddStage(workerpool.Stage(upload), 2)
+//	go func() {
+//		for res := range pl.Results() { ... }
+//	}()
+//	pl.Send(ctx, url)
+//	pl.Shutdown(ctx)
+type Pipeline struct {
+	opts []Option
+
+	mu      sync.Mutex
+	stages  []*Pool[any, any]
+	started bool
+	stopped bool
+
+	out       chan Result[any, any]
+	forwarded []chan struct{} // закрывается, когда результаты стадии переданы дальше
+}
+
+// NewPipeline создаёт пустой конвейер. Опции применяются к пулу каждой стадии.
+func NewPipeline(opts ...Option) *Pipeline {
+	return &Pipeline{opts: opts, out: make(chan Result[any, any])}
+}
+
+// Stage приводит типизированный обработчик к виду, который принимает AddStage.
+// Если задание стадии не того типа, обработчик возвращает ошибку.
+func Stage[In, Out any](handler Handler[In, Out]) Handler[any, any] {
+	return func(ctx context.Context, job any) (any, error) {
+		in, ok := job.(In)
+		if !ok {
+			var want In
+			return nil, fmt.Errorf("pipeline stage expects %T, got %T", want, job)
+		}
+		return handler(ctx, in)
+	}
+}
+
+// AddStage добавляет в конец конвейера стадию с обработчиком handler и workers воркерами.
+// Стадии добавляются до первого Send; добавление в запущенный конвейер вызывает панику.
+func (pl *Pipeline) AddStage(handler Handler[any, any], workers int) *Pipeline {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	if pl.started {
+		panic("workerpool: AddStage called after pipeline start")
+	}
+	opts := append([]Option{}, pl.opts...)
+	opts = append(opts, WithHandler(handler), WithInitialWorkers(workers), WithResultStream(0))
+	pl.stages = append(pl.stages, NewPool[any, any](opts...))
+	return pl
+}
+
+// Stages возвращает пулы стадий по порядку, например для Stats каждой из них.
+func (pl *Pipeline) Stages() []*Pool[any, any] {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	return append([]*Pool[any, any]{}, pl.stages...)
+}
+
+// Send отправляет задание на первую стадию, ожидая места в её очереди.
+// Возвращает ошибку, если в конвейере нет стадий, ctx отменён или конвейер остановлен.
+func (pl *Pipeline) Send(ctx context.Context, job any) error {
+	if err := pl.start(); err != nil {
+		return err
+	}
+	return pl.stages[0].SendJobContext(ctx, job)
+}
+
+// Results возвращает результаты последней стадии. Задания, упавшие на промежуточной стадии,
+// тоже попадают сюда с ошибкой этой стадии: Job в таком результате — задание упавшей стадии.
+// Канал закрывается, когда Shutdown остановит все стадии; читать его нужно до закрытия,
+// иначе конвейер встанет.
+func (pl *Pipeline) Results() <-chan Result[any, any] {
+	return pl.out
+}
+
+// Shutdown останавливает стадии по порядку: очередная стадия останавливается, когда
+// предыдущая дообработала очередь и передала ей все результаты, поэтому задания
+// не теряются между стадиями. Ошибки стадий объединяются.
+func (pl *Pipeline) Shutdown(ctx context.Context) error {
+	if err := pl.start(); err != nil {
+		return err
+	}
+	pl.mu.Lock()
+	if pl.stopped {
+		pl.mu.Unlock()
+		return fmt.Errorf("pipeline is already shut down")
+	}
+	pl.stopped = true
+	pl.mu.Unlock()
+
+	var errs []error
+	for i, stage := range pl.stages {
+		if err := stage.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stage %d: %w", i, err))
+		}
+		<-pl.forwarded[i]
+	}
+	close(pl.out)
+	return errors.Join(errs...)
+}
+
+// start фиксирует набор стадий и запускает передачу результатов между ними.
+func (pl *Pipeline) start() error {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	if len(pl.stages) == 0 {
+		return fmt.Errorf("pipeline has no stages")
+	}
+	if pl.started {
+		return nil
+	}
+	pl.started = true
+	for i, stage := range pl.stages {
+		var next *Pool[any, any]
+		if i+1 < len(pl.stages) {
+			next = pl.stages[i+1]
+		}
+		done := make(chan struct{})
+		pl.forwarded = append(pl.forwarded, done)
+		go pl.forward(stage, next, done)
+	}
+	return nil
+}
+
+// forward передаёт успешные результаты стадии на следующую стадию (next == nil — на выход),
+// а ошибки — сразу на выход.
+func (pl *Pipeline) forward(stage, next *Pool[any, any], done chan<- struct{}) {
+	defer close(done)
+
+	for res := range stage.Results() {
+		if res.Err != nil || next == nil {
+			pl.out <- res
+			continue
+		}
+		// Ожидание места в очереди следующей стадии сдерживает эту стадию
+		if err := next.SendJobContext(context.Background(), res.Value); err != nil {
+			pl.out <- Result[any, any]{Job: res.Value, Err: err}
+		}
+	}
+}
+
+// Pipe связывает два пула с разными типами заданий, когда конвейера из одинаковых
+// стадий Pipeline мало: каждый результат из потока Results пула src преобразуется
+// функцией transform и, если та вернула true, отправляется заданием в dst с ожиданием
+// места в его очереди, поэтому медленный dst сдерживает src. Результаты, отброшенные
+// transform, например с ошибкой, в dst не попадают. Задания, которые dst не принял,
+// потому что тот остановлен, уходят обработчику недоставленных dst (WithDeadLetter).
+// Передача завершается, когда поток Results пула src закрывается по его остановке;
+// тогда закрывается и возвращаемый канал. dst при этом не останавливается. Пул src должен
+// быть создан с WithResultStream или WithOrderedResults, а других читателей Results у него быть не должно.
+func Pipe[T, R, U, V any](src *Pool[T, R], dst *Pool[U, V], transform func(Result[T, R]) (U, bool)) (<-chan struct{}, error) {
+	results := src.Results()
+	if results == nil {
+		return nil, errors.New("cannot pipe: source pool has no result stream")
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		// Поток читается до конца даже после отказа dst: иначе src не сможет остановиться
+		for res := range results {
+			job, ok := transform(res)
+			if !ok {
+				continue
+			}
+			if err := dst.SendJobContext(context.Background(), job); err != nil {
+				dst.logger.Warn("failed to pipe result", "job", job, "error", err)
+				dst.deadLetter(job, err, 0)
+			}
+		}
+	}()
+	return done, nil
+}
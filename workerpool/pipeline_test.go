@@ -0,0 +1,132 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestPipe(t *testing.T) {
+	src := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			return job * 10, nil
+		}),
+		WithResultStream(0),
+		WithInitialWorkers(2),
+	)
+	dst := NewPool[int, string](
+		WithHandler(func(ctx context.Context, job int) (string, error) {
+			return fmt.Sprintf("v=%d", job), nil
+		}),
+		WithResultStream(10),
+		WithInitialWorkers(2),
+	)
+
+	done, err := Pipe(src, dst, func(res Result[int, int]) (int, bool) {
+		// Результат 30 отфильтровывается и во второй пул не попадает
+		return res.Value, res.Err == nil && res.Value != 30
+	})
+	if err != nil {
+		t.Fatalf("Pipe: %v", err)
+	}
+	for job := 1; job <= 5; job++ {
+		if err := src.SendJob(job); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+
+	// Остановка первого пула закрывает его поток результатов и завершает передачу
+	if err := src.Shutdown(context.Background()); err != nil {
+		t.Fatalf("src.Shutdown: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(testTimeout):
+		t.Fatal("pipe did not stop after the source pool shut down")
+	}
+	if err := dst.Shutdown(context.Background()); err != nil {
+		t.Fatalf("dst.Shutdown: %v", err)
+	}
+
+	var got []string
+	for res := range dst.Results() {
+		if res.Err != nil {
+			t.Errorf("dst job %d failed: %v", res.Job, res.Err)
+		}
+		got = append(got, res.Value)
+	}
+	sort.Strings(got)
+	want := []string{"v=10", "v=20", "v=40", "v=50"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("dst results = %v, want %v", got, want)
+	}
+}
+
+func TestPipeRequiresResultStream(t *testing.T) {
+	src := NewPool[int, int](WithHandler(echo[int]))
+	dst := NewPool[int, int](WithHandler(echo[int]))
+	defer src.Shutdown(context.Background())
+	defer dst.Shutdown(context.Background())
+
+	if _, err := Pipe(src, dst, func(res Result[int, int]) (int, bool) { return res.Value, true }); err == nil {
+		t.Fatal("Pipe without a result stream succeeded")
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	pl := NewPipeline(WithBufferSize(2))
+	pl.AddStage(Stage(func(ctx context.Context, s string) (int, error) {
+		var n int
+		_, err := fmt.Sscan(s, &n)
+		return n, err
+	}), 2)
+	pl.AddStage(Stage(func(ctx context.Context, n int) (int, error) { return n * 2, nil }), 2)
+	pl.AddStage(Stage(func(ctx context.Context, n int) (string, error) { return fmt.Sprint("#", n), nil }), 1)
+
+	var values, failed []string
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for res := range pl.Results() {
+			if res.Err != nil {
+				failed = append(failed, fmt.Sprint(res.Job))
+				continue
+			}
+			values = append(values, res.Value.(string))
+		}
+	}()
+
+	// Буферы стадий меньше числа заданий: Send ждёт, пока конвейер не продвинется
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	for _, job := range []string{"1", "2", "x", "3", "4", "5"} {
+		if err := pl.Send(ctx, job); err != nil {
+			t.Fatalf("Send(%s): %v", job, err)
+		}
+	}
+	if err := pl.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	select {
+	case <-collected:
+	case <-time.After(testTimeout):
+		t.Fatal("Results not closed after Shutdown")
+	}
+
+	// Упавшее на первой стадии задание приходит с ошибкой и дальше не идёт
+	sort.Strings(values)
+	if fmt.Sprint(values) != "[#10 #2 #4 #6 #8]" {
+		t.Errorf("pipeline output = %v, want [#10 #2 #4 #6 #8]", values)
+	}
+	if len(failed) != 1 || failed[0] != "x" {
+		t.Errorf("failed jobs = %v, want [x]", failed)
+	}
+	if err := pl.Shutdown(ctx); err == nil {
+		t.Error("second Shutdown succeeded")
+	}
+	if err := NewPipeline().Send(ctx, "1"); err == nil {
+		t.Error("Send to a pipeline without stages succeeded")
+	}
+}
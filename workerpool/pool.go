@@ -0,0 +1,1167 @@
+// Package workerpool реализует пул воркеров с динамическим добавлением и удалением воркеров.
+// Задания типа T обрабатываются пользовательским обработчиком Handler, который передаётся в NewPool
+// и возвращает результат типа R.
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Worker представляет собой структуру с ID и функцией отмены context.
+// Context используется для управления завершением работы горутины.
+type Worker struct {
+	ID     int
+	Cancel context.CancelFunc
+
+	removed    bool          // воркер уже отключён через RemoveWorker
+	started    time.Time     // момент запуска воркера
+	busyTime   time.Duration // суммарное время обработки заданий
+	working    bool          // воркер сейчас обрабатывает задание
+	lastActive time.Time     // момент завершения последнего задания (или запуска)
+	processed  uint64        // число обработанных заданий
+	currentJob any           // задание в работе (nil — воркер простаивает)
+	currentID  JobID         // ID задания в работе
+	jobStarted time.Time     // момент начала текущего задания
+
+	// stop закрывается, чтобы воркер завершился, не беря новых заданий, но доделав текущее
+	stop chan struct{}
+	// wake сигналит о заданиях в личной очереди воркера (SubmitToWorker)
+	wake chan struct{}
+	// exited закрывается, когда горутина воркера завершилась
+	exited chan struct{}
+}
+
+// ErrQueueFull возвращается, когда в буфере заданий нет свободного места.
+var ErrQueueFull = errors.New("job queue is full")
+
+// ErrWorkerNotFound — воркера с указанным ID нет в пуле.
+var ErrWorkerNotFound = errors.New("worker not found")
+
+// ErrNoWorkers указывает, что задания некому было обработать: воркеры ни разу не запускались.
+var ErrNoWorkers = errors.New("no workers were ever added")
+
+// ShutdownError сообщает, что Shutdown не успел обработать часть заданий.
+type ShutdownError struct {
+	Unprocessed int   // число отброшенных заданий
+	Err         error // причина: истечение контекста или ErrNoWorkers
+}
+
+func (e *ShutdownError) Error() string {
+	return fmt.Sprintf("shutdown: %d queued jobs left unprocessed: %v", e.Unprocessed, e.Err)
+}
+
+func (e *ShutdownError) Unwrap() error {
+	return e.Err
+}
+
+// Handler обрабатывает одно задание и возвращает его результат.
+// Контекст отменяется, когда воркер удаляют из пула или пул останавливается.
+type Handler[T, R any] func(ctx context.Context, job T) (R, error)
+
+// Result — итог обработки одного задания.
+type Result[T, R any] struct {
+	ID    JobID
+	Job   T
+	Value R
+	Err   error
+}
+
+// Pool реализует структуру worker-pool.
+// Включает мьютекс для синхронизации, список воркеров, очередь заданий и счётчик активных горутин.
+type Pool[T, R any] struct {
+	config
+	eventHub
+
+	mu      sync.Mutex
+	workers map[int]Worker
+
+	// queue хранит задания под p.mu; tokens содержит по одному значению на задание в очереди
+	// и позволяет воркерам ждать работу в select вместе с отменой. Сам канал tokens тоже
+	// защищён p.mu: Resize заменяет его и закрывает tokensChanged, чтобы разбудить воркеров.
+	// chans — их копия для воркеров, которая читается без блокировки
+	queue         taskQueue[T, R]
+	tokens        chan struct{}
+	tokensChanged chan struct{}
+	chans         atomic.Pointer[tokenChannels]
+
+	nextID int
+	wg     sync.WaitGroup
+
+	// tasks — задания в очереди и в работе по их ID, нужны для Cancel
+	tasks     map[JobID]*task[T, R]
+	nextJobID JobID
+
+	metrics metrics
+
+	// outcomes — итоги заданий по окнам WithOutcomeWindows (nil — выключены)
+	outcomes *outcomeWindows
+
+	// history — записи WithHistory о последних завершённых заданиях
+	history history
+
+	// paused — пул на паузе; resume закрывается при её снятии;
+	// heldTokens — жетоны, взятые воркерами во время паузы и возвращаемые в Resume
+	paused     bool
+	resume     chan struct{}
+	heldTokens int
+
+	// pauseC — канал resume, пока пул на паузе (nil — не на паузе); читается воркерами без блокировки
+	pauseC atomic.Pointer[chan struct{}]
+
+	// progress — подписчики SubscribeProgress по ID задания
+	progress map[JobID][]chan ProgressUpdate
+
+	// keys — принятые задания по ключу дедупликации (SendJobWithKey)
+	keys map[string]*task[T, R]
+
+	// partitions — задания, ждущие своей очереди в занятых партициях (SendJobForPartition);
+	// ключ есть, пока у партиции есть задание в очереди или в работе. backlogged — их общее число
+	partitions map[string][]*task[T, R]
+	backlogged int
+
+	// tagActive — занятые места в тегах WithTagLimit (в очереди и в работе),
+	// tagBacklog — задания, ждущие освобождения исчерпанного тега; учитываются в backlogged
+	tagActive  map[string]int
+	tagBacklog map[string][]*task[T, R]
+
+	// affinity — личные очереди воркеров SubmitToWorker по ID воркера, pinnedCount — их общий размер
+	affinity    map[int][]*task[T, R]
+	pinnedCount int
+
+	// closing — остановка запросила закрытие канала жетонов; tokensClosed — канал закрыт.
+	// Канал закрывается только после того, как в партициях не останется отложенных заданий
+	closing      bool
+	tokensClosed bool
+
+	// failed закрывается при первой ошибке обработчика в режиме WithFailFast, failErr — эта ошибка
+	failOnce sync.Once
+	failed   chan struct{}
+	failErr  error
+
+	// firstErr — первая ошибка обработчика для FirstError, errs — ошибки WithErrorCollection
+	firstErr *JobError
+	errs     []error
+
+	// panics — число паник, перехваченных пулом за всё время (PanicCounter)
+	panics atomic.Uint64
+
+	// groups — именованные группы пула (Group), останавливаются вместе с ним
+	groups map[string]*Pool[T, R]
+
+	// results — поток Results (nil — не включён); resultMu упорядочивает отправку в него.
+	// heldResults — готовые результаты, ждущие предыдущих в режиме WithOrderedResults
+	results     chan Result[T, R]
+	resultMu    sync.Mutex
+	heldResults map[JobID]Result[T, R]
+	nextResult  JobID
+
+	// hooks — хуки WithSubmitHook с проверенным типом
+	hooks []SubmitHook[T]
+
+	// batch — пакетный обработчик WithBatchHandler (nil — задания обрабатываются по одному)
+	batch *batchRunner[T, R]
+
+	// handler — цепочка middleware вокруг выбора обработчика; hasHandler — задан WithHandler.
+	// onResult — функция OnResult; атомарна, чтобы завершение задания не брало p.mu
+	handler    Handler[T, R]
+	hasHandler bool
+	onResult   atomic.Pointer[func(Result[T, R])]
+
+	// registry — обработчики по типу задания (Register); copy-on-write, чтобы выбор не брал блокировок
+	registryMu sync.Mutex
+	registry   atomic.Pointer[map[string]Handler[T, R]]
+
+	// reserved — число слотов буфера, зарезервированных через Reserve
+	reserved int
+
+	// space закрывается и заменяется новым каналом, когда в очереди освобождается место;
+	// spaceWanted — канал кто-то ждёт
+	space       chan struct{}
+	spaceWanted bool
+
+	inflightCost   atomic.Int64
+	memoryPressure atomic.Bool
+
+	spawnMu   sync.Mutex
+	nextSpawn time.Time
+
+	// spawning — воркеры, запуск которых уже решён в spawnNeededLocked, но ещё не выполнен
+	spawning int
+
+	// slow — ограничитель параллелизма для WithSlowStart (nil — выключен)
+	slow *slowStart
+
+	// meta — произвольные метки пула (владелец, назначение) для реестров пулов
+	meta map[string]string
+
+	// done закрывается в начале остановки пула и будит фоновые задачи и ожидающих;
+	// closed — по её завершении, когда пул перешёл в Closed
+	done   chan struct{}
+	closed chan struct{}
+
+	// pending — число заданий в очереди и в работе, нужно для отслеживания состояния
+	pending   int
+	drained   chan struct{} // закрывается, когда pending падает до нуля; создаётся в Wait
+	state     PoolState
+	onState   func(PoolState)
+	idleTimer Timer
+}
+
+// NewPool создаёт новый пул, настроенный опциями. Обработчик заданий задаётся
+// через WithHandler; типы пула должны совпадать с типами обработчика:
+//
+//	pool := workerpool.NewPool[string, string](
+//		workerpool.WithHandler(processJob),
+//		workerpool.WithBufferSize(10),
+//		workerpool.WithInitialWorkers(2),
+//	)
+//
+// Пул без WithHandler принимает только задания со своим обработчиком: SubmitWith
+// и задания типов Register, а SendJob и Submit возвращают ErrNoHandler.
+// При несовпадении типов NewPool паникует.
+func NewPool[T, R any](opts ...Option) *Pool[T, R] {
+	p := &Pool[T, R]{
+		config:  config{bufferSize: defaultBufferSize, agingInterval: defaultAgingInterval},
+		workers: make(map[int]Worker),
+		tasks:   make(map[JobID]*task[T, R]),
+		space:   make(chan struct{}),
+		done:    make(chan struct{}),
+		closed:  make(chan struct{}),
+		failed:  make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(&p.config)
+	}
+	if p.clock == nil {
+		p.clock = realClock{}
+	}
+	p.queue = newTaskQueue[T, R](p.clock)
+
+	handler, ok := p.jobHandler.(Handler[T, R])
+	if !ok && p.jobHandler != nil {
+		panic(fmt.Sprintf("workerpool: handler type %T does not match pool type %T", p.jobHandler, Handler[T, R](nil)))
+	}
+	if p.resultStream {
+		p.results = make(chan Result[T, R], p.resultBuffer)
+	}
+	p.queue.interval, p.queue.aging = p.agingInterval, p.agingFunc
+	p.queue.ordered = p.overflow == OverflowDropOldest
+	p.registerNamed()
+	p.hooks = submitHooks[T](p.submitHooks)
+	if p.batchConfig != nil {
+		p.batch = newBatchRunner[T, R](p.batchConfig, p.batchTarget)
+	} else if p.batchTarget > 0 {
+		panic("workerpool: WithAdaptiveBatching requires WithBatchHandler")
+	}
+	p.hasHandler = handler != nil
+	p.handler = chainMiddleware(p.dispatch(handler), p.middleware)
+	p.tokens = make(chan struct{}, p.bufferSize)
+	p.tokensChanged = make(chan struct{})
+	p.chans.Store(&tokenChannels{tokens: p.tokens, changed: p.tokensChanged})
+
+	if p.logger == nil {
+		p.logger = slog.New(discardHandler{})
+	}
+	if p.codec == nil {
+		p.codec = JSONCodec{}
+	}
+	if p.breaker != nil {
+		p.breaker.logger, p.breaker.clock = p.logger, p.clock
+	}
+	if p.limiter != nil {
+		p.limiter.clock, p.limiter.last = p.clock, p.clock.Now()
+	}
+	if p.outcomeWidth > 0 {
+		p.outcomes = newOutcomeWindows(p.clock.Now(), p.outcomeWidth, p.outcomeCount)
+	}
+	if p.slowStartRamp > 0 {
+		p.slow = &slowStart{start: p.clock.Now(), ramp: p.slowStartRamp}
+	}
+	if p.maxHeapBytes > 0 {
+		p.sampleMemory()
+		go p.guardMemory()
+	}
+	if p.autoscale != nil {
+		go p.runAutoscaler()
+	}
+	if p.governor != nil {
+		go p.runGovernor()
+	}
+	if p.watchdog != nil {
+		go p.runWatchdog()
+	}
+	if p.coordinator != nil {
+		p.coordinated.shutdown = p.Shutdown
+		p.coordinator.join(p.coordinated)
+	}
+	if p.maxWorkers > 0 && p.workerIdleTimeout == 0 {
+		p.workerIdleTimeout = defaultWorkerIdleTimeout
+	}
+	for i := 0; i < p.initialWorkers; i++ {
+		p.AddWorker()
+	}
+	if p.persistence != nil {
+		p.replay()
+	}
+	return p
+}
+
+// AddWorker запускает нового воркера в виде горутины.
+// Каждому воркеру присваивается уникальный ID и создаётся свой context.
+// С WithWorkerSpawnRate вызов ждёт, пока лимит позволит запустить воркера.
+// Если пул уже останавливается, воркер не запускается и возвращается -1.
+func (p *Pool[T, R]) AddWorker() int {
+	if !p.waitSpawnSlot() {
+		return -1
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.state == Draining || p.state == Closed {
+		return -1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	id := p.nextID
+	p.nextID++
+
+	now := p.clock.Now()
+	worker := Worker{
+		ID:         id,
+		Cancel:     cancel,
+		started:    now,
+		lastActive: now,
+		stop:       make(chan struct{}),
+		wake:       make(chan struct{}, 1),
+		exited:     make(chan struct{}),
+	}
+	p.workers[id] = worker
+	p.wg.Add(1)
+
+	// Запускаем горутину — сам воркер
+	go func(id int, ctx context.Context, stop, wake <-chan struct{}, exited chan<- struct{}) {
+		defer func() {
+			// При завершении удаляем воркера из пула и помечаем завершение wg;
+			// его личная очередь достаётся остальным воркерам
+			p.mu.Lock()
+			delete(p.workers, id)
+			p.unpinLocked(id)
+			p.mu.Unlock()
+			close(exited)
+			p.wg.Done()
+			p.logger.Info("worker stopped", "worker", id)
+		}()
+
+		ctx, ok := p.initWorker(ctx, id)
+		if !ok {
+			return
+		}
+		defer p.cleanupWorker(ctx, id)
+
+		// current — задание, которое воркер обрабатывает; по нему WithSupervision
+		// решает, что делать с заданием, если воркер упадёт
+		var current *task[T, R]
+		if p.supervised {
+			defer func() {
+				if r := recover(); r != nil {
+					p.recoverWorker(id, current, r)
+				}
+			}()
+		}
+
+		p.logger.Info("worker started", "worker", id)
+		var idle Timer
+		if p.workerIdleTimeout > 0 {
+			idle = p.clock.NewTimer(p.workerIdleTimeout)
+			defer idle.Stop()
+		}
+		for {
+			// При медленном старте воркер берёт задание, только получив разрешение
+			if !p.slowStartAcquire(ctx) {
+				return
+			}
+			// Отмена важнее очередного задания: select выбирает готовую ветку случайно
+			if ctx.Err() != nil {
+				p.slowStartRelease()
+				return
+			}
+			// По той же причине снятый с работы воркер не берёт задание, даже если оно уже ждёт
+			select {
+			case <-stop:
+				p.slowStartRelease()
+				return
+			default:
+			}
+			if resume := p.pauseWait(); resume != nil {
+				// Пул на паузе — ждём Resume, не занимая разрешение медленного старта
+				p.slowStartRelease()
+				select {
+				case <-resume:
+				case <-ctx.Done():
+					return
+				case <-stop:
+					return
+				}
+				continue
+			}
+			// Под давлением WithGovernor воркер ждёт, пока параллелизм снова разрешат поднять
+			governed, ok := p.governorAcquire(ctx, stop)
+			if !ok {
+				p.slowStartRelease()
+				return
+			}
+			// Разомкнутая цепь WithCircuitBreaker не выдаёт заданий до конца Cooldown
+			probe, ok := p.breakerAcquire(ctx, stop)
+			if !ok {
+				p.slowStartRelease()
+				p.governorRelease()
+				return
+			}
+			tokens, changed := p.tokenChans()
+			idleC := p.idleWait(idle)
+			var t *task[T, R]
+			select {
+			case <-ctx.Done():
+				// Контекст отменён — завершение воркера
+				p.releasePermits(probe)
+				return
+			case <-idleC:
+				// Заданий не было дольше WithWorkerIdleTimeout
+				p.releasePermits(probe)
+				if p.retireIdle(id) {
+					return
+				}
+				continue
+			case <-stop:
+				// Воркер снят с работы между заданиями
+				p.releasePermits(probe)
+				return
+			case <-changed:
+				// Очередь пересоздана через Resize — ждём на новом канале
+				p.releasePermits(probe)
+				continue
+			case <-governed:
+				// Регулятор изменил ограничение — разрешение нужно получить заново
+				p.releasePermits(probe)
+				continue
+			case _, ok := <-tokens:
+				if !ok {
+					// Очередь закрыта — завершение воркера
+					p.releasePermits(probe)
+					return
+				}
+				t = p.startWork(id)
+			case <-wake:
+				// Задание из личной очереди воркера
+				t = p.startPinned(id)
+			}
+			if t == nil {
+				// Задание успели убрать из очереди
+				p.releasePermits(probe)
+				continue
+			}
+			t.probe = probe
+
+			if p.batch != nil {
+				// В пакетном режиме воркер добирает задания до полного пакета
+				tasks := p.collectBatch(ctx, id, t)
+				start := p.clock.Now()
+				p.runBatch(ctx, tasks)
+				p.finishWork(id, len(tasks), p.clock.Now().Sub(start))
+				p.slowStartRelease()
+				p.governorRelease()
+				for _, t := range tasks {
+					p.jobDone(t)
+				}
+				continue
+			}
+
+			// Обработка задания
+			p.logger.Debug("processing job", "worker", id, "job", t.job, "id", t.id)
+			start := p.clock.Now()
+			current, t.completed = t, false
+			p.run(ctx, t)
+			current = nil
+			p.finishWork(id, 1, p.clock.Now().Sub(start))
+			p.slowStartRelease()
+			p.governorRelease()
+			p.jobDone(t)
+		}
+	}(id, ctx, worker.stop, worker.wake, worker.exited)
+
+	return id
+}
+
+// run выполняет обработчик для задания и передаёт результат в Future и OnResult.
+func (p *Pool[T, R]) run(ctx context.Context, t *task[T, R]) {
+	if p.expire(t, p.clock.Now()) {
+		return
+	}
+	// Ожидание единиц WithConcurrencyUnits и места в бюджете WithCoordinator не входит в таймаут задания
+	if err := p.acquireUnits(ctx, t); err != nil {
+		p.complete(t, *new(R), err, 0, p.clock.Now(), 0)
+		return
+	}
+	defer p.releaseUnits(t)
+	if err := p.coordinatorAcquire(ctx); err != nil {
+		p.complete(t, *new(R), err, 0, p.clock.Now(), 0)
+		return
+	}
+	defer p.coordinatorRelease()
+
+	ctx, cancel := p.jobContext(ctx, t)
+	defer cancel()
+
+	start := p.clock.Now()
+	ctx = p.traceStarted(ctx, t, start)
+	ctx = p.withProgress(ctx, t.id)
+	ctx = p.withClock(ctx)
+	p.emit(EventStarted, t, 0, nil)
+	value, attempts, err := p.callWithRetry(ctx, t)
+	p.complete(t, value, err, attempts, start, p.clock.Now().Sub(start))
+}
+
+// complete учитывает результат задания в метриках и передаёт его в Future и OnResult.
+func (p *Pool[T, R]) complete(t *task[T, R], value R, err error, attempts int, start time.Time, latency time.Duration) {
+	t.completed = true
+	// ErrNoOp — не ошибка: задание выполнено, но работы для него не нашлось
+	noOp := errors.Is(err, ErrNoOp)
+	if noOp {
+		err = nil
+	}
+	t.latency = latency
+	p.breakerRecord(t, err)
+	if err != nil {
+		p.logger.Warn("job failed", "job", t.job, "id", t.id, "attempts", attempts, "error", err)
+		p.deadLetter(t.job, err, attempts)
+		p.recordFailure(t, err)
+		p.emit(EventFailed, t, attempts, err)
+	} else {
+		p.emit(EventSucceeded, t, attempts, nil)
+	}
+
+	wait := start.Sub(t.enqueued)
+	if noOp {
+		p.metrics.recordNoOp()
+	} else {
+		p.metrics.record(latency, wait, err != nil)
+		if p.outcomes != nil {
+			p.outcomes.record(p.clock.Now(), err)
+		}
+		if p.observer != nil {
+			p.observer.ObserveJob(latency, wait, err)
+		}
+	}
+	p.traceFinished(t, latency, err)
+
+	t.finish(value, err)
+	if onResult := p.onResult.Load(); onResult != nil {
+		(*onResult)(Result[T, R]{ID: t.id, Job: t.job, Value: value, Err: err})
+	}
+	p.publishResult(t, value, err)
+}
+
+// OnResult регистрирует функцию, получающую результат каждого обработанного задания.
+// Функция вызывается в горутине воркера, поэтому должна быстро возвращать управление.
+func (p *Pool[T, R]) OnResult(fn func(Result[T, R])) {
+	if fn == nil {
+		p.onResult.Store(nil)
+		return
+	}
+	p.onResult.Store(&fn)
+}
+
+// retireLocked просит воркера завершиться после текущего задания. Вызывается под p.mu.
+func (p *Pool[T, R]) retireLocked(id int) bool {
+	worker, exists := p.workers[id]
+	if !exists || worker.removed {
+		return false
+	}
+	close(worker.stop)
+	worker.removed = true
+	p.workers[id] = worker
+	return true
+}
+
+// liveWorkersLocked возвращает число воркеров, которые не отключаются. Вызывается под p.mu.
+func (p *Pool[T, R]) liveWorkersLocked() int {
+	live := 0
+	for _, worker := range p.workers {
+		if !worker.removed {
+			live++
+		}
+	}
+	return live
+}
+
+// RemoveWorker отключает конкретного воркера по ID.
+// Контекст воркера будет отменён, и тот завершит выполнение, прервав текущее задание;
+// чтобы дать заданию доработать, используйте RemoveWorkerGraceful.
+// Возвращает true, если живой воркер действительно был отключён, и false,
+// если воркера с таким ID нет или он уже отключается.
+func (p *Pool[T, R]) RemoveWorker(id int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	worker, exists := p.workers[id]
+	if !exists || worker.removed {
+		return false
+	}
+	worker.Cancel() // воркер удалится сам при завершении горутины
+	worker.removed = true
+	p.workers[id] = worker
+	return true
+}
+
+// RemoveWorkerGraceful снимает воркера мягко: тот доделывает текущее задание,
+// больше не берёт новых и завершается. Возвращает ErrWorkerNotFound, если воркера
+// с таким ID нет или он уже отключается.
+func (p *Pool[T, R]) RemoveWorkerGraceful(id int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.retireLocked(id) {
+		return fmt.Errorf("%w: %d", ErrWorkerNotFound, id)
+	}
+	return nil
+}
+
+// RemoveWorkerNow снимает воркера немедленно, отменяя контекст текущего задания, как RemoveWorker.
+// Возвращает ErrWorkerNotFound, если воркера с таким ID нет или он уже отключается.
+func (p *Pool[T, R]) RemoveWorkerNow(id int) error {
+	if !p.RemoveWorker(id) {
+		return fmt.Errorf("%w: %d", ErrWorkerNotFound, id)
+	}
+	return nil
+}
+
+// RemoveWorkerWait отключает воркера, как RemoveWorker, и ждёт завершения его горутины,
+// то есть прерывания текущего задания и вызова WithWorkerCleanup.
+// Возвращает ErrWorkerNotFound, если воркера с таким ID нет, и ошибку ctx, если тот истёк раньше.
+// Для уже отключающегося воркера просто ждёт его завершения.
+func (p *Pool[T, R]) RemoveWorkerWait(ctx context.Context, id int) error {
+	p.mu.Lock()
+	worker, exists := p.workers[id]
+	if !exists {
+		p.mu.Unlock()
+		return fmt.Errorf("%w: %d", ErrWorkerNotFound, id)
+	}
+	if !worker.removed {
+		worker.Cancel()
+		worker.removed = true
+		p.workers[id] = worker
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-worker.exited:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SendJob помещает задание в очередь.
+// Если очередь заполнена, возвращается ошибка.
+// Зарезервированные через Reserve слоты считаются занятыми.
+func (p *Pool[T, R]) SendJob(job T) error {
+	return p.SendJobCost(job, 1)
+}
+
+// SendJobCost помещает в очередь задание с заданной стоимостью.
+// При ограничении WithCapacity задание отклоняется, если суммарная стоимость
+// незавершённых заданий превысила бы допустимую.
+// При включённом WithMemoryGuard и нехватке памяти возвращается ErrMemoryPressure.
+func (p *Pool[T, R]) SendJobCost(job T, cost int) error {
+	return p.enqueue(&task[T, R]{job: job, cost: cost})
+}
+
+// SendJobWithPriority помещает в очередь задание с приоритетом: задания с большим
+// приоритетом обрабатываются раньше. Обычный SendJob использует приоритет 0.
+// Приоритет ожидающего задания растёт на 1 за каждую секунду в очереди,
+// поэтому фоновые задания рано или поздно обгоняют поток новых срочных;
+// скорость старения задаётся через WithAgingInterval и WithAgingFunc.
+func (p *Pool[T, R]) SendJobWithPriority(job T, priority int) error {
+	return p.enqueue(&task[T, R]{job: job, cost: 1, priority: priority})
+}
+
+// SendJobContext помещает задание в очередь, ожидая освобождения места, если очередь заполнена.
+// Ожидание прерывается отменой или истечением срока ctx, а также остановкой пула.
+// Так отправители получают обратное давление вместо потери заданий.
+func (p *Pool[T, R]) SendJobContext(ctx context.Context, job T) error {
+	return p.enqueueWait(ctx, &task[T, R]{job: job, cost: 1})
+}
+
+// SendJobTimeout — то же, что SendJobContext, но с ограничением времени ожидания timeout.
+func (p *Pool[T, R]) SendJobTimeout(job T, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return p.SendJobContext(ctx, job)
+}
+
+// Submit помещает задание в очередь и возвращает Future, через который можно
+// дождаться результата. Ошибка возвращается, если задание не удалось поставить в очередь.
+func (p *Pool[T, R]) Submit(job T) (*Future[R], error) {
+	future := newFuture[R]()
+	if err := p.enqueue(&task[T, R]{job: job, cost: 1, future: future}); err != nil {
+		return nil, err
+	}
+	return future, nil
+}
+
+// enqueueWait ставит задание в очередь, ожидая места, если очередь заполнена.
+func (p *Pool[T, R]) enqueueWait(ctx context.Context, t *task[T, R]) error {
+	if err := p.runSubmitHooks(t); err != nil {
+		return err
+	}
+	p.traceEnqueued(ctx, t)
+	err := p.withPersistence(t, func() error {
+		return p.waitAndEnqueue(ctx, t)
+	})
+	if err != nil || t.merged {
+		// Отклонённое или объединённое с другим задание не выполнится само по себе
+		p.traceFinished(t, 0, err)
+	}
+	return err
+}
+
+// waitAndEnqueue повторяет tryEnqueue, пока в очереди не появится место.
+func (p *Pool[T, R]) waitAndEnqueue(ctx context.Context, t *task[T, R]) error {
+	for {
+		err := p.tryEnqueue(t)
+		if !errors.Is(err, ErrQueueFull) {
+			return err
+		}
+		// Канал берётся только при заполненной очереди, а попытка повторяется после этого,
+		// чтобы не пропустить освобождение места между ними
+		p.mu.Lock()
+		space := p.spaceWaitLocked()
+		p.mu.Unlock()
+		if err := p.tryEnqueue(t); !errors.Is(err, ErrQueueFull) {
+			return err
+		}
+		select {
+		case <-space:
+		case <-p.done:
+			// Следующая попытка вернёт ошибку остановленного пула
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// spaceWaitLocked возвращает канал, закрывающийся при освобождении места в очереди.
+// Вызывается под p.mu.
+func (p *Pool[T, R]) spaceWaitLocked() <-chan struct{} {
+	p.spaceWanted = true
+	return p.space
+}
+
+// signalSpaceLocked будит отправителей, ожидающих места в очереди. Вызывается под p.mu.
+// Канал пересоздаётся, только если его кто-то ждёт: иначе каждое задание стоило бы аллокации.
+func (p *Pool[T, R]) signalSpaceLocked() {
+	if !p.spaceWanted {
+		return
+	}
+	p.spaceWanted = false
+	close(p.space)
+	p.space = make(chan struct{})
+}
+
+// enqueue ставит задание в очередь, при переполнении поступая по WithOverflowPolicy.
+// Задание, не поместившееся в очередь, дополнительно уходит обработчику недоставленных.
+func (p *Pool[T, R]) enqueue(t *task[T, R]) error {
+	if err := p.runSubmitHooks(t); err != nil {
+		return err
+	}
+	p.traceEnqueued(context.Background(), t)
+	err := p.withPersistence(t, func() error {
+		return p.enqueueOverflow(t)
+	})
+	if err != nil || t.merged {
+		// Отклонённое или объединённое с другим задание не выполнится само по себе
+		p.traceFinished(t, 0, err)
+	}
+	if errors.Is(err, ErrQueueFull) {
+		p.deadLetter(t.job, err, 0)
+		if p.overflow == OverflowDropNewest {
+			t.finish(*new(R), ErrJobDropped)
+			return nil
+		}
+	}
+	return err
+}
+
+// tryEnqueue проверяет условия приёма и ставит задание в очередь.
+func (p *Pool[T, R]) tryEnqueue(t *task[T, R]) error {
+	if t.cost < 0 {
+		return fmt.Errorf("job cost must not be negative, got %d", t.cost)
+	}
+	if err := p.checkHandler(t); err != nil {
+		return err
+	}
+	if p.memoryPressure.Load() {
+		return ErrMemoryPressure
+	}
+
+	notify := noop
+	defer func() { notify() }()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.acceptingLocked(); err != nil {
+		return err
+	}
+	if err := p.failedFastLocked(); err != nil {
+		return err
+	}
+	if err := p.checkPinnedLocked(t); err != nil {
+		return err
+	}
+	if p.coalesceLocked(t) {
+		return nil
+	}
+	switch {
+	case t.reserved != nil:
+		// Зарезервированный слот всегда свободен: обычные задания его не занимают
+		if *t.reserved == 0 {
+			return errors.New("reservation is exhausted")
+		}
+	case p.queuedLocked()+p.reserved >= p.bufferSize:
+		return ErrQueueFull
+	}
+	if err := p.admitCostLocked(t); err != nil {
+		return err
+	}
+	if t.reserved != nil {
+		*t.reserved--
+		p.reserved--
+	}
+	notify = p.pushLocked(t)
+	return nil
+}
+
+// pushLocked ставит принятое задание в очередь и будит воркера. Вызывается под p.mu.
+func (p *Pool[T, R]) pushLocked(t *task[T, R]) func() {
+	p.nextJobID++
+	t.id = p.nextJobID
+	if t.future != nil {
+		t.future.id.Store(uint64(t.id))
+	}
+	p.tasks[t.id] = t
+	p.rememberKeyLocked(t)
+	t.enqueued = p.clock.Now()
+	if p.synchronous {
+		// Задание выполнится в горутине отправителя после снятия блокировки
+		notify := p.jobQueuedLocked()
+		return func() {
+			notify()
+			p.emit(EventEnqueued, t, 0, nil)
+			p.runInline(t)
+		}
+	}
+	if p.holdPartitionLocked(t) {
+		// Задание встанет в очередь воркеров после предыдущего задания партиции
+		notify := p.jobQueuedLocked()
+		return func() {
+			notify()
+			p.emit(EventEnqueued, t, 0, nil)
+		}
+	}
+	if t.pinned {
+		p.pinLocked(t)
+		notify := p.jobQueuedLocked()
+		return func() {
+			notify()
+			p.emit(EventEnqueued, t, 0, nil)
+		}
+	}
+	p.queueLocked(t, t.enqueued)
+	notify := p.jobQueuedLocked()
+	spawn := p.spawnNeededLocked()
+	return func() {
+		notify()
+		p.spawnWorkers(spawn)
+		p.emit(EventEnqueued, t, 0, nil)
+	}
+}
+
+// acceptingLocked проверяет, что пул ещё принимает задания. Вызывается под p.mu.
+// После начала Shutdown канал жетонов закрывается, и отправка в него вызвала бы панику.
+func (p *Pool[T, R]) acceptingLocked() error {
+	if p.state == Draining || p.state == Closed {
+		return fmt.Errorf("cannot send job: %w (%s)", ErrPoolClosed, p.state)
+	}
+	return nil
+}
+
+// admitCostLocked резервирует стоимость задания в пределах WithCapacity. Вызывается под p.mu,
+// поэтому проверка и увеличение счётчика не разделяются другими отправителями.
+func (p *Pool[T, R]) admitCostLocked(t *task[T, R]) error {
+	cost := int64(t.cost)
+	if p.capacity > 0 {
+		if cost > p.capacity {
+			return fmt.Errorf("job cost %d exceeds pool capacity %d", cost, p.capacity)
+		}
+		if p.inflightCost.Load()+cost > p.capacity {
+			return fmt.Errorf("pool capacity exceeded")
+		}
+	}
+	p.inflightCost.Add(cost)
+	return nil
+}
+
+// Flush обрабатывает оставшиеся в очереди задания синхронно, в вызывающей горутине.
+// Работает только когда в пуле нет запущенных воркеров; возвращает число обработанных заданий.
+// Если контекст отменён, обработка прерывается и возвращается ошибка контекста.
+func (p *Pool[T, R]) Flush(ctx context.Context) (int, error) {
+	p.mu.Lock()
+	running := len(p.workers)
+	p.mu.Unlock()
+	if running > 0 {
+		return 0, fmt.Errorf("cannot flush: %d workers are running", running)
+	}
+
+	processed := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return processed, err
+		}
+		tokens, _ := p.tokenChans()
+		select {
+		case _, ok := <-tokens:
+			if !ok {
+				// Очередь закрыта — больше заданий не будет
+				return processed, nil
+			}
+			t := p.startWork(-1)
+			if t == nil {
+				continue
+			}
+			p.logger.Debug("flushing job", "job", t.job, "id", t.id)
+			if p.batch != nil {
+				p.runBatch(ctx, []*task[T, R]{t})
+			} else {
+				p.run(ctx, t)
+			}
+			p.jobDone(t)
+			processed++
+		default:
+			// Очередь пуста
+			return processed, nil
+		}
+	}
+}
+
+// Shutdown останавливает пул: новые задания больше не принимаются, выполняющиеся
+// задания завершаются, а с ждущими в очереди поступают по WithShutdownMode.
+// По умолчанию (ShutdownDrain) воркеры дообрабатывают всю очередь и только потом завершаются;
+// в режиме ShutdownDiscard очередь сразу отбрасывается, а её Future завершаются с ErrJobDropped.
+// Если ctx истекает раньше, обработка прерывается отменой контекстов воркеров,
+// оставшиеся задания отбрасываются, и возвращается *ShutdownError с их числом.
+// В строгом режиме (WithStrictShutdown) ошибка возвращается и тогда, когда задания
+// были отброшены, потому что воркеры так ни разу и не запускались.
+// Группы пула (Group) останавливаются параллельно с ним с тем же ctx, их ошибки
+// объединяются с ошибкой пула. Вызов безопасно повторять: повторный вызов дожидается
+// завершения первой остановки (или истечения своего ctx) и возвращает nil.
+func (p *Pool[T, R]) Shutdown(ctx context.Context) error {
+	return p.shutdown(ctx, p.shutdownMode)
+}
+
+func (p *Pool[T, R]) shutdown(ctx context.Context, mode ShutdownMode) error {
+	// Запоминаем, были ли вообще воркеры
+	neverStarted, ok := p.beginShutdown()
+	if !ok {
+		// Остановка уже идёт или завершена — повторный вызов просто дожидается её
+		return p.waitClosed(ctx)
+	}
+	groupsDone := p.shutdownGroups(ctx, mode)
+
+	if mode == ShutdownDiscard {
+		// Выполняющиеся задания дорабатывают, ждущие в очереди не начнутся
+		p.dropQueued()
+	}
+	p.unpause()
+
+	// Сигнализируем воркерам, что больше не будет заданий
+	p.closeTokens()
+
+	stopped := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(stopped)
+	}()
+
+	var err, timeout error
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		// Время вышло — прерываем воркеров и не ждём остаток очереди
+		timeout = ctx.Err()
+		p.cancelWorkers()
+		<-stopped
+	}
+	unprocessed := len(p.dropQueued())
+	p.finishShutdown()
+
+	switch {
+	case timeout != nil:
+		err = &ShutdownError{Unprocessed: unprocessed, Err: timeout}
+	case p.strictShutdown && neverStarted && unprocessed > 0:
+		err = &ShutdownError{Unprocessed: unprocessed, Err: ErrNoWorkers}
+	}
+	if groupErr := <-groupsDone; groupErr != nil {
+		return errors.Join(err, groupErr)
+	}
+	return err
+}
+
+// ShutdownNow немедленно останавливает пул: отменяет контексты выполняющихся заданий,
+// не дожидаясь обработки очереди, и возвращает задания, которые так и не были начаты.
+// Их можно сохранить или отправить в другой пул.
+// Задания групп пула (Group) возвращаются вместе с заданиями самого пула.
+// Если остановка уже идёт, ShutdownNow прерывает выполняющиеся задания, дожидается её
+// завершения и возвращает nil; для уже остановленного пула сразу возвращает nil.
+func (p *Pool[T, R]) ShutdownNow() []T {
+	if _, ok := p.beginShutdown(); !ok {
+		// Ускоряем уже идущую остановку: выполняющиеся задания прерываются
+		p.cancelWorkers()
+		<-p.closed
+		return nil
+	}
+
+	// Сначала останавливаем воркеров, чтобы они не разобрали остаток очереди
+	p.cancelWorkers()
+	p.wg.Wait()
+	p.unpause()
+
+	p.closeTokens()
+	unprocessed := p.dropQueued()
+	p.finishShutdown()
+
+	for _, name := range p.Groups() {
+		unprocessed = append(unprocessed, p.Group(name).ShutdownNow()...)
+	}
+	return unprocessed
+}
+
+// ShutdownDrain — прежнее имя ShutdownNow.
+//
+// Deprecated: используйте ShutdownNow.
+func (p *Pool[T, R]) ShutdownDrain() []T {
+	return p.ShutdownNow()
+}
+
+// dropQueued отклоняет задания, оставшиеся в закрытой очереди, и возвращает их.
+func (p *Pool[T, R]) dropQueued() []T {
+	tasks := p.takeQueued()
+	var dropped []T
+	for _, t := range tasks {
+		p.traceFinished(t, 0, ErrJobDropped)
+		p.emit(EventDropped, t, 0, ErrJobDropped)
+		t.finish(*new(R), ErrJobDropped)
+		p.publishResult(t, *new(R), ErrJobDropped)
+		dropped = append(dropped, t.job)
+	}
+	return dropped
+}
+
+// takeQueued извлекает все ждущие задания вместе с их учётом в пуле, не завершая их.
+func (p *Pool[T, R]) takeQueued() []*task[T, R] {
+	p.mu.Lock()
+	tasks := append(p.queue.drain(), p.dropHeldLocked()...)
+	tasks = append(tasks, p.dropPinnedLocked()...)
+	tasks = append(tasks, p.dropTagHeldLocked()...)
+	p.closeTokensIfDrainedLocked()
+	for _, t := range tasks {
+		p.freeTagsLocked(t)
+		delete(p.tasks, t.id)
+		p.forgetKeyLocked(t)
+		p.closeProgressLocked(t.id)
+		p.inflightCost.Add(-int64(t.cost))
+	}
+	p.pending -= len(tasks)
+	p.signalDrainedLocked()
+	p.mu.Unlock()
+	return tasks
+}
+
+// beginShutdown переводит пул в Draining и останавливает фоновые задачи.
+// Возвращает neverStarted == true, если в пуле ни разу не запускались воркеры.
+// Переход допустим только из Idle или Busy: если остановка уже начата, возвращается ok == false.
+// Паузу beginShutdown не снимает: вызывающий снимает её через unpause, когда ждущие
+// задания, которые не должны начаться, уже убраны из очереди.
+func (p *Pool[T, R]) beginShutdown() (neverStarted, ok bool) {
+	p.mu.Lock()
+	if p.state == Draining || p.state == Closed {
+		p.mu.Unlock()
+		return false, false
+	}
+	neverStarted = p.nextID == 0
+	if p.idleTimer != nil {
+		p.idleTimer.Stop()
+		p.idleTimer = nil
+	}
+	notify := p.setStateLocked(Draining)
+	p.mu.Unlock()
+	notify()
+
+	close(p.done)
+	return neverStarted, true
+}
+
+// unpause снимает паузу на время остановки, чтобы воркеры дообработали очередь.
+func (p *Pool[T, R]) unpause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.resumeLocked()
+}
+
+// waitClosed ждёт завершения остановки, начатой другим вызовом.
+// Возвращает ошибку ctx, если тот истёк раньше.
+func (p *Pool[T, R]) waitClosed(ctx context.Context) error {
+	// Уже остановленный пул важнее истёкшего ctx: select выбирает готовую ветку случайно
+	select {
+	case <-p.closed:
+		return nil
+	default:
+	}
+	select {
+	case <-p.closed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// cancelWorkers отменяет контексты всех активных воркеров.
+func (p *Pool[T, R]) cancelWorkers() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, worker := range p.workers {
+		worker.Cancel()
+	}
+}
+
+// finishShutdown переводит остановленный пул в Closed.
+func (p *Pool[T, R]) finishShutdown() {
+	p.mu.Lock()
+	notify := p.setStateLocked(Closed)
+	p.mu.Unlock()
+	p.closeResults()
+	if p.coordinator != nil {
+		p.coordinator.leave(p.coordinated)
+	}
+	close(p.closed)
+	notify()
+}
@@ -0,0 +1,553 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+)
+
+// testTimeout — сколько тесты ждут событий, которые должны наступить почти сразу.
+const testTimeout = 2 * time.Second
+
+// await ждёт завершения задания future и возвращает его итог.
+func await[R any](t *testing.T, future *Future[R]) (R, error) {
+	t.Helper()
+	select {
+	case <-future.Done():
+	case <-time.After(testTimeout):
+		t.Fatalf("job %d did not finish in %v", future.ID(), testTimeout)
+	}
+	return future.Result(), future.Err()
+}
+
+// eventually ждёт, пока cond не станет истинным.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(testTimeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// echo — обработчик, возвращающий само задание.
+func echo[T any](ctx context.Context, job T) (T, error) {
+	return job, nil
+}
+
+func TestStrictShutdownReportsDiscardedJobs(t *testing.T) {
+	for _, strict := range []bool{false, true} {
+		opts := []Option{WithHandler(echo[string])}
+		if strict {
+			opts = append(opts, WithStrictShutdown())
+		}
+		pool := NewPool[string, string](opts...)
+		for _, job := range []string{"a", "b", "c"} {
+			if err := pool.SendJob(job); err != nil {
+				t.Fatalf("SendJob: %v", err)
+			}
+		}
+
+		err := pool.Shutdown(context.Background())
+		if !strict {
+			if err != nil {
+				t.Errorf("non-strict Shutdown = %v, want nil", err)
+			}
+			continue
+		}
+		var shutdownErr *ShutdownError
+		if !errors.As(err, &shutdownErr) || !errors.Is(err, ErrNoWorkers) {
+			t.Fatalf("strict Shutdown = %v, want *ShutdownError wrapping ErrNoWorkers", err)
+		}
+		if shutdownErr.Unprocessed != 3 {
+			t.Errorf("Unprocessed = %d, want 3", shutdownErr.Unprocessed)
+		}
+	}
+}
+
+func TestRemoveWorkerReportsCancellation(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]))
+	defer pool.Shutdown(context.Background())
+
+	id := pool.AddWorker()
+	if !pool.RemoveWorker(id) {
+		t.Error("first RemoveWorker = false, want true")
+	}
+	if pool.RemoveWorker(id) {
+		t.Error("repeated RemoveWorker = true, want false")
+	}
+	if pool.RemoveWorker(id + 100) {
+		t.Error("RemoveWorker of an unknown id = true, want false")
+	}
+}
+
+func TestFlushRunsJobsInline(t *testing.T) {
+	var ran []int
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			ran = append(ran, job)
+			return job, nil
+		}),
+	)
+	defer pool.Shutdown(context.Background())
+
+	for i := 0; i < 5; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	n, err := pool.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if n != 5 || len(ran) != 5 {
+		t.Errorf("Flush processed %d jobs, handler ran %d times, want 5", n, len(ran))
+	}
+	if q := pool.QueueLen(); q != 0 {
+		t.Errorf("QueueLen after Flush = %d, want 0", q)
+	}
+
+	// С запущенными воркерами Flush отказывается работать
+	pool.AddWorker()
+	if _, err := pool.Flush(context.Background()); err == nil {
+		t.Error("Flush with running workers succeeded")
+	}
+}
+
+func TestShutdownDrainReturnsUnprocessedJobs(t *testing.T) {
+	// Без воркеров все задания остаются в очереди
+	idle := NewPool[int, int](WithHandler(echo[int]))
+	for i := 0; i < 5; i++ {
+		if err := idle.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	if jobs := idle.ShutdownDrain(); len(jobs) != 5 {
+		t.Errorf("ShutdownDrain without workers returned %v, want all 5 jobs", jobs)
+	}
+
+	// Медленный воркер занят первым заданием: его прерывают, а остальные возвращаются
+	started := make(chan struct{})
+	slow := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			close(started)
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}),
+		WithInitialWorkers(1),
+	)
+	first, err := slow.Submit(0)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	<-started
+	for i := 1; i <= 4; i++ {
+		if err := slow.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+
+	jobs := slow.ShutdownDrain()
+	sort.Ints(jobs)
+	if len(jobs) != 4 || jobs[0] != 1 || jobs[3] != 4 {
+		t.Errorf("ShutdownDrain returned %v, want [1 2 3 4]", jobs)
+	}
+	if _, err := await(t, first); !errors.Is(err, context.Canceled) {
+		t.Errorf("running job error = %v, want context.Canceled", err)
+	}
+	if err := slow.SendJob(5); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("SendJob after ShutdownDrain error = %v, want ErrPoolClosed", err)
+	}
+}
+
+func TestTypedJobsAndResults(t *testing.T) {
+	type order struct {
+		ID    int
+		Items []string
+	}
+	type receipt struct {
+		OrderID int
+		Count   int
+	}
+	pool := NewPool[order, receipt](
+		WithHandler(func(ctx context.Context, o order) (receipt, error) {
+			return receipt{OrderID: o.ID, Count: len(o.Items)}, nil
+		}),
+		WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	results := make(chan Result[order, receipt], 1)
+	pool.OnResult(func(res Result[order, receipt]) { results <- res })
+
+	future, err := pool.Submit(order{ID: 42, Items: []string{"tea", "milk"}})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	got, err := await(t, future)
+	if err != nil || got != (receipt{OrderID: 42, Count: 2}) {
+		t.Errorf("future result = %+v, %v", got, err)
+	}
+	select {
+	case res := <-results:
+		if res.Job.ID != 42 || res.Value != got {
+			t.Errorf("OnResult = %+v, want job 42 with %+v", res, got)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("OnResult was not called")
+	}
+}
+
+func TestSendJobContextWaitsForSpace(t *testing.T) {
+	// Без воркеров очередь из одного места остаётся заполненной
+	pool := NewPool[int, int](WithHandler(echo[int]), WithBufferSize(1))
+	defer pool.Shutdown(context.Background())
+
+	if err := pool.SendJob(1); err != nil {
+		t.Fatalf("SendJob: %v", err)
+	}
+	if err := pool.SendJobTimeout(2, 20*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("SendJobTimeout on a full queue = %v, want DeadlineExceeded", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := pool.SendJobContext(ctx, 2); !errors.Is(err, context.Canceled) {
+		t.Fatalf("SendJobContext with cancelled ctx = %v, want Canceled", err)
+	}
+
+	// Отправитель ждёт, пока воркер не освободит место
+	sent := make(chan error, 1)
+	go func() { sent <- pool.SendJobContext(context.Background(), 3) }()
+	select {
+	case err := <-sent:
+		t.Fatalf("SendJobContext returned %v before space was freed", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	pool.AddWorker()
+	select {
+	case err := <-sent:
+		if err != nil {
+			t.Errorf("SendJobContext after space was freed = %v", err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("SendJobContext still blocked after a worker freed space")
+	}
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	started := make(chan struct{}, 1)
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			started <- struct{}{}
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}),
+		WithInitialWorkers(1),
+	)
+	running, err := pool.Submit(0)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	<-started
+	for i := 1; i <= 3; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+
+	// Зависшее задание не даёт дообработать очередь: по истечении ctx оно прерывается,
+	// а ждущие задания учитываются в ошибке
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	err = pool.Shutdown(ctx)
+	var shutdownErr *ShutdownError
+	if !errors.As(err, &shutdownErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown = %v, want *ShutdownError wrapping DeadlineExceeded", err)
+	}
+	if shutdownErr.Unprocessed != 3 {
+		t.Errorf("Unprocessed = %d, want 3", shutdownErr.Unprocessed)
+	}
+	if _, err := await(t, running); !errors.Is(err, context.Canceled) {
+		t.Errorf("running job error = %v, want context.Canceled", err)
+	}
+}
+
+func TestShutdownWaitsForQueuedJobs(t *testing.T) {
+	release := make(chan struct{})
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			<-release
+			return job, nil
+		}),
+		WithInitialWorkers(2),
+	)
+	var futures []*Future[int]
+	for i := 0; i < 5; i++ {
+		future, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		futures = append(futures, future)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- pool.Shutdown(context.Background()) }()
+	select {
+	case err := <-done:
+		t.Fatalf("Shutdown returned %v while jobs were still running", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Shutdown = %v", err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("Shutdown did not return after jobs finished")
+	}
+	for _, future := range futures {
+		if _, err := await(t, future); err != nil {
+			t.Errorf("job %d error = %v", future.ID(), err)
+		}
+	}
+}
+
+func TestRemoveWorkerGracefulAndNow(t *testing.T) {
+	started := make(chan int, 2)
+	release := make(chan struct{})
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		started <- job
+		select {
+		case <-release:
+			return job, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}))
+	defer pool.Shutdown(context.Background())
+	gentle, abrupt := pool.AddWorker(), pool.AddWorker()
+
+	first, _ := pool.Submit(1)
+	second, _ := pool.Submit(2)
+	<-started
+	<-started
+
+	// Мягко снятый воркер доделывает задание, а снятый немедленно прерывает его
+	futures := map[JobID]*Future[int]{first.ID(): first, second.ID(): second}
+	byWorker := map[int]*Future[int]{}
+	for _, w := range pool.Workers() {
+		byWorker[w.ID] = futures[w.CurrentJobID]
+	}
+	if err := pool.RemoveWorkerGraceful(gentle); err != nil {
+		t.Fatalf("RemoveWorkerGraceful: %v", err)
+	}
+	if err := pool.RemoveWorkerNow(abrupt); err != nil {
+		t.Fatalf("RemoveWorkerNow: %v", err)
+	}
+	if _, err := await(t, byWorker[abrupt]); !errors.Is(err, context.Canceled) {
+		t.Errorf("job of the removed worker error = %v, want context.Canceled", err)
+	}
+	close(release)
+	if _, err := await(t, byWorker[gentle]); err != nil {
+		t.Errorf("job of the gracefully removed worker error = %v, want nil", err)
+	}
+	eventually(t, "workers stopped", func() bool { return pool.Stats().Workers == 0 })
+
+	for _, id := range []int{gentle, abrupt, abrupt + 100} {
+		if err := pool.RemoveWorkerGraceful(id); !errors.Is(err, ErrWorkerNotFound) {
+			t.Errorf("RemoveWorkerGraceful(%d) = %v, want ErrWorkerNotFound", id, err)
+		}
+		if err := pool.RemoveWorkerNow(id); !errors.Is(err, ErrWorkerNotFound) {
+			t.Errorf("RemoveWorkerNow(%d) = %v, want ErrWorkerNotFound", id, err)
+		}
+	}
+}
+
+func TestRemoveWorkerGracefulLeavesQueuedJobs(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		release := make(chan struct{})
+		pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+			if job == 1 {
+				<-release
+			}
+			return job, nil
+		}))
+		id := pool.AddWorker()
+		running, _ := pool.Submit(1)
+		eventually(t, "job started", func() bool { return pool.Stats().BusyWorkers == 1 })
+		queued, _ := pool.Submit(2)
+
+		// Доделав текущее задание, воркер завершается, не беря ждущее в очереди
+		if err := pool.RemoveWorkerGraceful(id); err != nil {
+			t.Fatalf("RemoveWorkerGraceful: %v", err)
+		}
+		close(release)
+		if _, err := await(t, running); err != nil {
+			t.Fatalf("running job: %v", err)
+		}
+		eventually(t, "worker stopped", func() bool { return pool.Stats().Workers == 0 })
+		select {
+		case <-queued.Done():
+			t.Fatal("gracefully removed worker took a queued job")
+		default:
+		}
+		pool.AddWorker()
+		if _, err := await(t, queued); err != nil {
+			t.Fatalf("queued job: %v", err)
+		}
+		pool.Shutdown(context.Background())
+	}
+}
+
+func TestRepeatedShutdown(t *testing.T) {
+	release := make(chan struct{})
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		select {
+		case <-release:
+			return job, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}), WithInitialWorkers(1))
+	running, err := pool.Submit(1)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	eventually(t, "job started", func() bool { return pool.Stats().BusyWorkers == 1 })
+
+	first := make(chan error, 1)
+	go func() { first <- pool.Shutdown(context.Background()) }()
+	eventually(t, "shutdown started", func() bool { return pool.State() == Draining })
+	if err := pool.SendJob(2); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("SendJob during shutdown = %v, want ErrPoolClosed", err)
+	}
+
+	// Повторный вызов дожидается первой остановки, но не дольше своего ctx
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := pool.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("repeated Shutdown with a short ctx = %v, want DeadlineExceeded", err)
+	}
+	second := make(chan error, 1)
+	go func() { second <- pool.Shutdown(context.Background()) }()
+	close(release)
+	for _, done := range []chan error{first, second} {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Shutdown = %v, want nil", err)
+			}
+		case <-time.After(testTimeout):
+			t.Fatal("Shutdown did not return")
+		}
+	}
+	if _, err := await(t, running); err != nil {
+		t.Errorf("running job: %v", err)
+	}
+
+	// Для остановленного пула оба вызова сразу возвращают nil
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown of a closed pool = %v, want nil", err)
+	}
+	if jobs := pool.ShutdownNow(); jobs != nil {
+		t.Errorf("ShutdownNow of a closed pool = %v, want nil", jobs)
+	}
+	if err := pool.SendJob(3); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("SendJob after Shutdown = %v, want ErrPoolClosed", err)
+	}
+}
+
+func TestShutdownNowInterruptsShutdown(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}), WithInitialWorkers(1))
+	stuck, err := pool.Submit(1)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	eventually(t, "job started", func() bool { return pool.Stats().BusyWorkers == 1 })
+
+	graceful := make(chan error, 1)
+	go func() { graceful <- pool.Shutdown(context.Background()) }()
+	eventually(t, "shutdown started", func() bool { return pool.State() == Draining })
+
+	// ShutdownNow прерывает зависшее задание, на котором застряла мягкая остановка
+	if jobs := pool.ShutdownNow(); jobs != nil {
+		t.Errorf("ShutdownNow during Shutdown = %v, want nil", jobs)
+	}
+	if _, err := await(t, stuck); !errors.Is(err, context.Canceled) {
+		t.Errorf("stuck job = %v, want context.Canceled", err)
+	}
+	select {
+	case <-graceful:
+	case <-time.After(testTimeout):
+		t.Fatal("Shutdown did not return after ShutdownNow")
+	}
+	if pool.State() != Closed {
+		t.Errorf("state = %v, want Closed", pool.State())
+	}
+}
+
+// BenchmarkSendJobParallel измеряет путь задания от SendJobContext до завершения
+// при конкурентной отправке: на нём не должно быть лишних захватов p.mu и аллокаций.
+//
+//	go test -run '^$' -bench SendJobParallel -benchmem -cpu 1,4,16
+func BenchmarkSendJobParallel(b *testing.B) {
+	for _, workers := range []int{1, 8, 64} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			pool := NewPool[int, int](
+				WithHandler(echo[int]),
+				WithInitialWorkers(workers),
+				WithBufferSize(1024),
+			)
+			defer pool.Shutdown(context.Background())
+
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					if err := pool.SendJobContext(ctx, i); err != nil {
+						b.Errorf("SendJobContext: %v", err)
+						return
+					}
+				}
+			})
+			if err := pool.Wait(ctx); err != nil {
+				b.Fatalf("Wait: %v", err)
+			}
+		})
+	}
+}
+
+// BenchmarkSubmitParallel — то же с Future и OnResult, которые завершение задания
+// раньше читало под p.mu.
+func BenchmarkSubmitParallel(b *testing.B) {
+	pool := NewPool[int, int](
+		WithHandler(echo[int]),
+		WithInitialWorkers(8),
+		WithBufferSize(1024),
+	)
+	defer pool.Shutdown(context.Background())
+	pool.OnResult(func(Result[int, int]) {})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			future, err := pool.SubmitContext(context.Background(), i)
+			if err != nil {
+				b.Errorf("SubmitContext: %v", err)
+				return
+			}
+			<-future.Done()
+		}
+	})
+}
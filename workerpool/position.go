@@ -0,0 +1,95 @@
+package workerpool
+
+import (
+	"slices"
+	"time"
+)
+
+// QueuePosition — место задания в очереди и оценка того, когда оно начнёт выполняться.
+type QueuePosition struct {
+	// Position — номер задания в очереди начиная с 1 (0 — задание уже выполняется)
+	Position int
+
+	// EstimatedWait — оценка ожидания до начала выполнения по недавней пропускной способности
+	EstimatedWait time.Duration
+
+	// EstimatedStart — оценка момента начала выполнения (нулевое время — оценить не по чему:
+	// пул ещё не обработал ни одного задания или у него нет воркеров)
+	EstimatedStart time.Time
+}
+
+// SubmitWithPosition — то же, что Submit, но дополнительно возвращает место задания
+// в очереди и оценку начала его выполнения, например для «ваше задание 12-е, примерно 30 с».
+func (p *Pool[T, R]) SubmitWithPosition(job T) (*Future[R], QueuePosition, error) {
+	future, err := p.Submit(job)
+	if err != nil {
+		return nil, QueuePosition{}, err
+	}
+	pos, _ := p.QueuePosition(future.ID())
+	return future, pos, nil
+}
+
+// QueuePosition возвращает текущее место задания в очереди и оценку начала его выполнения.
+// Место учитывает приоритеты, старение и поочерёдную выдачу заданий арендаторов, но это
+// оценка: задания с большим приоритетом, отправленные позже, могут его обогнать.
+// Ожидание оценивается по среднему времени недавних заданий и числу воркеров.
+// Возвращает false, если задание уже завершилось или не существовало.
+func (p *Pool[T, R]) QueuePosition(id JobID) (QueuePosition, bool) {
+	p.mu.Lock()
+	t, exists := p.tasks[id]
+	if !exists {
+		p.mu.Unlock()
+		return QueuePosition{}, false
+	}
+	ahead, queued := p.aheadLocked(t)
+	workers := p.liveWorkersLocked()
+	idle := 0
+	for _, worker := range p.workers {
+		if !worker.removed && !worker.working {
+			idle++
+		}
+	}
+	if workers == 0 {
+		// Ленивые воркеры WithMaxWorkers запустятся по требованию
+		workers, idle = p.maxWorkers, p.maxWorkers
+	}
+	p.mu.Unlock()
+
+	if !queued {
+		return QueuePosition{}, true
+	}
+	pos := QueuePosition{Position: ahead + 1}
+
+	p.metrics.mu.Lock()
+	var total time.Duration
+	for _, latency := range p.metrics.latencies {
+		total += latency
+	}
+	samples := len(p.metrics.latencies)
+	p.metrics.mu.Unlock()
+
+	if samples == 0 || workers == 0 {
+		return pos, true
+	}
+	// Заданию нужен воркер, освободившийся после того, как начнутся все задания перед ним
+	if slots := ahead + 1 - idle; slots > 0 {
+		pos.EstimatedWait = time.Duration(slots) * (total / time.Duration(samples)) / time.Duration(workers)
+	}
+	pos.EstimatedStart = p.clock.Now().Add(pos.EstimatedWait)
+	return pos, true
+}
+
+// aheadLocked возвращает число заданий, которые начнут выполняться раньше t,
+// и false, если t уже не ждёт в очереди. Вызывается под p.mu.
+func (p *Pool[T, R]) aheadLocked(t *task[T, R]) (int, bool) {
+	switch {
+	case t.pinned:
+		return slices.Index(p.affinity[t.worker], t), true
+	case t.held:
+		// Задание встанет в общую очередь после предшественников по партиции
+		return p.queue.len() + slices.Index(p.partitions[t.partition], t), true
+	case t.tagHeld != "":
+		return p.queue.len() + slices.Index(p.tagBacklog[t.tagHeld], t), true
+	}
+	return p.queue.ahead(t)
+}
@@ -0,0 +1,76 @@
+package workerpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestQueuePosition(t *testing.T) {
+	const took = 10 * time.Second
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	// Каждое задание выполняется took по часам пула
+	pool := workerpool.NewPool[int, int](
+		workerpool.WithHandler(func(ctx context.Context, job int) (int, error) {
+			return job, workerpool.Sleep(ctx, took)
+		}),
+		workerpool.WithClock(clock),
+	)
+	// Задания спят по часам пула, которые после теста никто не двигает
+	defer pool.ShutdownNow()
+
+	// Без воркеров и замеров место известно, а начало оценить не по чему
+	warmups := make([]*workerpool.Future[int], 2)
+	for i := range warmups {
+		future, pos, err := pool.SubmitWithPosition(i)
+		if err != nil {
+			t.Fatalf("SubmitWithPosition: %v", err)
+		}
+		if pos.Position != i+1 || !pos.EstimatedStart.IsZero() {
+			t.Errorf("position without workers = %+v, want %d-th with no estimate", pos, i+1)
+		}
+		warmups[i] = future
+	}
+	pool.AddWorker()
+	pool.AddWorker()
+	for _, future := range warmups {
+		advanceUntil(t, clock, took, "warm-up job finished", isDone(future))
+	}
+	if _, ok := pool.QueuePosition(warmups[0].ID()); ok {
+		t.Error("QueuePosition of a finished job reported it")
+	}
+
+	// Оба воркера заняты, поэтому задания ждут по очереди по полпериода на воркера
+	for i := 0; i < 2; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	advanceUntil(t, clock, 0, "workers busy", func() bool { return pool.Stats().BusyWorkers == 2 && pool.QueueLen() == 0 })
+	now := clock.Now()
+	var queued []*workerpool.Future[int]
+	for i := 1; i <= 3; i++ {
+		future, pos, err := pool.SubmitWithPosition(i)
+		if err != nil {
+			t.Fatalf("SubmitWithPosition: %v", err)
+		}
+		wait := time.Duration(i) * took / 2
+		if pos.Position != i || pos.EstimatedWait != wait || !pos.EstimatedStart.Equal(now.Add(wait)) {
+			t.Errorf("job %d position = %+v, want %d-th starting in %v", i, pos, i, wait)
+		}
+		queued = append(queued, future)
+	}
+
+	// Когда задания перед ним начинаются, место продвигается, а начавшееся задание получает 0
+	clock.Advance(took)
+	advanceUntil(t, clock, 0, "next jobs started", func() bool { return pool.QueueLen() == 1 })
+	if pos, ok := pool.QueuePosition(queued[2].ID()); !ok || pos.Position != 1 {
+		t.Errorf("position after two jobs started = %+v, %v; want 1", pos, ok)
+	}
+	if pos, ok := pool.QueuePosition(queued[0].ID()); !ok || pos.Position != 0 {
+		t.Errorf("position of a running job = %+v, %v; want 0", pos, ok)
+	}
+}
@@ -0,0 +1,87 @@
+package workerpool
+
+import (
+	"context"
+	"time"
+)
+
+// progressBuffer — сколько непрочитанных обновлений хранит канал подписчика.
+// Если подписчик не успевает читать, новые обновления для него отбрасываются.
+const progressBuffer = 16
+
+// ProgressUpdate — сообщение обработчика о ходе выполнения задания.
+type ProgressUpdate struct {
+	JobID   JobID
+	Percent float64 // от 0 до 100
+	Message string
+	At      time.Time
+}
+
+type progressKey struct{}
+
+// Progress сообщает подписчикам SubscribeProgress о ходе выполнения текущего задания.
+// Вызывается из обработчика с его ctx; вне обработчика пула ничего не делает.
+func Progress(ctx context.Context, percent float64, msg string) {
+	if report, ok := ctx.Value(progressKey{}).(func(float64, string)); ok {
+		report(percent, msg)
+	}
+}
+
+// SubscribeProgress возвращает канал обновлений хода выполнения задания id и функцию отписки.
+// Канал закрывается, когда задание завершается или отписка вызвана; для уже
+// завершённого или неизвестного задания возвращается закрытый канал.
+func (p *Pool[T, R]) SubscribeProgress(id JobID) (<-chan ProgressUpdate, func()) {
+	ch := make(chan ProgressUpdate, progressBuffer)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.tasks[id]; !exists {
+		close(ch)
+		return ch, noop
+	}
+	if p.progress == nil {
+		p.progress = make(map[JobID][]chan ProgressUpdate)
+	}
+	p.progress[id] = append(p.progress[id], ch)
+
+	unsubscribe := func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		subs := p.progress[id]
+		for i, sub := range subs {
+			if sub == ch {
+				p.progress[id] = append(subs[:i], subs[i+1:]...)
+				close(ch)
+				break
+			}
+		}
+	}
+	return ch, unsubscribe
+}
+
+// withProgress добавляет в контекст обработчика функцию, через которую работает Progress.
+func (p *Pool[T, R]) withProgress(ctx context.Context, id JobID) context.Context {
+	return context.WithValue(ctx, progressKey{}, func(percent float64, msg string) {
+		update := ProgressUpdate{JobID: id, Percent: percent, Message: msg, At: p.clock.Now()}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		for _, ch := range p.progress[id] {
+			select {
+			case ch <- update:
+			default:
+			}
+		}
+	})
+}
+
+// closeProgressLocked закрывает каналы подписчиков завершённого задания. Вызывается под p.mu.
+func (p *Pool[T, R]) closeProgressLocked(id JobID) {
+	for _, ch := range p.progress[id] {
+		close(ch)
+	}
+	delete(p.progress, id)
+}
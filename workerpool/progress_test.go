@@ -0,0 +1,58 @@
+package workerpool
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSubscribeProgress(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		for i := 1; i <= job; i++ {
+			Progress(ctx, float64(i)*100/float64(job), "step")
+		}
+		return job, nil
+	}))
+	defer pool.Shutdown(context.Background())
+
+	// Подписка оформляется, пока задание ждёт в очереди, поэтому видно все обновления
+	future, err := pool.Submit(4)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	updates, _ := pool.SubscribeProgress(future.ID())
+	other, unsubscribe := pool.SubscribeProgress(future.ID())
+	unsubscribe()
+	if _, open := <-other; open {
+		t.Fatal("channel still open after unsubscribe")
+	}
+	pool.AddWorker()
+
+	var percents []float64
+	timeout := time.After(testTimeout)
+	for done := false; !done; {
+		select {
+		case update, open := <-updates:
+			if !open {
+				done = true
+				break
+			}
+			if update.JobID != future.ID() || update.Message != "step" {
+				t.Errorf("update = %+v, want job %d step", update, future.ID())
+			}
+			percents = append(percents, update.Percent)
+		case <-timeout:
+			t.Fatalf("progress channel not closed after the job finished (got %v)", percents)
+		}
+	}
+	if len(percents) != 4 || percents[0] != 25 || percents[3] != 100 {
+		t.Errorf("progress = %v, want [25 50 75 100]", percents)
+	}
+
+	// Для завершённого задания канал сразу закрыт, а Progress вне пула ничего не делает
+	finished, _ := pool.SubscribeProgress(future.ID())
+	if _, open := <-finished; open {
+		t.Error("subscription to a finished job is open")
+	}
+	Progress(context.Background(), 50, "outside")
+}
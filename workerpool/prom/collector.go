@@ -0,0 +1,111 @@
+// Package prom экспортирует показатели пула workerpool в Prometheus.
+// Пакет вынесен в отдельный модуль, чтобы основной пакет не зависел от клиента Prometheus.
+package prom
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StatsSource — пул, показатели которого собирает Collector.
+// Ему удовлетворяет *workerpool.Pool с любыми типами заданий.
+type StatsSource interface {
+	Stats() workerpool.Stats
+}
+
+// Collector реализует prometheus.Collector для пула: число воркеров и длину очереди
+// как gauge, обработанные и упавшие задания как counter, а время обработки
+// и ожидания в очереди как histogram. Холостые задания (workerpool.ErrNoOp) считаются
+// отдельным counter и в histogram не попадают.
+//
+//	c := prom.NewCollector("myapp")
+//	pool := workerpool.NewPool[Job, Result](workerpool.WithHandler(handle), workerpool.WithMetricsObserver(c))
+//	c.Watch(pool)
+//	prometheus.MustRegister(c)
+type Collector struct {
+	mu     sync.Mutex
+	source StatsSource
+
+	workers     *prometheus.Desc
+	busyWorkers *prometheus.Desc
+	queueLen    *prometheus.Desc
+	processed   *prometheus.Desc
+	failed      *prometheus.Desc
+	noOp        *prometheus.Desc
+
+	duration  prometheus.Histogram
+	queueWait prometheus.Histogram
+}
+
+// NewCollector создаёт коллектор с метриками в пространстве имён namespace.
+func NewCollector(namespace string) *Collector {
+	name := func(metric string) string {
+		return prometheus.BuildFQName(namespace, "workerpool", metric)
+	}
+	return &Collector{
+		workers:     prometheus.NewDesc(name("workers"), "Number of workers in the pool.", nil, nil),
+		busyWorkers: prometheus.NewDesc(name("busy_workers"), "Number of workers processing a job.", nil, nil),
+		queueLen:    prometheus.NewDesc(name("queue_length"), "Number of jobs waiting in the queue.", nil, nil),
+		processed:   prometheus.NewDesc(name("jobs_processed_total"), "Total number of processed jobs.", nil, nil),
+		failed:      prometheus.NewDesc(name("jobs_failed_total"), "Total number of jobs that returned an error.", nil, nil),
+		noOp:        prometheus.NewDesc(name("jobs_noop_total"), "Total number of jobs that had nothing to do (workerpool.ErrNoOp).", nil, nil),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    name("job_duration_seconds"),
+			Help:    "Time spent processing a job.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		queueWait: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    name("queue_wait_seconds"),
+			Help:    "Time a job spent waiting in the queue.",
+			Buckets: prometheus.DefBuckets,
+		}),
+	}
+}
+
+// Watch задаёт пул, с которого снимаются gauge и counter метрики.
+func (c *Collector) Watch(source StatsSource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.source = source
+}
+
+// ObserveJob реализует workerpool.MetricsObserver.
+func (c *Collector) ObserveJob(latency, queueWait time.Duration, err error) {
+	c.duration.Observe(latency.Seconds())
+	c.queueWait.Observe(queueWait.Seconds())
+}
+
+// Describe реализует prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.workers
+	ch <- c.busyWorkers
+	ch <- c.queueLen
+	ch <- c.processed
+	ch <- c.failed
+	ch <- c.noOp
+	c.duration.Describe(ch)
+	c.queueWait.Describe(ch)
+}
+
+// Collect реализует prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	source := c.source
+	c.mu.Unlock()
+
+	if source != nil {
+		s := source.Stats()
+		ch <- prometheus.MustNewConstMetric(c.workers, prometheus.GaugeValue, float64(s.Workers))
+		ch <- prometheus.MustNewConstMetric(c.busyWorkers, prometheus.GaugeValue, float64(s.BusyWorkers))
+		ch <- prometheus.MustNewConstMetric(c.queueLen, prometheus.GaugeValue, float64(s.QueueLen))
+		ch <- prometheus.MustNewConstMetric(c.processed, prometheus.CounterValue, float64(s.Processed))
+		ch <- prometheus.MustNewConstMetric(c.failed, prometheus.CounterValue, float64(s.Failed))
+		ch <- prometheus.MustNewConstMetric(c.noOp, prometheus.CounterValue, float64(s.NoOp))
+	}
+	c.duration.Collect(ch)
+	c.queueWait.Collect(ch)
+}
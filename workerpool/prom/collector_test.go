@@ -0,0 +1,64 @@
+package prom
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector("test")
+	pool := workerpool.NewPool[int, int](
+		workerpool.WithHandler(func(ctx context.Context, job int) (int, error) {
+			if job < 0 {
+				return 0, errors.New("boom")
+			}
+			return job, nil
+		}),
+		workerpool.WithMetricsObserver(c),
+		workerpool.WithInitialWorkers(2),
+	)
+	c.Watch(pool)
+	for _, job := range []int{1, 2, -1} {
+		if err := pool.SendJob(job); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(c)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	values := make(map[string]float64)
+	for _, mf := range families {
+		m := mf.GetMetric()[0]
+		switch {
+		case m.Counter != nil:
+			values[mf.GetName()] = m.Counter.GetValue()
+		case m.Gauge != nil:
+			values[mf.GetName()] = m.Gauge.GetValue()
+		case m.Histogram != nil:
+			values[mf.GetName()] = float64(m.Histogram.GetSampleCount())
+		}
+	}
+	for name, want := range map[string]float64{
+		"test_workerpool_jobs_processed_total": 3,
+		"test_workerpool_jobs_failed_total":    1,
+		"test_workerpool_jobs_noop_total":      0,
+		"test_workerpool_queue_length":         0,
+		"test_workerpool_job_duration_seconds": 3,
+		"test_workerpool_queue_wait_seconds":   3,
+	} {
+		if got, ok := values[name]; !ok || got != want {
+			t.Errorf("%s = %v (present %t), want %v", name, got, ok, want)
+		}
+	}
+}
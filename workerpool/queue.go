@@ -0,0 +1,367 @@
+package workerpool
+
+import (
+	"container/heap"
+	"context"
+	"time"
+)
+
+// defaultAgingInterval — без WithAgingInterval за каждый такой промежуток ожидания эффективный
+// приоритет задания растёт на 1. Благодаря этому задания с низким приоритетом не голодают
+// под постоянным потоком срочных.
+const defaultAgingInterval = time.Second
+
+// agingRefresh — как часто очередь пересчитывает приоритеты для WithAgingFunc.
+const agingRefresh = 100 * time.Millisecond
+
+// task — задание в очереди вместе с его стоимостью, приоритетом и, для Submit, ожидающим результатом.
+type task[T, R any] struct {
+	id       JobID
+	job      T
+	cost     int
+	weight   int // вес для WithConcurrencyUnits (0 — как 1)
+	priority int
+	key      string // ключ дедупликации (пустой — без дедупликации)
+	tenant   string // ключ справедливого распределения (пустой — общая очередь)
+	kind     string // тип задания для Register (пустой — обработчик WithHandler)
+	merged   bool   // задание объединено с уже принятым по ключу
+	walKey   uint64 // ключ в журнале WithPersistence (0 — не журналируется)
+
+	// handler — обработчик SubmitWith вместо обработчика пула (nil — обработчик пула)
+	handler Handler[T, R]
+
+	// singleFlight — ключ задания занят до его завершения, как с WithDedupInFlight (SubmitSingleFlight)
+	singleFlight bool
+
+	// partition — ключ партиции SendJobForPartition (пустой — без упорядочивания);
+	// held — задание ждёт завершения предыдущего задания партиции, защищено p.mu
+	partition string
+	held      bool
+
+	// tags — теги WithTagLimit; tagsTaken — задание занимает места в тегах;
+	// tagHeld — тег, в очереди которого задание ждёт (пустой — не ждёт). Защищены p.mu
+	tags      []string
+	tagsTaken bool
+	tagHeld   string
+
+	// reserved — оставшиеся слоты Reservation, один из которых задание занимает вместо места
+	// в общей очереди (nil — обычное задание). Защищено p.mu
+	reserved *int
+
+	// pinned — задание из SubmitToWorker ждёт в личной очереди воркера worker, защищено p.mu
+	pinned bool
+	worker int
+
+	// traceCtx — контекст трассировки WithTracer (nil — не трассируется)
+	traceCtx context.Context
+	timeout  time.Duration // ограничение времени выполнения (0 — без ограничения)
+	latency  time.Duration // время обработки со всеми повторами, заполняется в complete
+	ttl      time.Duration // срок жизни в очереди SubmitWithTTL (0 — общий WithJobTTL)
+
+	// submitCtx — контекст отправителя SubmitContext: из него берутся значения и срок (nil — не задан)
+	submitCtx context.Context
+	enqueued  time.Time // время постановки в очередь
+	metadata  map[string]string
+	future    *Future[R]
+
+	// cancel отменяет контекст выполняющегося задания; cancelled — Cancel вызван до начала выполнения.
+	// Оба поля защищены p.mu.
+	cancel    context.CancelFunc
+	cancelled bool
+
+	probe bool // пробное задание полуоткрытой цепи WithCircuitBreaker
+
+	// completed — итог текущего выполнения уже передаётся в complete; crashes — сколько раз
+	// воркер падал посреди задания (WithSupervision). Используются только горутиной воркера
+	completed bool
+	crashes   int
+
+	rank  float64 // ключ порядка с учётом старения: больше — раньше
+	seq   uint64  // номер постановки в очередь: при равном rank первым идёт более раннее
+	index int     // позиция в куче
+}
+
+// finish передаёт итог задания ожидающему Future, если он есть.
+func (t *task[T, R]) finish(value R, err error) {
+	if t.future != nil {
+		t.future.resolve(value, err)
+	}
+}
+
+// taskQueue — очередь заданий с приоритетами.
+// Задания каждого арендатора (task.tenant) лежат в отдельной двоичной куче, а pop обходит
+// арендаторов по кругу, поэтому арендатор с тысячами заданий не задерживает остальных.
+// Задания без арендатора попадают в общую кучу, которая участвует в обходе наравне с другими.
+// Линейное старение учитывается без перестройки кучи: эффективный приоритет priority + ожидание/interval
+// у всех заданий растёт одинаково, поэтому порядок задаёт постоянный ключ priority - постановка/interval.
+// Произвольная функция старения aging так не сводится, и кучи перестраиваются не чаще раза в agingRefresh.
+type taskQueue[T, R any] struct {
+	tenants map[string]*taskHeap[T, R]
+	ring    []string // арендаторы с непустыми кучами в порядке обхода
+	next    int      // позиция в ring, с которой начнётся следующий pop
+	n       int
+	seq     uint64
+	epoch   time.Time // точка отсчёта для ключа старения
+	clock   Clock     // часы пула для пересчёта старения
+
+	// fifo — кольцевой буфер заданий в порядке постановки, начиная с fifoHead: даёт oldest
+	// за амортизированное O(1) для OverflowDropOldest и ведётся, только если задан ordered.
+	// Выданные и убранные задания не вычищаются сразу, а пропускаются, когда доходят до начала буфера
+	ordered  bool
+	fifo     []fifoEntry[T, R]
+	fifoHead int
+
+	interval time.Duration // шаг линейного старения (0 — без старения)
+	aging    AgingFunc     // функция WithAgingFunc (nil — линейное старение)
+	ranked   time.Time     // момент последнего пересчёта приоритетов для aging
+}
+
+func newTaskQueue[T, R any](clock Clock) taskQueue[T, R] {
+	now := clock.Now()
+	return taskQueue[T, R]{tenants: make(map[string]*taskHeap[T, R]), clock: clock, epoch: now, ranked: now, interval: defaultAgingInterval}
+}
+
+func (q *taskQueue[T, R]) len() int {
+	return q.n
+}
+
+// push ставит задание в очередь в момент now.
+func (q *taskQueue[T, R]) push(t *task[T, R], now time.Time) {
+	q.seq++
+	t.seq = q.seq
+	t.rank = q.rankOf(t, now)
+	h, ok := q.tenants[t.tenant]
+	if !ok {
+		h = &taskHeap[T, R]{}
+		q.tenants[t.tenant] = h
+		// Новый арендатор встаёт в конец обхода, перед тем, чья очередь сейчас
+		q.ring = append(q.ring, "")
+		copy(q.ring[q.next+1:], q.ring[q.next:])
+		q.ring[q.next] = t.tenant
+		q.next++
+		if q.next == len(q.ring) {
+			q.next = 0
+		}
+	}
+	heap.Push(h, t)
+	q.n++
+	if !q.ordered {
+		return
+	}
+	q.fifo = append(q.fifo, fifoEntry[T, R]{t: t, seq: t.seq})
+	if stale := len(q.fifo) - q.fifoHead - q.n; stale > q.n+64 {
+		q.compactFIFO()
+	}
+}
+
+// fifoEntry — запись кольцевого буфера taskQueue.fifo.
+type fifoEntry[T, R any] struct {
+	t   *task[T, R]
+	seq uint64 // seq задания в момент постановки: отличает устаревшую запись
+}
+
+// queued сообщает, что задание записи всё ещё ждёт в очереди.
+func (e fifoEntry[T, R]) queued() bool {
+	return e.t.index >= 0 && e.t.seq == e.seq
+}
+
+// compactFIFO убирает из буфера записи выданных и убранных заданий.
+func (q *taskQueue[T, R]) compactFIFO() {
+	live := q.fifo[:0]
+	for _, e := range q.fifo[q.fifoHead:] {
+		if e.queued() {
+			live = append(live, e)
+		}
+	}
+	clear(q.fifo[len(live):])
+	q.fifo, q.fifoHead = live, 0
+}
+
+// pop извлекает задание с наибольшим эффективным приоритетом у очередного арендатора
+// или nil, если очередь пуста.
+func (q *taskQueue[T, R]) pop() *task[T, R] {
+	if q.n == 0 {
+		return nil
+	}
+	if q.aging != nil {
+		if now := q.clock.Now(); now.Sub(q.ranked) >= agingRefresh {
+			q.rerank(now)
+		}
+	}
+	tenant := q.ring[q.next]
+	h := q.tenants[tenant]
+	t := heap.Pop(h).(*task[T, R])
+	q.n--
+	if h.Len() == 0 {
+		q.dropTenant(q.next)
+	} else {
+		q.next++
+	}
+	if q.next >= len(q.ring) {
+		q.next = 0
+	}
+	return t
+}
+
+// rankOf возвращает ключ порядка задания, поставленного в очередь в момент now.
+func (q *taskQueue[T, R]) rankOf(t *task[T, R], now time.Time) float64 {
+	switch {
+	case q.aging != nil:
+		// Ключ должен быть сравним с ключами последнего пересчёта
+		return q.aging(t.priority, max(0, q.ranked.Sub(t.enqueued)))
+	case q.interval > 0:
+		return float64(t.priority) - float64(now.Sub(q.epoch))/float64(q.interval)
+	}
+	return float64(t.priority)
+}
+
+// rerank пересчитывает эффективные приоритеты по функции aging на момент now.
+func (q *taskQueue[T, R]) rerank(now time.Time) {
+	q.ranked = now
+	for _, h := range q.tenants {
+		for _, t := range *h {
+			t.rank = q.aging(t.priority, now.Sub(t.enqueued))
+		}
+		heap.Init(h)
+	}
+}
+
+// remove убирает задание из очереди. Возвращает false, если его там уже нет.
+func (q *taskQueue[T, R]) remove(t *task[T, R]) bool {
+	h, ok := q.tenants[t.tenant]
+	if !ok || t.index < 0 || t.index >= len(*h) || (*h)[t.index] != t {
+		return false
+	}
+	heap.Remove(h, t.index)
+	q.n--
+	if h.Len() == 0 {
+		for i, tenant := range q.ring {
+			if tenant == t.tenant {
+				q.dropTenant(i)
+				break
+			}
+		}
+		if q.next >= len(q.ring) {
+			q.next = 0
+		}
+	}
+	return true
+}
+
+// dropTenant исключает из обхода арендатора на позиции i, когда его куча опустела.
+func (q *taskQueue[T, R]) dropTenant(i int) {
+	delete(q.tenants, q.ring[i])
+	q.ring = append(q.ring[:i], q.ring[i+1:]...)
+	if i < q.next {
+		q.next--
+	}
+}
+
+// oldest возвращает задание, дольше всех ждущее в очереди, или nil, если очередь пуста.
+// Требует ordered.
+func (q *taskQueue[T, R]) oldest() *task[T, R] {
+	for q.fifoHead < len(q.fifo) {
+		if e := q.fifo[q.fifoHead]; e.queued() {
+			return e.t
+		}
+		q.fifo[q.fifoHead] = fifoEntry[T, R]{}
+		q.fifoHead++
+	}
+	q.fifo, q.fifoHead = q.fifo[:0], 0
+	return nil
+}
+
+// peek возвращает задание, которое выдаст следующий pop, не извлекая его, или nil,
+// если очередь пуста. Отложенный пересчёт WithAgingFunc peek не выполняет.
+func (q *taskQueue[T, R]) peek() *task[T, R] {
+	if q.n == 0 {
+		return nil
+	}
+	return (*q.tenants[q.ring[q.next]])[0]
+}
+
+// list возвращает все задания очереди в произвольном порядке, не извлекая их.
+func (q *taskQueue[T, R]) list() []*task[T, R] {
+	tasks := make([]*task[T, R], 0, q.n)
+	for _, h := range q.tenants {
+		tasks = append(tasks, *h...)
+	}
+	return tasks
+}
+
+// ahead возвращает число заданий очереди, которые pop выдаст раньше t,
+// и false, если t в очереди нет.
+func (q *taskQueue[T, R]) ahead(t *task[T, R]) (int, bool) {
+	h, ok := q.tenants[t.tenant]
+	if !ok || t.index < 0 || t.index >= len(*h) || (*h)[t.index] != t {
+		return 0, false
+	}
+	// Сколько заданий своего арендатора выйдет раньше t
+	own := 0
+	for _, other := range *h {
+		if other.rank > t.rank || (other.rank == t.rank && other.seq < t.seq) {
+			own++
+		}
+	}
+
+	// Арендаторы выдают по заданию по кругу начиная с q.next: стоящие в обходе раньше
+	// арендатора t успеют выдать own+1 заданий, стоящие позже — own
+	ahead := own
+	before := true
+	for i := range q.ring {
+		tenant := q.ring[(q.next+i)%len(q.ring)]
+		if tenant == t.tenant {
+			before = false
+			continue
+		}
+		turns := own
+		if before {
+			turns++
+		}
+		ahead += min(turns, len(*q.tenants[tenant]))
+	}
+	return ahead, true
+}
+
+// drain извлекает все задания в порядке очереди.
+func (q *taskQueue[T, R]) drain() []*task[T, R] {
+	tasks := make([]*task[T, R], 0, q.n)
+	for t := q.pop(); t != nil; t = q.pop() {
+		tasks = append(tasks, t)
+	}
+	return tasks
+}
+
+// taskHeap реализует heap.Interface для taskQueue.
+type taskHeap[T, R any] []*task[T, R]
+
+func (h taskHeap[T, R]) Len() int { return len(h) }
+
+func (h taskHeap[T, R]) Less(i, j int) bool {
+	if h[i].rank != h[j].rank {
+		return h[i].rank > h[j].rank
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h taskHeap[T, R]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *taskHeap[T, R]) Push(x any) {
+	t := x.(*task[T, R])
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *taskHeap[T, R]) Pop() any {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	t.index = -1
+	*h = old[:n-1]
+	return t
+}
@@ -0,0 +1,131 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// scanOldest — прежний поиск самого давнего задания полным обходом куч, для сравнения с fifo.
+func scanOldest[T, R any](q *taskQueue[T, R]) *task[T, R] {
+	var oldest *task[T, R]
+	for _, h := range q.tenants {
+		for _, t := range *h {
+			if oldest == nil || t.seq < oldest.seq {
+				oldest = t
+			}
+		}
+	}
+	return oldest
+}
+
+// BenchmarkDropOldest измеряет вытеснение при заполненной очереди OverflowDropOldest:
+// поиск самого давнего задания, его удаление и постановку нового.
+//
+//	go test -run '^$' -bench DropOldest -benchmem
+func BenchmarkDropOldest(b *testing.B) {
+	for _, size := range []int{100, 10000} {
+		for _, bc := range []struct {
+			name   string
+			oldest func(*taskQueue[int, int]) *task[int, int]
+		}{
+			{"ring", (*taskQueue[int, int]).oldest},
+			{"scan", scanOldest[int, int]},
+		} {
+			b.Run(fmt.Sprintf("%s/size=%d", bc.name, size), func(b *testing.B) {
+				q := newTaskQueue[int, int](realClock{})
+				// Прежняя очередь буфер не вела и за его поддержку не платила
+				q.ordered = bc.name == "ring"
+				now := q.clock.Now()
+				for i := 0; i < size; i++ {
+					q.push(&task[int, int]{job: i, priority: i % 4, tenant: fmt.Sprint(i % 8)}, now)
+				}
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					oldest := bc.oldest(&q)
+					if !q.remove(oldest) {
+						b.Fatal("oldest job is not in the queue")
+					}
+					q.push(&task[int, int]{job: i, priority: i % 4, tenant: oldest.tenant}, now)
+				}
+			})
+		}
+	}
+}
+
+func TestOldestFollowsArrivalOrder(t *testing.T) {
+	q := newTaskQueue[int, int](realClock{})
+	q.ordered = true
+	now := q.clock.Now()
+	for i := 0; i < 200; i++ {
+		q.push(&task[int, int]{job: i, priority: i % 4, tenant: fmt.Sprint(i % 3)}, now)
+	}
+	// Выданные pop задания пропускаются, и oldest совпадает с полным обходом
+	for i := 0; i < 50; i++ {
+		q.pop()
+	}
+	for q.len() > 0 {
+		want := scanOldest(&q)
+		if got := q.oldest(); got != want {
+			t.Fatalf("oldest = job %d, want job %d", got.job, want.job)
+		}
+		q.remove(want)
+	}
+	if got := q.oldest(); got != nil {
+		t.Errorf("oldest of empty queue = job %d, want nil", got.job)
+	}
+}
+
+func TestQueuePriorityAndAging(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	q := newTaskQueue[string, string](realClock{})
+	q.epoch = start
+
+	// Одновременно поставленные задания выходят по приоритету, равные — в порядке постановки
+	q.push(&task[string, string]{job: "low", priority: 0}, start)
+	q.push(&task[string, string]{job: "high", priority: 5}, start)
+	q.push(&task[string, string]{job: "high-2", priority: 5}, start)
+	for _, want := range []string{"high", "high-2", "low"} {
+		if got := q.pop(); got == nil || got.job != want {
+			t.Fatalf("pop = %v, want %q", got, want)
+		}
+	}
+
+	// За каждую секунду ожидания приоритет растёт на 1: фоновое задание, прождавшее 3 с,
+	// обгоняет пришедшее позже задание с приоритетом 2
+	q.push(&task[string, string]{job: "background", priority: 0}, start)
+	q.push(&task[string, string]{job: "urgent", priority: 2}, start.Add(3*time.Second))
+	if got := q.pop(); got.job != "background" {
+		t.Errorf("pop after aging = %q, want %q", got.job, "background")
+	}
+}
+
+func TestSendJobWithPriorityOrder(t *testing.T) {
+	processed := make(chan int, 4)
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		processed <- job
+		return job, nil
+	}))
+	defer pool.Shutdown(context.Background())
+
+	// Без воркеров задания копятся в очереди, а первый воркер разбирает их по приоритету
+	for _, priority := range []int{1, 3, 0, 2} {
+		if err := pool.SendJobWithPriority(priority, priority); err != nil {
+			t.Fatalf("SendJobWithPriority: %v", err)
+		}
+	}
+	pool.AddWorker()
+	for _, want := range []int{3, 2, 1, 0} {
+		select {
+		case got := <-processed:
+			if got != want {
+				t.Errorf("processed %d, want %d", got, want)
+			}
+		case <-time.After(testTimeout):
+			t.Fatal("jobs were not processed")
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Package redisqueue реализует workerpool.Queue поверх списка Redis, чтобы пулы
+// в нескольких процессах делили одну очередь заданий.
+// Пакет вынесен в отдельный модуль, чтобы основной пакет не зависел от клиента Redis.
+//
+// Задание удаляется из списка в момент извлечения, поэтому при падении процесса
+// задания, уже переданные в его пул, теряются (доставка «не более одного раза»).
+// Для гарантированной доставки совмещайте с workerpool.WithPersistence.
+package redisqueue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/redis/go-redis/v9"
+)
+
+// popTimeout — на сколько блокируется BRPOP; между вызовами проверяется отмена ctx.
+const popTimeout = time.Second
+
+// Queue — очередь заданий в списке Redis с ключом key.
+type Queue struct {
+	client redis.UniversalClient
+	key    string
+}
+
+var _ workerpool.Queue = (*Queue)(nil)
+
+// New создаёт очередь в списке key. Клиент остаётся во владении вызывающего:
+// Close его не закрывает.
+func New(client redis.UniversalClient, key string) *Queue {
+	return &Queue{client: client, key: key}
+}
+
+// Push реализует workerpool.Queue.
+func (q *Queue) Push(ctx context.Context, data []byte) error {
+	return q.client.LPush(ctx, q.key, data).Err()
+}
+
+// Pop реализует workerpool.Queue.
+func (q *Queue) Pop(ctx context.Context) ([]byte, error) {
+	for {
+		res, err := q.client.BRPop(ctx, popTimeout, q.key).Result()
+		switch {
+		case err == nil:
+			// BRPOP возвращает пару «ключ, значение»
+			return []byte(res[1]), nil
+		case errors.Is(err, redis.Nil):
+			// Таймаут без заданий — проверяем ctx и ждём снова
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+		default:
+			return nil, err
+		}
+	}
+}
+
+// Len реализует workerpool.Queue.
+func (q *Queue) Len(ctx context.Context) (int, error) {
+	n, err := q.client.LLen(ctx, q.key).Result()
+	return int(n), err
+}
+
+// Close реализует workerpool.Queue.
+func (q *Queue) Close() error {
+	return nil
+}
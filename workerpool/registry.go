@@ -0,0 +1,129 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownJobType — для типа задания не зарегистрирован обработчик.
+var ErrUnknownJobType = errors.New("unknown job type")
+
+// ErrNoHandler — пул создан без WithHandler, а у задания нет своего обработчика.
+var ErrNoHandler = errors.New("no handler configured for job")
+
+// WithNamedHandler регистрирует обработчик для заданий типа name, как Register.
+// Пул, в котором есть именованные обработчики, можно создать и без WithHandler:
+// тогда задания без типа (SendJob, Submit) отклоняются при отправке с ErrNoHandler.
+func WithNamedHandler[T, R any](name string, handler Handler[T, R]) Option {
+	return func(c *config) {
+		if c.namedHandlers == nil {
+			c.namedHandlers = make(map[string]any)
+		}
+		c.namedHandlers[name] = handler
+	}
+}
+
+// Register регистрирует обработчик для заданий типа name, превращая пул в небольшой
+// исполнитель задач: pool.Register("resize_image", resize), pool.Register("send_email", send).
+// Задания с типом отправляются через SendNamed и SubmitNamed; повторная регистрация
+// заменяет обработчик. Middleware из WithMiddleware оборачивает все обработчики.
+func (p *Pool[T, R]) Register(name string, handler Handler[T, R]) {
+	p.registryMu.Lock()
+	defer p.registryMu.Unlock()
+
+	handlers := make(map[string]Handler[T, R])
+	if current := p.registry.Load(); current != nil {
+		for n, h := range *current {
+			handlers[n] = h
+		}
+	}
+	handlers[name] = handler
+	p.registry.Store(&handlers)
+}
+
+// SendNamed помещает в очередь задание типа name.
+// Возвращает ErrUnknownJobType, если обработчик для типа не зарегистрирован.
+func (p *Pool[T, R]) SendNamed(name string, job T) error {
+	_, err := p.SubmitNamed(name, job)
+	return err
+}
+
+// SubmitNamed — то же, что SendNamed, но возвращает Future с результатом задания.
+// Тип задания доступен обработчику через JobInfo(ctx).Type. Журнал WithPersistence
+// тип не сохраняет: после перезапуска такие задания обработает обработчик WithHandler.
+func (p *Pool[T, R]) SubmitNamed(name string, job T) (*Future[R], error) {
+	if p.namedHandler(name) == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownJobType, name)
+	}
+	t := &task[T, R]{job: job, cost: 1, kind: name, future: newFuture[R]()}
+	if err := p.enqueue(t); err != nil {
+		return nil, err
+	}
+	return t.future, nil
+}
+
+// SubmitWith — то же, что Submit, но задание обрабатывает handler вместо обработчика пула,
+// например для разовой служебной задачи, ради которой не стоит заводить отдельный пул.
+// На handler, как и на обработчик пула, действуют таймаут, перехват паник, WithRetry и middleware.
+// В пакетном режиме (WithBatchHandler) задания обрабатываются пакетами, и SubmitWith недоступен.
+func (p *Pool[T, R]) SubmitWith(job T, handler Handler[T, R]) (*Future[R], error) {
+	if handler == nil {
+		return nil, errors.New("SubmitWith requires a handler")
+	}
+	if p.batch != nil {
+		return nil, errors.New("SubmitWith is not supported in batch mode")
+	}
+	t := &task[T, R]{job: job, cost: 1, handler: handler, future: newFuture[R]()}
+	if err := p.enqueue(t); err != nil {
+		return nil, err
+	}
+	return t.future, nil
+}
+
+// checkHandler проверяет, что заданию t есть кому достаться: обработчику WithHandler,
+// своему обработчику SubmitWith, обработчику типа или пакетному обработчику.
+// Так ошибка настройки видна при отправке, а не после того, как задание возьмёт воркер.
+func (p *Pool[T, R]) checkHandler(t *task[T, R]) error {
+	if p.hasHandler || p.batch != nil || t.handler != nil || t.kind != "" {
+		return nil
+	}
+	return ErrNoHandler
+}
+
+// namedHandler возвращает обработчик типа name или nil.
+func (p *Pool[T, R]) namedHandler(name string) Handler[T, R] {
+	if handlers := p.registry.Load(); handlers != nil {
+		return (*handlers)[name]
+	}
+	return nil
+}
+
+// dispatch возвращает обработчик, выбирающий зарегистрированный обработчик по типу задания.
+// Задания без типа получает fallback, а задания SubmitWith — свой обработчик.
+func (p *Pool[T, R]) dispatch(fallback Handler[T, R]) Handler[T, R] {
+	return func(ctx context.Context, job T) (R, error) {
+		info, _ := JobInfo(ctx)
+		if handler, ok := info.handler.(Handler[T, R]); ok && handler != nil {
+			return handler(ctx, job)
+		}
+		if info.Type == "" && fallback != nil {
+			return fallback(ctx, job)
+		}
+		if handler := p.namedHandler(info.Type); handler != nil {
+			return handler(ctx, job)
+		}
+		return *new(R), fmt.Errorf("%w: %q", ErrUnknownJobType, info.Type)
+	}
+}
+
+// registerNamed переносит обработчики WithNamedHandler в реестр пула.
+func (p *Pool[T, R]) registerNamed() {
+	for name, h := range p.namedHandlers {
+		handler, ok := h.(Handler[T, R])
+		if !ok {
+			panic(fmt.Sprintf("workerpool: handler type %T for %q does not match pool type %T", h, name, Handler[T, R](nil)))
+		}
+		p.Register(name, handler)
+	}
+}
@@ -0,0 +1,186 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSubmitWithOverridesHandler(t *testing.T) {
+	var defaultCalls, wrapped atomic.Int32
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			defaultCalls.Add(1)
+			return "default " + job, nil
+		}),
+		WithMiddleware(func(next Handler[string, string]) Handler[string, string] {
+			return func(ctx context.Context, job string) (string, error) {
+				wrapped.Add(1)
+				return next(ctx, job)
+			}
+		}),
+		WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	future, err := pool.SubmitWith("cleanup", func(ctx context.Context, job string) (string, error) {
+		return "custom " + job, nil
+	})
+	if err != nil {
+		t.Fatalf("SubmitWith: %v", err)
+	}
+	value, err := await(t, future)
+	if err != nil {
+		t.Fatalf("job failed: %v", err)
+	}
+	if value != "custom cleanup" {
+		t.Errorf("result = %q, want %q", value, "custom cleanup")
+	}
+	if n := defaultCalls.Load(); n != 0 {
+		t.Errorf("default handler called %d times, want 0", n)
+	}
+	if n := wrapped.Load(); n != 1 {
+		t.Errorf("middleware called %d times, want 1", n)
+	}
+}
+
+func TestSendJobWithoutHandler(t *testing.T) {
+	pool := NewPool[string, string](
+		WithNamedHandler("echo", func(ctx context.Context, job string) (string, error) {
+			return job, nil
+		}),
+		WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	if err := pool.SendJob("plain"); !errors.Is(err, ErrNoHandler) {
+		t.Fatalf("SendJob error = %v, want ErrNoHandler", err)
+	}
+	if _, err := pool.Submit("plain"); !errors.Is(err, ErrNoHandler) {
+		t.Fatalf("Submit error = %v, want ErrNoHandler", err)
+	}
+
+	// Задания со своим обработчиком пул по-прежнему принимает
+	future, err := pool.SubmitNamed("echo", "typed")
+	if err != nil {
+		t.Fatalf("SubmitNamed: %v", err)
+	}
+	if value, err := await(t, future); err != nil || value != "typed" {
+		t.Errorf("SubmitNamed result = %q, %v", value, err)
+	}
+	future, err = pool.SubmitWith("closure", func(ctx context.Context, job string) (string, error) {
+		return job, nil
+	})
+	if err != nil {
+		t.Fatalf("SubmitWith: %v", err)
+	}
+	if value, err := await(t, future); err != nil || value != "closure" {
+		t.Errorf("SubmitWith result = %q, %v", value, err)
+	}
+}
+
+func TestReservationWithoutHandler(t *testing.T) {
+	pool := NewPool[string, string]()
+	defer pool.Shutdown(context.Background())
+
+	r, err := pool.Reserve(1)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if _, err := r.Submit("plain"); !errors.Is(err, ErrNoHandler) {
+		t.Fatalf("Reservation.Submit error = %v, want ErrNoHandler", err)
+	}
+}
+
+func TestSubmitWithChildJobUsesPoolHandler(t *testing.T) {
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			return "default " + job, nil
+		}),
+		WithInitialWorkers(2),
+	)
+	// С унаследованным обработчиком потомки порождали бы новых потомков без конца
+	defer pool.ShutdownNow()
+
+	// Задание, отправленное из обработчика SubmitWith с его ctx, не наследует этот обработчик
+	future, err := pool.SubmitWith("parent", func(ctx context.Context, job string) (string, error) {
+		child, err := pool.SubmitContext(ctx, "child")
+		if err != nil {
+			return "", err
+		}
+		select {
+		case <-child.Done():
+			return child.Result(), child.Err()
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(testTimeout / 2):
+			return "", errors.New("child job did not finish")
+		}
+	})
+	if err != nil {
+		t.Fatalf("SubmitWith: %v", err)
+	}
+	value, err := await(t, future)
+	if err != nil {
+		t.Fatalf("job failed: %v", err)
+	}
+	if value != "default child" {
+		t.Errorf("child result = %q, want %q", value, "default child")
+	}
+}
+
+func TestNamedHandlers(t *testing.T) {
+	upper := func(ctx context.Context, job string) (string, error) {
+		info, _ := JobInfo(ctx)
+		return info.Type + ":" + strings.ToUpper(job), nil
+	}
+	pool := NewPool[string, string](WithHandler(echo[string]), WithNamedHandler("upper", upper), WithInitialWorkers(1))
+	defer pool.Shutdown(context.Background())
+	pool.Register("reverse", func(ctx context.Context, job string) (string, error) {
+		runes := []rune(job)
+		slices.Reverse(runes)
+		return string(runes), nil
+	})
+
+	// Задания с типом уходят своему обработчику, без типа — обработчику WithHandler
+	for _, tt := range []struct{ kind, job, want string }{
+		{"upper", "mail", "upper:MAIL"},
+		{"reverse", "mail", "liam"},
+		{"", "mail", "mail"},
+	} {
+		var future *Future[string]
+		var err error
+		if tt.kind == "" {
+			future, err = pool.Submit(tt.job)
+		} else {
+			future, err = pool.SubmitNamed(tt.kind, tt.job)
+		}
+		if err != nil {
+			t.Fatalf("submit %q: %v", tt.kind, err)
+		}
+		if got, err := await(t, future); err != nil || got != tt.want {
+			t.Errorf("job of type %q = %q, %v; want %q", tt.kind, got, err, tt.want)
+		}
+	}
+
+	// Повторная регистрация заменяет обработчик
+	pool.Register("upper", echo[string])
+	future, err := pool.SubmitNamed("upper", "mail")
+	if err != nil {
+		t.Fatalf("SubmitNamed: %v", err)
+	}
+	if got, _ := await(t, future); got != "mail" {
+		t.Errorf("re-registered handler result = %q, want mail", got)
+	}
+
+	if err := pool.SendNamed("resize", "img"); !errors.Is(err, ErrUnknownJobType) {
+		t.Errorf("SendNamed of an unknown type = %v, want ErrUnknownJobType", err)
+	}
+	if _, err := pool.SubmitNamed("resize", "img"); !errors.Is(err, ErrUnknownJobType) {
+		t.Errorf("SubmitNamed of an unknown type = %v, want ErrUnknownJobType", err)
+	}
+}
@@ -0,0 +1,161 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Queue — внешняя очередь заданий (Redis, NATS, SQS...), через которую несколько
+// процессов делят общую нагрузку: отправители кладут туда задания через PushJob,
+// а каждый процесс вычитывает их в свой пул через Pool.Consume.
+type Queue interface {
+	// Push добавляет закодированное задание в очередь.
+	Push(ctx context.Context, data []byte) error
+	// Pop извлекает следующее задание, ожидая его появления до отмены ctx.
+	Pop(ctx context.Context) ([]byte, error)
+	// Len возвращает число заданий в очереди.
+	Len(ctx context.Context) (int, error)
+	// Close освобождает ресурсы очереди.
+	Close() error
+}
+
+// ErrQueueClosed — внешняя очередь закрыта.
+var ErrQueueClosed = errors.New("queue is closed")
+
+// PushJob кодирует задание в JSON и кладёт его во внешнюю очередь q.
+func PushJob[T any](ctx context.Context, q Queue, job T) error {
+	return PushJobWithCodec(ctx, q, JSONCodec{}, job)
+}
+
+// PushJobWithCodec — то же, что PushJob, но кодирует задание кодеком codec.
+// Пулы, вычитывающие q, должны быть созданы с тем же кодеком (WithCodec).
+func PushJobWithCodec[T any](ctx context.Context, q Queue, codec Codec, job T) error {
+	data, err := codec.Encode(&job)
+	if err != nil {
+		return fmt.Errorf("encode job: %w", err)
+	}
+	return q.Push(ctx, data)
+}
+
+// Consume вычитывает задания из внешней очереди q и ставит их в пул, пока не будет
+// отменён ctx, не закроется очередь или пул не начнёт останавливаться.
+// Задания декодируются кодеком WithCodec (по умолчанию JSON). Когда очередь
+// пула заполнена, новые задания не вычитываются, так что лишняя работа достаётся
+// другим процессам. Задание, взятое из q, но не принятое пулом, уходит обработчику
+// недоставленных (WithDeadLetter).
+func (p *Pool[T, R]) Consume(ctx context.Context, q Queue) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-p.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		// Ждём места до извлечения, чтобы не держать у себя задания, которые могли бы взять другие
+		if err := p.waitSpace(ctx); err != nil {
+			return err
+		}
+		data, err := q.Pop(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		var job T
+		if err := p.codec.Decode(data, &job); err != nil {
+			p.logger.Error("dropping undecodable job from queue", "error", err)
+			p.deadLetter(job, fmt.Errorf("decode job: %w", err), 0)
+			continue
+		}
+		if err := p.SendJobContext(context.WithoutCancel(ctx), job); err != nil {
+			p.deadLetter(job, err, 0)
+			return err
+		}
+	}
+}
+
+// waitSpace ждёт, пока в очереди пула не появится место.
+func (p *Pool[T, R]) waitSpace(ctx context.Context) error {
+	for {
+		p.mu.Lock()
+		if err := p.acceptingLocked(); err != nil {
+			p.mu.Unlock()
+			return err
+		}
+		if p.queuedLocked()+p.reserved < p.bufferSize {
+			p.mu.Unlock()
+			return nil
+		}
+		space := p.spaceWaitLocked()
+		p.mu.Unlock()
+
+		select {
+		case <-space:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// MemoryQueue — Queue в памяти процесса. Подходит для тестов и для связки
+// нескольких пулов внутри одного процесса.
+type MemoryQueue struct {
+	items  chan []byte
+	closed chan struct{}
+}
+
+// NewMemoryQueue создаёт очередь в памяти на size заданий.
+func NewMemoryQueue(size int) *MemoryQueue {
+	return &MemoryQueue{items: make(chan []byte, size), closed: make(chan struct{})}
+}
+
+// Push реализует Queue. Ждёт места, если очередь заполнена.
+func (q *MemoryQueue) Push(ctx context.Context, data []byte) error {
+	select {
+	case <-q.closed:
+		return ErrQueueClosed
+	default:
+	}
+	select {
+	case q.items <- data:
+		return nil
+	case <-q.closed:
+		return ErrQueueClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Pop реализует Queue.
+func (q *MemoryQueue) Pop(ctx context.Context) ([]byte, error) {
+	select {
+	case data := <-q.items:
+		return data, nil
+	case <-q.closed:
+		return nil, ErrQueueClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Len реализует Queue.
+func (q *MemoryQueue) Len(context.Context) (int, error) {
+	return len(q.items), nil
+}
+
+// Close реализует Queue. Оставшиеся задания больше нельзя извлечь.
+func (q *MemoryQueue) Close() error {
+	select {
+	case <-q.closed:
+	default:
+		close(q.closed)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConsumeLeavesJobsForOtherPools(t *testing.T) {
+	ctx := context.Background()
+	q := NewMemoryQueue(10)
+	for i := 0; i < 5; i++ {
+		if err := PushJob(ctx, q, i); err != nil {
+			t.Fatalf("PushJob: %v", err)
+		}
+	}
+
+	// Пул без воркеров с очередью на 2 задания вычитывает ровно столько, сколько вмещает
+	busy := NewPool[int, int](WithHandler(echo[int]), WithBufferSize(2))
+	defer busy.ShutdownNow()
+	consumed := make(chan error, 1)
+	go func() { consumed <- busy.Consume(ctx, q) }()
+	eventually(t, "busy pool filled", func() bool { return busy.QueueLen() == 2 })
+	time.Sleep(10 * time.Millisecond)
+	if n, _ := q.Len(ctx); n != 3 {
+		t.Fatalf("external queue has %d jobs, want 3 left for other pools", n)
+	}
+
+	// Остальное забирает другой пул
+	done := make(chan int, 5)
+	idle := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		done <- job
+		return job, nil
+	}), WithInitialWorkers(1))
+	defer idle.Shutdown(ctx)
+	go idle.Consume(ctx, q)
+	for i := 0; i < 3; i++ {
+		select {
+		case <-done:
+		case <-time.After(testTimeout):
+			t.Fatalf("second pool processed %d of 3 jobs", i)
+		}
+	}
+
+	// Закрытие внешней очереди завершает Consume
+	q.Close()
+	busy.AddWorker()
+	select {
+	case err := <-consumed:
+		if !errors.Is(err, ErrQueueClosed) {
+			t.Fatalf("Consume after Close = %v, want ErrQueueClosed", err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("Consume did not return after the queue was closed")
+	}
+}
+
+func TestConsumeDeadLettersUndecodableJobs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := NewMemoryQueue(2)
+	q.Push(ctx, []byte("not json"))
+	PushJob(ctx, q, 7)
+
+	dead := NewDeadLetterQueue(1)
+	pool := NewPool[int, int](WithHandler(echo[int]), WithDeadLetter(dead), WithInitialWorkers(1))
+	defer pool.Shutdown(context.Background())
+	results := make(chan Result[int, int], 1)
+	pool.OnResult(func(r Result[int, int]) { results <- r })
+	consumed := make(chan error, 1)
+	go func() { consumed <- pool.Consume(ctx, q) }()
+
+	select {
+	case dl := <-dead.C():
+		if dl.Err == nil || !strings.HasPrefix(dl.Err.Error(), "decode job") {
+			t.Errorf("dead letter error = %v, want a decode error", dl.Err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("undecodable job did not reach dead letters")
+	}
+	// Следующее задание вычитывается как обычно
+	select {
+	case r := <-results:
+		if r.Job != 7 || r.Err != nil {
+			t.Errorf("result = %+v, want job 7", r)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("job after the undecodable one was not processed")
+	}
+
+	cancel()
+	select {
+	case err := <-consumed:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Consume after cancel = %v, want context.Canceled", err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("Consume did not return after ctx was canceled")
+	}
+}
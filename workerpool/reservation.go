@@ -0,0 +1,56 @@
+package workerpool
+
+import (
+	"fmt"
+)
+
+// Reservation — набор слотов очереди, заранее зарезервированных под будущие задания.
+type Reservation[T, R any] struct {
+	pool  *Pool[T, R]
+	slots *int // оставшиеся слоты, защищены pool.mu
+}
+
+// Reserve резервирует n слотов буфера заданий.
+// Обычный SendJob не может занять эти слоты, поэтому задания, отправленные через
+// Reservation.Submit, гарантированно найдут место в очереди.
+func (p *Pool[T, R]) Reserve(n int) (Reservation[T, R], error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n <= 0 {
+		return Reservation[T, R]{}, fmt.Errorf("reservation size must be positive, got %d", n)
+	}
+	if free := p.bufferSize - p.queuedLocked() - p.reserved; n > free {
+		return Reservation[T, R]{}, fmt.Errorf("cannot reserve %d slots: only %d free", n, free)
+	}
+	p.reserved += n
+	return Reservation[T, R]{pool: p, slots: &n}, nil
+}
+
+// Submit помещает задание в очередь, используя один из зарезервированных слотов, и возвращает
+// его Future. Задание проходит тот же путь, что и отправленное через Pool.Submit: хуки, журнал
+// WithPersistence, трассировку, WithMemoryGuard и WithCapacity; резервирование гарантирует
+// только место в очереди.
+func (r Reservation[T, R]) Submit(job T) (*Future[R], error) {
+	if r.pool == nil {
+		return nil, fmt.Errorf("reservation is empty")
+	}
+	t := &task[T, R]{job: job, cost: 1, reserved: r.slots, future: newFuture[R]()}
+	if err := r.pool.enqueue(t); err != nil {
+		return nil, err
+	}
+	return t.future, nil
+}
+
+// Release возвращает неиспользованные слоты обратно в общую очередь.
+func (r Reservation[T, R]) Release() {
+	if r.pool == nil {
+		return
+	}
+	r.pool.mu.Lock()
+	defer r.pool.mu.Unlock()
+
+	r.pool.reserved -= *r.slots
+	*r.slots = 0
+	r.pool.signalSpaceLocked()
+}
@@ -0,0 +1,94 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestReservationSurvivesFullQueue(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]), WithBufferSize(5))
+	defer pool.Shutdown(context.Background())
+
+	r, err := pool.Reserve(2)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob %d: %v", i, err)
+		}
+	}
+	if err := pool.SendJob(3); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("SendJob into reserved slots = %v, want ErrQueueFull", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := r.Submit(10 + i); err != nil {
+			t.Fatalf("reserved Submit %d: %v", i, err)
+		}
+	}
+	if _, err := r.Submit(12); err == nil {
+		t.Fatal("Submit beyond the reservation succeeded")
+	}
+	if n := pool.QueueLen(); n != 5 {
+		t.Errorf("QueueLen = %d, want 5", n)
+	}
+}
+
+func TestReservationRelease(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]), WithBufferSize(2))
+	defer pool.Shutdown(context.Background())
+
+	r, err := pool.Reserve(2)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	if _, err := pool.Reserve(1); err == nil {
+		t.Fatal("Reserve beyond the free slots succeeded")
+	}
+	if err := pool.SendJob(1); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("SendJob with all slots reserved = %v, want ErrQueueFull", err)
+	}
+
+	r.Release()
+	for i := 0; i < 2; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob after Release: %v", err)
+		}
+	}
+}
+
+func TestReservedJobIsJournaledAndReturnsFuture(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.wal")
+	fp, err := OpenFilePersistence(path, false)
+	if err != nil {
+		t.Fatalf("OpenFilePersistence: %v", err)
+	}
+	defer fp.Close()
+	pool := NewPool[int, int](WithHandler(echo[int]), WithBufferSize(1), WithPersistence(fp))
+	defer pool.Shutdown(context.Background())
+
+	r, err := pool.Reserve(1)
+	if err != nil {
+		t.Fatalf("Reserve: %v", err)
+	}
+	future, err := r.Submit(7)
+	if err != nil {
+		t.Fatalf("reserved Submit: %v", err)
+	}
+	// Задание в зарезервированном слоте журналируется, как любое другое
+	if jobs, err := fp.Load(); err != nil || len(jobs) != 1 {
+		t.Fatalf("journal = %d jobs, %v; want 1", len(jobs), err)
+	}
+
+	pool.AddWorker()
+	if value, err := await(t, future); err != nil || value != 7 {
+		t.Errorf("reserved job result = %d, %v", value, err)
+	}
+	eventually(t, "journal emptied", func() bool {
+		jobs, err := fp.Load()
+		return err == nil && len(jobs) == 0
+	})
+}
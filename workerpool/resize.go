@@ -0,0 +1,76 @@
+package workerpool
+
+import (
+	"fmt"
+)
+
+// Resize меняет вместимость очереди заданий на ходу; уже поставленные задания сохраняются.
+// Уменьшить очередь можно не меньше, чем до числа ждущих в ней и зарезервированных заданий.
+func (p *Pool[T, R]) Resize(capacity int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.acceptingLocked(); err != nil {
+		return err
+	}
+	if used := p.queuedLocked() + p.reserved; capacity < used {
+		return fmt.Errorf("cannot resize queue to %d: %d slots are in use", capacity, used)
+	}
+	if capacity == p.bufferSize {
+		return nil
+	}
+
+	// Переносим жетоны в канал новой вместимости. Воркер, успевший забрать жетон
+	// из старого канала, просто обработает своё задание
+	tokens := make(chan struct{}, capacity)
+	for moved := false; !moved; {
+		select {
+		case <-p.tokens:
+			tokens <- struct{}{}
+		default:
+			moved = true
+		}
+	}
+	p.tokens = tokens
+	p.bufferSize = capacity
+
+	// Будим воркеров, ждущих на старом канале, и отправителей, ждущих места
+	close(p.tokensChanged)
+	p.tokensChanged = make(chan struct{})
+	p.chans.Store(&tokenChannels{tokens: p.tokens, changed: p.tokensChanged})
+	p.signalSpaceLocked()
+	return nil
+}
+
+// tokenChannels — канал жетонов вместе с каналом, закрывающимся при его замене.
+type tokenChannels struct {
+	tokens  chan struct{}
+	changed chan struct{}
+}
+
+// tokenChans возвращает текущий канал жетонов и канал, закрывающийся при его замене в Resize.
+// Воркер вызывает её перед каждым заданием, поэтому обходится без p.mu.
+func (p *Pool[T, R]) tokenChans() (tokens <-chan struct{}, changed <-chan struct{}) {
+	c := p.chans.Load()
+	return c.tokens, c.changed
+}
+
+// closeTokens сообщает воркерам, что новых заданий больше не будет.
+// Пока в партициях или личных очередях воркеров есть задания, закрытие откладывается:
+// им ещё нужны жетоны, а личным очередям — воркеры, ждущие на канале.
+func (p *Pool[T, R]) closeTokens() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closing = true
+	p.closeTokensIfDrainedLocked()
+}
+
+// closeTokensIfDrainedLocked закрывает канал жетонов, запрошенное closeTokens,
+// когда отложенных заданий партиций и личных очередей воркеров не осталось. Вызывается под p.mu.
+func (p *Pool[T, R]) closeTokensIfDrainedLocked() {
+	if p.closing && !p.tokensClosed && p.backlogged == 0 && p.pinnedCount == 0 {
+		close(p.tokens)
+		p.tokensClosed = true
+	}
+}
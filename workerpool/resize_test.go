@@ -0,0 +1,87 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResize(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]), WithBufferSize(2))
+	var futures []*Future[int]
+	for i := 0; i < 2; i++ {
+		future, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		futures = append(futures, future)
+	}
+
+	// Очередь нельзя сделать меньше числа ждущих в ней заданий
+	if err := pool.Resize(1); err == nil {
+		t.Fatal("Resize below the queued jobs succeeded")
+	}
+	if err := pool.Resize(4); err != nil {
+		t.Fatalf("Resize: %v", err)
+	}
+	for i := 2; i < 4; i++ {
+		future, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("Submit after growing the queue: %v", err)
+		}
+		futures = append(futures, future)
+	}
+	if _, err := pool.Submit(4); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("Submit over the new capacity = %v, want ErrQueueFull", err)
+	}
+
+	// Задания, поставленные до и после Resize, обрабатываются воркерами
+	pool.AddWorker()
+	for i, future := range futures {
+		if got, err := await(t, future); err != nil || got != i {
+			t.Errorf("job %d result = %d, %v", i, got, err)
+		}
+	}
+	if err := pool.Resize(1); err != nil {
+		t.Fatalf("shrinking an empty queue: %v", err)
+	}
+	if err := pool.SendJob(5); err != nil {
+		t.Fatalf("SendJob: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := pool.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if err := pool.Resize(8); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Resize after Shutdown = %v, want ErrPoolClosed", err)
+	}
+}
+
+func TestResizeWakesBlockedSender(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]), WithBufferSize(1))
+	defer pool.ShutdownNow()
+	if err := pool.SendJob(1); err != nil {
+		t.Fatalf("SendJob: %v", err)
+	}
+	sent := make(chan error, 1)
+	go func() { sent <- pool.SendJobContext(context.Background(), 2) }()
+	time.Sleep(10 * time.Millisecond)
+
+	if err := pool.Resize(2); err != nil {
+		t.Fatalf("Resize: %v", err)
+	}
+	select {
+	case err := <-sent:
+		if err != nil {
+			t.Fatalf("SendJobContext: %v", err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("sender waiting for space was not woken by Resize")
+	}
+	if n := pool.QueueLen(); n != 2 {
+		t.Errorf("QueueLen = %d, want 2", n)
+	}
+}
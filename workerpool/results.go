@@ -0,0 +1,146 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+)
+
+// WithResultStream включает поток результатов Results с буфером на buffer результатов.
+// Когда буфер заполнен, воркеры ждут читателя, поэтому медленный потребитель
+// сдерживает пул, а не копит результаты в памяти.
+func WithResultStream(buffer int) Option {
+	return func(c *config) {
+		c.resultStream = true
+		if buffer > 0 {
+			c.resultBuffer = buffer
+		}
+	}
+}
+
+// WithOrderedResults включает поток Results, как WithResultStream, но отдаёт результаты
+// в порядке приёма заданий, а не завершения: результат, готовый раньше предыдущих,
+// придерживается до их завершения. Приоритеты, партиции и арендаторы на порядок потока не влияют.
+func WithOrderedResults() Option {
+	return func(c *config) {
+		c.resultStream = true
+		c.orderedResults = true
+	}
+}
+
+// Results возвращает поток результатов всех принятых заданий, чтобы пул мог быть
+// промежуточной стадией конвейера: следующий пул или потребитель читает результаты по мере готовности.
+// Для отменённых (Cancel) и отброшенных при остановке заданий в поток попадает результат
+// с соответствующей ошибкой, так что на каждое принятое задание приходится ровно один результат.
+// Канал закрывается по завершении Shutdown или ShutdownNow; читать его нужно до закрытия,
+// иначе остановка будет ждать читателя. Без WithResultStream или WithOrderedResults возвращает nil.
+func (p *Pool[T, R]) Results() <-chan Result[T, R] {
+	return p.results
+}
+
+// publishResult отправляет результат задания t в поток Results.
+func (p *Pool[T, R]) publishResult(t *task[T, R], value R, err error) {
+	if p.results == nil {
+		return
+	}
+	res := Result[T, R]{ID: t.id, Job: t.job, Value: value, Err: err}
+
+	p.resultMu.Lock()
+	defer p.resultMu.Unlock()
+
+	if !p.orderedResults {
+		p.results <- res
+		return
+	}
+	// Задания нумеруются подряд с 1, поэтому следующий по порядку результат — nextResult
+	if p.heldResults == nil {
+		p.heldResults = make(map[JobID]Result[T, R])
+	}
+	p.heldResults[res.ID] = res
+	for {
+		next, ready := p.heldResults[p.nextResult+1]
+		if !ready {
+			return
+		}
+		delete(p.heldResults, next.ID)
+		p.nextResult = next.ID
+		p.results <- next
+	}
+}
+
+// WaitResults читает из потока Results, пока не наберёт n результатов, и возвращает их
+// в порядке поступления. Если ctx истёк раньше, возвращаются уже собранные результаты
+// и ошибка ctx (context.DeadlineExceeded при таймауте); если поток закрылся из-за остановки
+// пула — собранные результаты и ErrPoolClosed. Подходит для scatter-gather со сроком:
+// отправить задания и собрать столько результатов, сколько успеет прийти.
+// Результаты, прочитанные WaitResults, другие читатели Results уже не получат.
+func (p *Pool[T, R]) WaitResults(ctx context.Context, n int) ([]Result[T, R], error) {
+	if p.results == nil {
+		return nil, errors.New("WaitResults requires WithResultStream or WithOrderedResults")
+	}
+	collected := make([]Result[T, R], 0, n)
+	for len(collected) < n {
+		select {
+		case res, ok := <-p.results:
+			if !ok {
+				return collected, ErrPoolClosed
+			}
+			collected = append(collected, res)
+		case <-ctx.Done():
+			return collected, ctx.Err()
+		}
+	}
+	return collected, nil
+}
+
+// ProcessUntilIdle читает поток Results, вызывая fn для каждого результата, и возвращает nil,
+// когда в пуле не останется заданий в очереди и в работе, а их результаты будут прочитаны.
+// Так оформляется обычный цикл «отправить пакет заданий и обработать все ответы».
+// Возвращает ошибку ctx, если тот истёк раньше, и ErrPoolClosed, если поток закрылся
+// из-за остановки пула. fn вызывается в вызывающей горутине.
+func (p *Pool[T, R]) ProcessUntilIdle(ctx context.Context, fn func(Result[T, R])) error {
+	if p.results == nil {
+		return errors.New("ProcessUntilIdle requires WithResultStream or WithOrderedResults")
+	}
+	for {
+		// Результат публикуется до того, как задание перестаёт считаться незавершённым,
+		// поэтому к простою все результаты уже лежат в потоке
+		idle := p.drainedChan()
+		if idle == nil {
+			for {
+				select {
+				case res, ok := <-p.results:
+					if !ok {
+						return ErrPoolClosed
+					}
+					fn(res)
+				default:
+					return nil
+				}
+			}
+		}
+		for idle != nil {
+			select {
+			case res, ok := <-p.results:
+				if !ok {
+					return ErrPoolClosed
+				}
+				fn(res)
+			case <-idle:
+				idle = nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// closeResults закрывает поток Results после остановки пула.
+func (p *Pool[T, R]) closeResults() {
+	if p.results == nil {
+		return
+	}
+	p.resultMu.Lock()
+	defer p.resultMu.Unlock()
+
+	close(p.results)
+}
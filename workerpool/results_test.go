@@ -0,0 +1,177 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitResultsCollectsAll(t *testing.T) {
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			return job * job, nil
+		}),
+		WithInitialWorkers(2),
+		WithResultStream(8),
+	)
+	defer pool.Shutdown(context.Background())
+
+	for i := 1; i <= 5; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	results, err := pool.WaitResults(ctx, 5)
+	if err != nil {
+		t.Fatalf("WaitResults: %v", err)
+	}
+	seen := make(map[int]bool)
+	for _, res := range results {
+		if res.Err != nil || res.Value != res.Job*res.Job {
+			t.Errorf("result for job %d = %d, %v", res.Job, res.Value, res.Err)
+		}
+		seen[res.Job] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("got results for %d distinct jobs, want 5: %+v", len(seen), results)
+	}
+}
+
+func TestWaitResultsDeadline(t *testing.T) {
+	release := make(chan struct{})
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			// Нечётные задания задерживаются дольше срока сбора
+			if job%2 == 1 {
+				<-release
+			}
+			return job, nil
+		}),
+		WithInitialWorkers(5),
+		WithResultStream(8),
+	)
+	defer pool.Shutdown(context.Background())
+	defer close(release)
+
+	for i := 0; i < 5; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	results, err := pool.WaitResults(ctx, 5)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitResults error = %v, want context.DeadlineExceeded", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d partial results, want 3: %+v", len(results), results)
+	}
+	for _, res := range results {
+		if res.Job%2 != 0 {
+			t.Errorf("unexpected result for delayed job %d", res.Job)
+		}
+	}
+}
+
+func TestWaitResultsWithoutStream(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]))
+	defer pool.Shutdown(context.Background())
+
+	if _, err := pool.WaitResults(context.Background(), 1); err == nil {
+		t.Fatal("WaitResults without a result stream returned no error")
+	}
+}
+
+func TestProcessUntilIdle(t *testing.T) {
+	const jobs = 50
+	pool := NewPool[int, int](
+		WithHandler(echo[int]),
+		WithInitialWorkers(4),
+		// Буфер меньше пакета: воркеры ждут, пока ProcessUntilIdle читает результаты
+		WithResultStream(4),
+	)
+	defer pool.Shutdown(context.Background())
+
+	for i := 0; i < jobs; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	calls := make(map[int]int)
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := pool.ProcessUntilIdle(ctx, func(res Result[int, int]) { calls[res.Job]++ }); err != nil {
+		t.Fatalf("ProcessUntilIdle: %v", err)
+	}
+	for i := 0; i < jobs; i++ {
+		if calls[i] != 1 {
+			t.Errorf("fn called %d times for job %d, want 1", calls[i], i)
+		}
+	}
+	if len(calls) != jobs {
+		t.Errorf("fn called for %d jobs, want %d", len(calls), jobs)
+	}
+	select {
+	case res := <-pool.Results():
+		t.Errorf("result for job %d left unread", res.Job)
+	default:
+	}
+}
+
+func TestOrderedResults(t *testing.T) {
+	const jobs = 6
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			// Ранние задания выполняются дольше поздних
+			time.Sleep(time.Duration(jobs-job) * 2 * time.Millisecond)
+			return job * 10, nil
+		}),
+		WithInitialWorkers(jobs),
+		WithOrderedResults(),
+	)
+	var ids []JobID
+	for i := 0; i < jobs; i++ {
+		future, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		ids = append(ids, future.ID())
+	}
+	// Отменённое в очереди задание тоже получает результат на своём месте
+	pool.Pause()
+	cancelled, err := pool.Submit(jobs)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	pool.Cancel(cancelled.ID())
+	pool.Resume()
+
+	for i := 0; i <= jobs; i++ {
+		select {
+		case res := <-pool.Results():
+			if res.Job != i {
+				t.Fatalf("result %d is for job %d, want results in submission order", i, res.Job)
+			}
+			if i < jobs && (res.ID != ids[i] || res.Value != i*10 || res.Err != nil) {
+				t.Errorf("result %d = %+v", i, res)
+			}
+			if i == jobs && !errors.Is(res.Err, context.Canceled) {
+				t.Errorf("cancelled job result error = %v, want context.Canceled", res.Err)
+			}
+		case <-time.After(testTimeout):
+			t.Fatalf("result %d did not arrive", i)
+		}
+	}
+
+	// Поток закрывается после остановки пула
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if _, open := <-pool.Results(); open {
+		t.Error("Results still open after Shutdown")
+	}
+}
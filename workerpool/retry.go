@@ -0,0 +1,135 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy описывает повтор заданий, обработчик которых вернул ошибку.
+type RetryPolicy struct {
+	// MaxAttempts — общее число попыток, включая первую (меньше 2 — без повторов)
+	MaxAttempts int
+
+	// Backoff возвращает паузу перед попыткой attempt+1 (attempt начинается с 1).
+	// nil — ExponentialBackoff(100ms, 10s).
+	Backoff func(attempt int) time.Duration
+
+	// Jitter — доля случайного разброса паузы, от 0 до 1: при 0.2 пауза меняется в пределах ±20%
+	Jitter float64
+
+	// Retryable решает, стоит ли повторять задание после ошибки. nil — повторять любую ошибку,
+	// кроме отмены контекста задания.
+	Retryable func(err error) bool
+
+	// OnExhausted вызывается, когда задание завершилось ошибкой после всех попыток
+	// или ошибка признана неповторяемой.
+	OnExhausted func(job any, err error, attempts int)
+}
+
+// WithRetry включает автоматический повтор упавших заданий по правилам policy.
+// Повторы выполняются тем же воркером; на время паузы он не берёт новых заданий.
+// Повторы не выходят за срок задания (SubmitWithTimeout, срок контекста SubmitContext):
+// если пауза перед очередной попыткой не укладывается в оставшееся время, задание сразу
+// завершается ошибкой, оборачивающей context.DeadlineExceeded и последнюю ошибку обработчика.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *config) {
+		c.retry = &policy
+	}
+}
+
+// ExponentialBackoff возвращает паузы base, 2*base, 4*base... не больше max.
+func ExponentialBackoff(base, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		return d
+	}
+}
+
+// callWithRetry вызывает обработчик, повторяя его согласно политике повторов пула.
+// Возвращает также число сделанных попыток.
+func (p *Pool[T, R]) callWithRetry(ctx context.Context, t *task[T, R]) (R, int, error) {
+	job := t.job
+	policy := p.retry
+	value, err := p.callLimited(withJobInfo(ctx, t, 1), job)
+	if policy == nil || !failed(err) {
+		return value, 1, err
+	}
+
+	attempt := 1
+	for failed(err) && attempt < policy.MaxAttempts && policy.retryable(ctx, err) {
+		delay := policy.delay(attempt)
+		// Повтор после срока задания уже никому не нужен: если пауза в оставшееся время
+		// не укладывается, задание завершается сразу, не занимая воркера ожиданием
+		if deadline, ok := ctx.Deadline(); ok && !p.clock.Now().Add(delay).Before(deadline) {
+			return value, attempt, fmt.Errorf("no time left to retry before job deadline: %w (last error: %w)", context.DeadlineExceeded, err)
+		}
+		p.logger.Info("retrying job", "job", job, "attempt", attempt, "max_attempts", policy.MaxAttempts, "delay", delay, "error", err)
+		p.emit(EventRetried, t, attempt, err)
+
+		timer := p.clock.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return value, attempt, ctx.Err()
+		case <-timer.C():
+		}
+		// Пользовательский Retryable не смотрит на контекст, поэтому он проверяется перед каждой попыткой
+		if err := ctx.Err(); err != nil {
+			return value, attempt, err
+		}
+
+		attempt++
+		value, err = p.callLimited(withJobInfo(ctx, t, attempt), job)
+	}
+	if failed(err) && policy.OnExhausted != nil {
+		policy.OnExhausted(job, err, attempt)
+	}
+	return value, attempt, err
+}
+
+// callLimited — одна попытка обработки задания с учётом WithRateLimit.
+func (p *Pool[T, R]) callLimited(ctx context.Context, job T) (R, error) {
+	if p.limiter != nil {
+		if err := p.limiter.wait(ctx); err != nil {
+			return *new(R), err
+		}
+	}
+	return p.call(ctx, job)
+}
+
+func (rp *RetryPolicy) retryable(ctx context.Context, err error) bool {
+	if rp.Retryable != nil {
+		return rp.Retryable(err)
+	}
+	// Отменённое или просроченное задание повторять бессмысленно
+	return ctx.Err() == nil
+}
+
+func (rp *RetryPolicy) delay(attempt int) time.Duration {
+	backoff := rp.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff(100*time.Millisecond, 10*time.Second)
+	}
+	d := backoff(attempt)
+	if rp.Jitter > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * rp.Jitter * float64(d))
+	}
+	if d < 0 {
+		d = 0
+	}
+	return d
+}
+
+// failed сообщает, завершилась ли попытка ошибкой: ErrNoOp ошибкой не считается.
+func failed(err error) bool {
+	return err != nil && !errors.Is(err, ErrNoOp)
+}
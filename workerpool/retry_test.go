@@ -0,0 +1,157 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetriesStopAtJobDeadline(t *testing.T) {
+	errBoom := errors.New("boom")
+	for _, tc := range []struct {
+		name     string
+		backoff  time.Duration
+		maxCalls int32
+	}{
+		// Пауза длиннее оставшегося срока: повтора не будет вовсе
+		{name: "backoff beyond deadline", backoff: time.Hour, maxCalls: 1},
+		// Короткие паузы: повторы идут, пока не кончится срок
+		{name: "short backoff", backoff: 10 * time.Millisecond, maxCalls: 20},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			pool := NewPool[int, int](
+				WithHandler(func(ctx context.Context, job int) (int, error) {
+					calls.Add(1)
+					return 0, errBoom
+				}),
+				WithRetry(RetryPolicy{
+					MaxAttempts: 1000,
+					Backoff:     func(int) time.Duration { return tc.backoff },
+					// Повторять любую ошибку: срок должен соблюдаться и без проверки контекста в Retryable
+					Retryable: func(error) bool { return true },
+				}),
+				WithInitialWorkers(1),
+			)
+			defer pool.Shutdown(context.Background())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			future, err := pool.SubmitContext(ctx, 1)
+			if err != nil {
+				t.Fatalf("SubmitContext: %v", err)
+			}
+			_, err = await(t, future)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("job error = %v, want context.DeadlineExceeded", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("retries ran for %v after a 100ms deadline", elapsed)
+			}
+			if n := calls.Load(); n < 1 || n > tc.maxCalls {
+				t.Errorf("handler called %d times, want 1..%d", n, tc.maxCalls)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+	for attempt, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		if got := backoff(attempt); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestRetryUntilSuccess(t *testing.T) {
+	errFlaky := errors.New("flaky")
+	var calls atomic.Int32
+	var pauses []int
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			if calls.Add(1) < 3 {
+				return 0, errFlaky
+			}
+			info, _ := JobInfo(ctx)
+			return info.Attempt, nil
+		}),
+		WithRetry(RetryPolicy{
+			MaxAttempts: 5,
+			// Пауза запоминает номер попытки и почти не тратит времени
+			Backoff: func(attempt int) time.Duration {
+				pauses = append(pauses, attempt)
+				return time.Microsecond
+			},
+		}),
+		WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	future, err := pool.Submit(1)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	attempt, err := await(t, future)
+	if err != nil {
+		t.Fatalf("job failed after retries: %v", err)
+	}
+	if attempt != 3 || calls.Load() != 3 {
+		t.Errorf("succeeded on attempt %d after %d calls, want 3 and 3", attempt, calls.Load())
+	}
+	if len(pauses) != 2 || pauses[0] != 1 || pauses[1] != 2 {
+		t.Errorf("backoff called for attempts %v, want [1 2]", pauses)
+	}
+}
+
+func TestRetryExhaustedAndNonRetryable(t *testing.T) {
+	errFatal := errors.New("fatal")
+	errFlaky := errors.New("flaky")
+	exhausted := make(chan int, 2)
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			if job == "fatal" {
+				return "", errFatal
+			}
+			return "", errFlaky
+		}),
+		WithRetry(RetryPolicy{
+			MaxAttempts: 3,
+			Backoff:     func(int) time.Duration { return time.Microsecond },
+			Retryable:   func(err error) bool { return !errors.Is(err, errFatal) },
+			OnExhausted: func(job any, err error, attempts int) { exhausted <- attempts },
+		}),
+		WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	for _, tc := range []struct {
+		job      string
+		err      error
+		attempts int
+	}{
+		{"flaky", errFlaky, 3}, // все попытки исчерпаны
+		{"fatal", errFatal, 1}, // неповторяемая ошибка — без повторов
+	} {
+		future, err := pool.Submit(tc.job)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		if _, err := await(t, future); !errors.Is(err, tc.err) {
+			t.Errorf("%s job error = %v, want %v", tc.job, err, tc.err)
+		}
+		if attempts := <-exhausted; attempts != tc.attempts {
+			t.Errorf("%s job exhausted after %d attempts, want %d", tc.job, attempts, tc.attempts)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package workerpool
+
+import (
+	"context"
+	"time"
+)
+
+// WithFailFast включает режим остановки на первой ошибке, как у errgroup: после первой
+// ошибки обработчика пул перестаёт принимать задания (отправка возвращает ErrFailedFast),
+// а Run останавливает пул и возвращает эту ошибку. Уже принятые задания дообрабатываются.
+func WithFailFast() Option {
+	return func(c *config) {
+		c.failFast = true
+	}
+}
+
+// WithShutdownTimeout ограничивает время, которое Run и Close дают пулу на
+// дообработку очереди при остановке (по умолчанию — без ограничения).
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.shutdownTimeout = d
+	}
+}
+
+// Run запускает воркеров до числа из WithInitialWorkers (если их меньше), блокируется
+// до отмены ctx, а затем мягко останавливает пул через Shutdown. С WithFailFast пул
+// останавливается и на первой ошибке обработчика, и Run возвращает эту ошибку.
+// Отмена ctx ошибкой не считается, поэтому Run удобно запускать в errgroup.Group:
+//
+//	g.Go(func() error { return pool.Run(ctx) })
+func (p *Pool[T, R]) Run(ctx context.Context) error {
+	p.mu.Lock()
+	missing := p.initialWorkers - p.liveWorkersLocked()
+	p.mu.Unlock()
+	for i := 0; i < missing; i++ {
+		p.AddWorker()
+	}
+
+	var handlerErr error
+	select {
+	case <-ctx.Done():
+	case <-p.failed:
+		handlerErr = p.firstError()
+	case <-p.done:
+		// Пул остановили снаружи
+		return nil
+	}
+
+	if err := p.shutdownWithTimeout(); err != nil && handlerErr == nil {
+		return err
+	}
+	return handlerErr
+}
+
+// Close останавливает пул через Shutdown с ограничением WithShutdownTimeout.
+// Вместе с Run позволяет использовать пул как io.Closer.
+func (p *Pool[T, R]) Close() error {
+	return p.shutdownWithTimeout()
+}
+
+func (p *Pool[T, R]) shutdownWithTimeout() error {
+	ctx := context.Background()
+	if p.shutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.shutdownTimeout)
+		defer cancel()
+	}
+	return p.Shutdown(ctx)
+}
+
+func (p *Pool[T, R]) firstError() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.failErr
+}
@@ -0,0 +1,78 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunStopsOnContextCancel(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]), WithInitialWorkers(2))
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := make(chan error, 1)
+	go func() { ran <- pool.Run(ctx) }()
+
+	future, err := pool.Submit(1)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	await(t, future)
+	if n := pool.Stats().Workers; n != 2 {
+		t.Errorf("Workers = %d, want 2 from WithInitialWorkers", n)
+	}
+
+	// Отмена ctx останавливает пул, но ошибкой Run не считается
+	cancel()
+	select {
+	case err := <-ran:
+		if err != nil {
+			t.Fatalf("Run after cancel = %v, want nil", err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("Run did not return after ctx was canceled")
+	}
+	if err := pool.SendJob(2); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("SendJob after Run = %v, want ErrPoolClosed", err)
+	}
+}
+
+func TestRunFailFast(t *testing.T) {
+	boom := errors.New("boom")
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		if job < 0 {
+			return 0, boom
+		}
+		return job, nil
+	}), WithFailFast(), WithInitialWorkers(1))
+	ran := make(chan error, 1)
+	go func() { ran <- pool.Run(context.Background()) }()
+
+	if err := pool.SendJob(-1); err != nil {
+		t.Fatalf("SendJob: %v", err)
+	}
+	select {
+	case err := <-ran:
+		if !errors.Is(err, boom) {
+			t.Fatalf("Run = %v, want the handler error", err)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("Run did not return after the handler failed")
+	}
+	if err := pool.SendJob(1); err == nil {
+		t.Error("SendJob after a fail-fast error succeeded")
+	}
+}
+
+func TestCloseHonorsShutdownTimeout(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}), WithInitialWorkers(1), WithShutdownTimeout(20*time.Millisecond))
+	if err := pool.SendJob(1); err != nil {
+		t.Fatalf("SendJob: %v", err)
+	}
+	if err := pool.Close(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Close with a stuck job = %v, want DeadlineExceeded", err)
+	}
+}
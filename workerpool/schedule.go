@@ -0,0 +1,230 @@
+package workerpool
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SendJobAfter ставит задание в очередь через delay. Ошибка возвращается сразу,
+// только если пул уже не принимает задания; если в момент срабатывания задание
+// не удалось поставить в очередь, ошибка пишется в лог, а при переполнении задание
+// уходит обработчику недоставленных (WithDeadLetter). Задания, время которых
+// наступает после начала остановки пула, молча отбрасываются.
+func (p *Pool[T, R]) SendJobAfter(job T, delay time.Duration) error {
+	p.mu.Lock()
+	err := p.acceptingLocked()
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	p.clock.AfterFunc(delay, func() {
+		p.sendScheduled(job)
+	})
+	return nil
+}
+
+// SendJobAt ставит задание в очередь в момент at. Момент в прошлом означает «сразу».
+func (p *Pool[T, R]) SendJobAt(job T, at time.Time) error {
+	return p.SendJobAfter(job, at.Sub(p.clock.Now()))
+}
+
+// Schedule — периодическое задание, созданное ScheduleCron.
+type Schedule struct {
+	stop chan struct{}
+}
+
+// Stop прекращает постановку задания в очередь. Повторный вызов ничего не делает.
+func (s *Schedule) Stop() {
+	select {
+	case <-s.stop:
+	default:
+		close(s.stop)
+	}
+}
+
+// ScheduleCron ставит job в очередь по расписанию в формате cron (см. ParseCron),
+// пока не будет вызван Schedule.Stop или пул не начнёт останавливаться.
+// Время расписания считается в локальной зоне процесса.
+func (p *Pool[T, R]) ScheduleCron(spec string, job T) (*Schedule, error) {
+	cron, err := ParseCron(spec)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	err = p.acceptingLocked()
+	p.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Schedule{stop: make(chan struct{})}
+	go func() {
+		for {
+			next := cron.Next(p.clock.Now())
+			if next.IsZero() {
+				return
+			}
+
+			timer := p.clock.NewTimer(next.Sub(p.clock.Now()))
+			select {
+			case <-timer.C():
+				p.sendScheduled(job)
+			case <-s.stop:
+				timer.Stop()
+				return
+			case <-p.done:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+	return s, nil
+}
+
+// sendScheduled ставит в очередь задание, время которого подошло.
+func (p *Pool[T, R]) sendScheduled(job T) {
+	select {
+	case <-p.done:
+		return
+	default:
+	}
+	if err := p.enqueue(&task[T, R]{job: job, cost: 1}); err != nil {
+		p.logger.Warn("scheduled job rejected", "job", job, "error", err)
+	}
+}
+
+// CronSchedule — разобранное расписание cron.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64 // битовые маски допустимых значений
+
+	// anyDom и anyDow — поле задано как «*»: cron объединяет день месяца и день недели
+	// по «или», только если оба ограничены
+	anyDom, anyDow bool
+}
+
+// ParseCron разбирает расписание из пяти полей «минута час день-месяца месяц день-недели».
+// Поддерживаются «*», списки через запятую, диапазоны «a-b» и шаг «/n»,
+// а также сокращения @hourly, @daily, @weekly, @monthly и @yearly.
+// День недели задаётся числом от 0 (воскресенье) до 6; 7 тоже означает воскресенье.
+func ParseCron(spec string) (CronSchedule, error) {
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily", "@midnight":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	case "@yearly", "@annually":
+		spec = "0 0 1 1 *"
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("cron spec %q: expected 5 fields", spec)
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	var masks [5]uint64
+	for i, field := range fields {
+		mask, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return CronSchedule{}, fmt.Errorf("cron spec %q: %w", spec, err)
+		}
+		masks[i] = mask
+	}
+	// Воскресенье можно записать и как 7
+	if masks[4]&(1<<7) != 0 {
+		masks[4] |= 1
+	}
+
+	return CronSchedule{
+		minute: masks[0],
+		hour:   masks[1],
+		dom:    masks[2],
+		month:  masks[3],
+		dow:    masks[4],
+		anyDom: fields[2] == "*",
+		anyDow: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField разбирает одно поле расписания в битовую маску значений от min до max.
+func parseCronField(field string, min, max int) (uint64, error) {
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		lo, hi, step := min, max, 1
+
+		rangePart := part
+		if before, after, found := strings.Cut(part, "/"); found {
+			rangePart = before
+			if _, err := fmt.Sscanf(after, "%d", &step); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			if _, err := fmt.Sscanf(rangePart, "%d-%d", &lo, &hi); err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			if _, err := fmt.Sscanf(rangePart, "%d", &lo); err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			// «5/15» означает «с 5 до конца с шагом 15»
+			if rangePart == part {
+				hi = lo
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("value %q out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+// Next возвращает ближайший момент расписания строго после t
+// или нулевое время, если его нет в ближайшие пять лет (например, для 30 февраля).
+func (c CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c CronSchedule) dayMatches(t time.Time) bool {
+	domOK := c.dom&(1<<uint(t.Day())) != 0
+	dowOK := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.anyDom || c.anyDow {
+		return domOK && dowOK
+	}
+	return domOK || dowOK
+}
@@ -0,0 +1,136 @@
+package workerpool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestParseCron(t *testing.T) {
+	// 6 мая 2024 года — понедельник
+	monday := time.Date(2024, 5, 6, 17, 50, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"*/15 9-17 * * 1-5", monday, time.Date(2024, 5, 7, 9, 0, 0, 0, time.UTC)},
+		{"*/15 9-17 * * 1-5", time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC), time.Date(2024, 5, 6, 9, 15, 0, 0, time.UTC)},
+		{"0 12 * * 0", monday, time.Date(2024, 5, 12, 12, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", monday, time.Date(2024, 5, 12, 12, 0, 0, 0, time.UTC)},
+		{"30 8 1,15 * *", monday, time.Date(2024, 5, 15, 8, 30, 0, 0, time.UTC)},
+		{"@daily", monday, time.Date(2024, 5, 7, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", monday, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", monday, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", monday, time.Time{}},
+	}
+	for _, tt := range tests {
+		cron, err := workerpool.ParseCron(tt.spec)
+		if err != nil {
+			t.Errorf("ParseCron(%q): %v", tt.spec, err)
+			continue
+		}
+		if got := cron.Next(tt.from); !got.Equal(tt.want) {
+			t.Errorf("ParseCron(%q).Next(%v) = %v, want %v", tt.spec, tt.from, got, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "5-1 * * * *", "*/0 * * * *", "@sometimes"} {
+		if _, err := workerpool.ParseCron(spec); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestSendJobAfter(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := workerpooltest.NewClock(start)
+	done := make(chan int, 2)
+	pool := workerpool.NewPool[int, int](
+		workerpool.WithHandler(func(ctx context.Context, job int) (int, error) {
+			done <- job
+			return job, nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	if err := pool.SendJobAfter(1, 10*time.Second); err != nil {
+		t.Fatalf("SendJobAfter: %v", err)
+	}
+	if err := pool.SendJobAt(2, start.Add(time.Minute)); err != nil {
+		t.Fatalf("SendJobAt: %v", err)
+	}
+
+	clock.Advance(9 * time.Second)
+	time.Sleep(10 * time.Millisecond)
+	if n := len(done); n != 0 {
+		t.Fatalf("%d jobs ran before their delay", n)
+	}
+	advanceUntil(t, clock, time.Second, "delayed job", func() bool { return len(done) == 1 })
+	if job := <-done; job != 1 {
+		t.Fatalf("first job = %d, want 1", job)
+	}
+	clock.Advance(49 * time.Second)
+	time.Sleep(10 * time.Millisecond)
+	if n := len(done); n != 0 {
+		t.Fatalf("SendJobAt job ran before its time")
+	}
+	advanceUntil(t, clock, time.Second, "job at a time", func() bool { return len(done) == 1 })
+	if job := <-done; job != 2 {
+		t.Fatalf("second job = %d, want 2", job)
+	}
+}
+
+func TestScheduleCron(t *testing.T) {
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	done := make(chan int, 10)
+	pool := workerpool.NewPool[int, int](
+		workerpool.WithHandler(func(ctx context.Context, job int) (int, error) {
+			done <- job
+			return job, nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithInitialWorkers(1),
+	)
+	defer pool.Shutdown(context.Background())
+
+	if _, err := pool.ScheduleCron("every minute", 0); err == nil {
+		t.Fatal("ScheduleCron with a bad spec succeeded")
+	}
+	timers := clock.Timers()
+	schedule, err := pool.ScheduleCron("*/5 * * * *", 5)
+	if err != nil {
+		t.Fatalf("ScheduleCron: %v", err)
+	}
+
+	// Каждые пять минут по часам пула задание ставится в очередь ровно один раз
+	for i := 1; i <= 3; i++ {
+		clock.BlockUntil(timers + 1)
+		clock.Advance(4 * time.Minute)
+		time.Sleep(10 * time.Millisecond)
+		if n := len(done); n != 0 {
+			t.Fatalf("run %d happened a minute early", i)
+		}
+		clock.Advance(time.Minute)
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("scheduled job %d did not run", i)
+		}
+	}
+
+	// После Stop задания больше не ставятся
+	clock.BlockUntil(timers + 1)
+	schedule.Stop()
+	schedule.Stop()
+	clock.Advance(time.Hour)
+	time.Sleep(10 * time.Millisecond)
+	if n := len(done); n != 0 {
+		t.Fatalf("%d jobs ran after Stop", n)
+	}
+}
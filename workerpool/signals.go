@@ -0,0 +1,78 @@
+package workerpool
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// defaultSignals — сигналы для HandleSignals и RunWithSignals, если они не указаны.
+var defaultSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// HandleSignals начинает мягкую остановку пула через Close при получении одного
+// из сигналов sigs (по умолчанию os.Interrupt и SIGTERM): очередь дообрабатывается
+// в пределах WithShutdownTimeout. Повторный сигнал во время остановки прерывает выполняющиеся
+// задания, как при истечении таймаута.
+// Возвращает функцию, отменяющую подписку на сигналы.
+func (p *Pool[T, R]) HandleSignals(sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = defaultSignals
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	quit := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-ch:
+			p.logger.Info("received signal, shutting down", "signal", sig)
+		case <-quit:
+			return
+		case <-p.done:
+			return
+		}
+
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			if err := p.Close(); err != nil {
+				p.logger.Error("shutdown after signal failed", "error", err)
+			}
+		}()
+		select {
+		case sig := <-ch:
+			p.logger.Warn("received second signal, stopping immediately", "signal", sig)
+			// Остановка уже идёт, поэтому прерываем её, отменяя контексты воркеров
+			p.cancelWorkers()
+		case <-closed:
+		case <-quit:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(quit)
+		})
+	}
+}
+
+// RunWithSignals — то же, что Run, но пул останавливается и при получении одного
+// из сигналов sigs (по умолчанию os.Interrupt и SIGTERM). Так сервис на пуле
+// правильно реагирует на SIGTERM без дополнительного кода:
+//
+//	if err := pool.RunWithSignals(context.Background()); err != nil {
+//		log.Fatal(err)
+//	}
+func (p *Pool[T, R]) RunWithSignals(ctx context.Context, sigs ...os.Signal) error {
+	if len(sigs) == 0 {
+		sigs = defaultSignals
+	}
+	ctx, stop := signal.NotifyContext(ctx, sigs...)
+	defer stop()
+
+	return p.Run(ctx)
+}
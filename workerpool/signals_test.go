@@ -0,0 +1,82 @@
+//go:build unix
+
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestHandleSignals(t *testing.T) {
+	started := make(chan struct{}, 1)
+	pool := NewPool[string, string](WithHandler(func(ctx context.Context, job string) (string, error) {
+		if job == "stuck" {
+			started <- struct{}{}
+			<-ctx.Done()
+			return "", ctx.Err()
+		}
+		return job, nil
+	}), WithInitialWorkers(1))
+	stop := pool.HandleSignals(syscall.SIGUSR1)
+	defer stop()
+
+	stuck, err := pool.Submit("stuck")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	<-started
+
+	// Первый сигнал начинает мягкую остановку: новые задания не принимаются
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	eventually(t, "shutdown started", func() bool { return !pool.IsRunning() })
+	if err := pool.SendJob("late"); !errors.Is(err, ErrPoolClosed) {
+		t.Fatalf("SendJob after the signal = %v, want ErrPoolClosed", err)
+	}
+	select {
+	case <-stuck.Done():
+		t.Fatal("running job interrupted by the first signal")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Второй сигнал прерывает выполняющееся задание
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	if _, err := await(t, stuck); !errors.Is(err, context.Canceled) {
+		t.Errorf("running job after the second signal = %v, want context.Canceled", err)
+	}
+	eventually(t, "pool closed", func() bool { return pool.State() == Closed })
+}
+
+func TestRunWithSignals(t *testing.T) {
+	// Собственная подписка не даёт сигналу завершить процесс, пока Run на него не подписался
+	caught := make(chan os.Signal, 1)
+	signal.Notify(caught, syscall.SIGUSR2)
+	defer signal.Stop(caught)
+
+	pool := NewPool[int, int](WithHandler(echo[int]), WithInitialWorkers(1))
+	ran := make(chan error, 1)
+	go func() { ran <- pool.RunWithSignals(context.Background(), syscall.SIGUSR2) }()
+
+	// Момент подписки снаружи не виден, поэтому сигнал повторяется, пока Run не вернётся
+	deadline := time.After(testTimeout)
+	for {
+		syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+		select {
+		case err := <-ran:
+			if err != nil {
+				t.Fatalf("RunWithSignals = %v, want nil", err)
+			}
+			if pool.State() != Closed {
+				t.Errorf("state after RunWithSignals = %v, want Closed", pool.State())
+			}
+			return
+		case <-deadline:
+			t.Fatal("RunWithSignals did not return after the signal")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package workerpool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidSnapshot — данные Restore не являются снимком Snapshot или повреждены.
+var ErrInvalidSnapshot = errors.New("invalid queue snapshot")
+
+// snapshotVersion — версия формата Snapshot.
+const snapshotVersion = 1
+
+// queueSnapshot — формат Snapshot: задания закодированы кодеком WithCodec,
+// а параметры отправки сохранены рядом с ними.
+type queueSnapshot struct {
+	Version int           `json:"version"`
+	Jobs    []snapshotJob `json:"jobs"`
+}
+
+type snapshotJob struct {
+	Data      []byte            `json:"data"`
+	Type      string            `json:"type,omitempty"`
+	Priority  int               `json:"priority,omitempty"`
+	Cost      int               `json:"cost"`
+	Weight    int               `json:"weight,omitempty"`
+	Key       string            `json:"key,omitempty"`
+	Tenant    string            `json:"tenant,omitempty"`
+	Partition string            `json:"partition,omitempty"`
+	Tags      []string          `json:"tags,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// Snapshot сериализует задания, ждущие в очереди и ещё не начавшие выполняться,
+// вместе с их типом, приоритетом, арендатором, партицией, тегами и метаданными.
+// Snapshot не убирает задания из очереди: чтобы они не выполнились дважды, перед снимком
+// пул ставят на паузу (Pause), а после — останавливают через ShutdownNow.
+// Привязка к воркеру из SubmitToWorker, обработчики SubmitWith и Future заданий в снимок не попадают.
+func (p *Pool[T, R]) Snapshot() ([]byte, error) {
+	p.mu.Lock()
+	tasks := p.pendingTasksLocked()
+	snap := queueSnapshot{Version: snapshotVersion, Jobs: make([]snapshotJob, 0, len(tasks))}
+	for _, t := range tasks {
+		snap.Jobs = append(snap.Jobs, snapshotJob{
+			Type:      t.kind,
+			Priority:  t.priority,
+			Cost:      t.cost,
+			Weight:    t.weight,
+			Key:       t.key,
+			Tenant:    t.tenant,
+			Partition: t.partition,
+			Tags:      t.tags,
+			Metadata:  t.metadata,
+		})
+	}
+	p.mu.Unlock()
+
+	for i, t := range tasks {
+		data, err := p.codec.Encode(&t.job)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot job %d: %w", t.id, err)
+		}
+		snap.Jobs[i].Data = data
+	}
+	return json.Marshal(snap)
+}
+
+// Restore ставит в очередь задания из снимка Snapshot, сохраняя их порядок и параметры отправки.
+// Снимок целиком проверяется до постановки первого задания, поэтому повреждённый снимок
+// или задание, которое некому обработать, не приводят к частичному восстановлению.
+// Как и SendJobContext, Restore ждёт места в очереди; ожидание прерывает остановка пула,
+// и тогда возвращается ошибка с числом уже восстановленных заданий.
+func (p *Pool[T, R]) Restore(data []byte) error {
+	var snap queueSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if snap.Version != snapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, snap.Version)
+	}
+
+	tasks := make([]*task[T, R], len(snap.Jobs))
+	for i, sj := range snap.Jobs {
+		if sj.Type != "" && p.namedHandler(sj.Type) == nil {
+			return fmt.Errorf("%w: %q", ErrUnknownJobType, sj.Type)
+		}
+		t := &task[T, R]{
+			cost:      sj.Cost,
+			weight:    sj.Weight,
+			priority:  sj.Priority,
+			key:       sj.Key,
+			tenant:    sj.Tenant,
+			kind:      sj.Type,
+			partition: sj.Partition,
+			tags:      sj.Tags,
+			metadata:  sj.Metadata,
+			// Future нужен, даже если его никто не получит: с восстановленным заданием
+			// может объединиться отправка SubmitWithKey с тем же ключом
+			future: newFuture[R](),
+		}
+		if err := p.checkHandler(t); err != nil {
+			return fmt.Errorf("restore job %d: %w", i, err)
+		}
+		if err := p.codec.Decode(sj.Data, &t.job); err != nil {
+			return fmt.Errorf("%w: job %d: %v", ErrInvalidSnapshot, i, err)
+		}
+		tasks[i] = t
+	}
+
+	for i, t := range tasks {
+		if err := p.enqueueWait(context.Background(), t); err != nil {
+			return fmt.Errorf("restored %d of %d jobs: %w", i, len(tasks), err)
+		}
+	}
+	return nil
+}
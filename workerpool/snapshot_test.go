@@ -0,0 +1,45 @@
+package workerpool
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRestoredJobCoalescesWithSubmitWithKey(t *testing.T) {
+	source := NewPool[string, string](WithHandler(echo[string]), WithInitialWorkers(1))
+	if err := source.Pause(); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	if _, err := source.SubmitWithKey("restored", "report"); err != nil {
+		t.Fatalf("SubmitWithKey: %v", err)
+	}
+	data, err := source.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	source.ShutdownNow()
+
+	pool := NewPool[string, string](WithHandler(echo[string]), WithInitialWorkers(1))
+	defer pool.Shutdown(context.Background())
+	if err := pool.Pause(); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	if err := pool.Restore(data); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	// Отправка с тем же ключом объединяется с восстановленным заданием и получает его итог
+	future, err := pool.SubmitWithKey("duplicate", "report")
+	if err != nil {
+		t.Fatalf("SubmitWithKey after Restore: %v", err)
+	}
+	if future == nil {
+		t.Fatal("SubmitWithKey after Restore returned a nil Future")
+	}
+	if err := pool.Resume(); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if value, err := await(t, future); err != nil || value != "restored" {
+		t.Errorf("coalesced result = %q, %v; want %q", value, err, "restored")
+	}
+}
@@ -0,0 +1,179 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PoolState описывает состояние пула.
+// Допустимые переходы: Idle ⇄ Busy по мере появления и завершения заданий,
+// Idle/Busy → Paused при вызове Pause и обратно при Resume,
+// Idle/Busy/Paused → Draining при вызове Shutdown, Drain или ShutdownNow, Draining → Closed по их завершении.
+// В состояниях Draining и Closed пул не принимает задания (отправка возвращает ErrPoolClosed),
+// а повторная остановка лишь дожидается первой. Недопустимые переходы Pause и Resume
+// возвращают ошибку: ErrInvalidTransition или, после начала остановки, ErrPoolClosed.
+type PoolState int
+
+// ErrPoolClosed — пул остановлен или останавливается и больше не принимает задания.
+var ErrPoolClosed = errors.New("pool is closed")
+
+// ErrInvalidTransition — переход недопустим в текущем состоянии пула, например Resume без Pause.
+var ErrInvalidTransition = errors.New("invalid pool state transition")
+
+const (
+	Idle     PoolState = iota // нет ни ожидающих, ни выполняющихся заданий
+	Busy                      // есть задания в очереди или в работе
+	Paused                    // обработка приостановлена (Pause), задания принимаются в очередь
+	Draining                  // выполняется Shutdown
+	Closed                    // пул остановлен
+)
+
+func (s PoolState) String() string {
+	switch s {
+	case Idle:
+		return "idle"
+	case Busy:
+		return "busy"
+	case Paused:
+		return "paused"
+	case Draining:
+		return "draining"
+	case Closed:
+		return "closed"
+	default:
+		return fmt.Sprintf("PoolState(%d)", int(s))
+	}
+}
+
+// stateDebounce — сколько пул должен простоять без заданий, прежде чем сообщить о переходе в Idle.
+// Это гасит дребезг Busy→Idle→Busy при коротких паузах между заданиями.
+const stateDebounce = 10 * time.Millisecond
+
+// OnStateChange регистрирует функцию, вызываемую при смене состояния пула (Idle, Busy, Paused, Draining, Closed).
+// Функция вызывается вне блокировки пула, возможно из разных горутин, и должна быстро возвращать управление.
+func (p *Pool[T, R]) OnStateChange(fn func(state PoolState)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.onState = fn
+}
+
+// IsRunning сообщает, принимает ли пул задания: остановка ещё не начиналась.
+func (p *Pool[T, R]) IsRunning() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.state == Idle || p.state == Busy || p.state == Paused
+}
+
+// State возвращает текущее состояние пула.
+func (p *Pool[T, R]) State() PoolState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.state
+}
+
+// setStateLocked меняет состояние пула и возвращает функцию уведомления,
+// которую нужно вызвать уже после снятия блокировки. Вызывается под p.mu.
+func (p *Pool[T, R]) setStateLocked(state PoolState) func() {
+	if p.state == state {
+		return noop
+	}
+	p.state = state
+	if fn := p.onState; fn != nil {
+		return func() { fn(state) }
+	}
+	return noop
+}
+
+// jobQueuedLocked учитывает новое задание в очереди. Вызывается под p.mu.
+func (p *Pool[T, R]) jobQueuedLocked() func() {
+	p.pending++
+	if p.idleTimer != nil {
+		// Пул снова получил работу до истечения задержки — переход в Idle отменяется
+		p.idleTimer.Stop()
+		p.idleTimer = nil
+	}
+	if p.state == Idle {
+		return p.setStateLocked(Busy)
+	}
+	return noop
+}
+
+// jobDone учитывает завершение задания и, если работы не осталось, планирует переход в Idle.
+func (p *Pool[T, R]) jobDone(t *task[T, R]) {
+	p.inflightCost.Add(-int64(t.cost))
+	p.unpersist(t)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.tasks, t.id)
+	p.forgetKeyLocked(t)
+	p.closeProgressLocked(t.id)
+	p.releasePartitionLocked(t)
+	p.releaseTagsLocked(t)
+	p.pending--
+	p.signalDrainedLocked()
+	if p.pending == 0 && p.state == Busy {
+		p.idleTimer = p.clock.AfterFunc(stateDebounce, p.markIdle)
+	}
+}
+
+// Wait блокируется, пока не завершатся все задания в очереди и в работе, но, в отличие
+// от Shutdown, не останавливает пул: после Wait можно отправлять новые задания.
+// Задания, отправленные во время ожидания, тоже дожидаются. Возвращает ошибку ctx,
+// если тот истёк раньше. На приостановленном пуле (Pause) Wait ждёт до Resume.
+func (p *Pool[T, R]) Wait(ctx context.Context) error {
+	drained := p.drainedChan()
+	if drained == nil {
+		return nil
+	}
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drainedChan возвращает канал, закрывающийся, когда не останется заданий в очереди и в работе,
+// или nil, если их нет уже сейчас.
+func (p *Pool[T, R]) drainedChan() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.pending == 0 {
+		return nil
+	}
+	if p.drained == nil {
+		p.drained = make(chan struct{})
+	}
+	return p.drained
+}
+
+// signalDrainedLocked будит Wait, если заданий не осталось. Вызывается под p.mu.
+func (p *Pool[T, R]) signalDrainedLocked() {
+	if p.pending == 0 && p.drained != nil {
+		close(p.drained)
+		p.drained = nil
+	}
+}
+
+// markIdle переводит пул в Idle, если за время задержки не появилось новых заданий.
+func (p *Pool[T, R]) markIdle() {
+	notify := noop
+	defer func() { notify() }()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.idleTimer = nil
+	if p.pending == 0 && p.state == Busy {
+		notify = p.setStateLocked(Idle)
+	}
+}
+
+func noop() {}
@@ -0,0 +1,193 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOnStateChange(t *testing.T) {
+	release := make(chan struct{})
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			<-release
+			return job, nil
+		}),
+		WithInitialWorkers(1),
+	)
+	states := make(chan PoolState, 16)
+	pool.OnStateChange(func(state PoolState) { states <- state })
+
+	expect := func(want PoolState) {
+		t.Helper()
+		select {
+		case got := <-states:
+			if got != want {
+				t.Fatalf("state = %v, want %v", got, want)
+			}
+		case <-time.After(testTimeout):
+			t.Fatalf("no transition to %v", want)
+		}
+	}
+
+	if err := pool.SendJob(1); err != nil {
+		t.Fatalf("SendJob: %v", err)
+	}
+	expect(Busy)
+	close(release)
+	expect(Idle)
+
+	// Задания подряд держат пул в Busy: дребезг Busy→Idle→Busy не доходит до обработчика
+	for i := 0; i < 3; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	expect(Busy)
+	expect(Idle)
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	expect(Draining)
+	expect(Closed)
+	select {
+	case state := <-states:
+		t.Errorf("unexpected transition to %v", state)
+	default:
+	}
+}
+
+func TestPauseResumeTransitions(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]), WithInitialWorkers(1))
+	states := make(chan PoolState, 16)
+	pool.OnStateChange(func(state PoolState) { states <- state })
+
+	if err := pool.Resume(); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("Resume without Pause = %v, want ErrInvalidTransition", err)
+	}
+	if err := pool.Pause(); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	if state := pool.State(); state != Paused {
+		t.Fatalf("state after Pause = %v, want Paused", state)
+	}
+	if err := pool.Pause(); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("second Pause = %v, want ErrInvalidTransition", err)
+	}
+
+	// На паузе задания принимаются, но пул остаётся в Paused
+	future, err := pool.Submit(1)
+	if err != nil {
+		t.Fatalf("Submit while paused: %v", err)
+	}
+	if state := pool.State(); state != Paused || !pool.IsRunning() {
+		t.Fatalf("state after Submit = %v (running %t), want running Paused", state, pool.IsRunning())
+	}
+	if err := pool.Resume(); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if value, err := await(t, future); err != nil || value != 1 {
+		t.Fatalf("job after Resume = %d, %v", value, err)
+	}
+	eventually(t, "pool idle", func() bool { return pool.State() == Idle })
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if state := pool.State(); state != Closed {
+		t.Fatalf("state after Shutdown = %v, want Closed", state)
+	}
+	if err := pool.Pause(); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Pause after Shutdown = %v, want ErrPoolClosed", err)
+	}
+	if err := pool.Resume(); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Resume after Shutdown = %v, want ErrPoolClosed", err)
+	}
+
+	var seen []PoolState
+	for len(states) > 0 {
+		seen = append(seen, <-states)
+	}
+	// Задание на паузе не переводит пул в Busy: после Resume он сразу в Busy, затем в Idle
+	want := []PoolState{Paused, Busy, Idle, Draining, Closed}
+	if len(seen) != len(want) {
+		t.Fatalf("transitions = %v, want %v", seen, want)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("transitions = %v, want %v", seen, want)
+		}
+	}
+}
+
+func TestPausedPoolShutsDown(t *testing.T) {
+	var processed atomic.Int32
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		processed.Add(1)
+		return job, nil
+	}), WithInitialWorkers(1))
+	if err := pool.Pause(); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	// Paused → Draining: Shutdown снимает паузу и дообрабатывает очередь
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if n := processed.Load(); n != 3 {
+		t.Errorf("processed %d jobs, want 3", n)
+	}
+}
+
+func TestWaitForQueuedAndRunningJobs(t *testing.T) {
+	release := make(chan struct{})
+	var processed atomic.Int32
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		<-release
+		processed.Add(1)
+		return job, nil
+	}), WithInitialWorkers(1))
+	defer pool.Shutdown(context.Background())
+
+	if err := pool.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait on an empty pool: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+
+	// Пока задания не завершены, Wait возвращает ошибку истёкшего ctx
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := pool.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait with running jobs = %v, want DeadlineExceeded", err)
+	}
+
+	close(release)
+	ctx, cancel = context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := pool.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if n := processed.Load(); n != 3 {
+		t.Fatalf("processed %d jobs after Wait, want 3", n)
+	}
+
+	// В отличие от Shutdown, после Wait пул принимает новые задания
+	future, err := pool.Submit(4)
+	if err != nil {
+		t.Fatalf("Submit after Wait: %v", err)
+	}
+	if got, err := await(t, future); err != nil || got != 4 {
+		t.Fatalf("job after Wait = %d, %v; want 4, nil", got, err)
+	}
+}
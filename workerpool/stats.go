@@ -0,0 +1,164 @@
+package workerpool
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencySamples — сколько последних замеров хранится для расчёта процентилей.
+const latencySamples = 1024
+
+// ErrNoOp возвращает обработчик, которому для задания не нашлось работы, например
+// опрос пустого источника. Такое задание завершается без ошибки и не повторяется,
+// но учитывается отдельно — в Stats.NoOp, а не в Processed и не в Failed, — чтобы
+// холостые циклы не смешивались с полезной работой в показателях.
+var ErrNoOp = errors.New("job had nothing to do")
+
+// Stats — снимок показателей пула для мониторинга и подбора его размера.
+type Stats struct {
+	QueueLen    int // заданий в очереди
+	Workers     int // воркеров всего
+	BusyWorkers int // воркеров, занятых заданием
+	IdleWorkers int // воркеров, ждущих задания
+
+	Processed uint64 // обработано заданий, включая упавшие
+	Failed    uint64 // заданий, завершившихся ошибкой
+	NoOp      uint64 // заданий, обработчик которых вернул ErrNoOp; в Processed не входят
+
+	// Время обработки заданий: среднее за всё время и процентили по последним замерам
+	AvgLatency time.Duration
+	P50Latency time.Duration
+	P95Latency time.Duration
+	P99Latency time.Duration
+
+	// Время ожидания заданий в очереди
+	AvgQueueWait time.Duration
+	P95QueueWait time.Duration
+}
+
+// MetricsObserver получает замеры каждого обработанного задания.
+// Через него пул подключается к внешним системам метрик, например к Prometheus.
+type MetricsObserver interface {
+	ObserveJob(latency, queueWait time.Duration, err error)
+}
+
+// WithMetricsObserver передаёт замеры каждого обработанного задания в o.
+// o вызывается в горутине воркера, поэтому должен быстро возвращать управление.
+func WithMetricsObserver(o MetricsObserver) Option {
+	return func(c *config) {
+		c.observer = o
+	}
+}
+
+// metrics накапливает показатели обработанных заданий. Защищена собственным мьютексом,
+// а не p.mu, чтобы завершение заданий не соперничало с их приёмом.
+type metrics struct {
+	mu sync.Mutex
+
+	processed uint64
+	failed    uint64
+	noOp      uint64
+
+	totalLatency time.Duration
+	totalWait    time.Duration
+
+	// Кольцевые буферы последних замеров
+	latencies []time.Duration
+	waits     []time.Duration
+	next      int
+}
+
+// record добавляет замеры одного обработанного задания.
+func (m *metrics) record(latency, wait time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.processed++
+	if failed {
+		m.failed++
+	}
+	m.totalLatency += latency
+	m.totalWait += wait
+
+	if len(m.latencies) < latencySamples {
+		m.latencies = append(m.latencies, latency)
+		m.waits = append(m.waits, wait)
+		return
+	}
+	m.latencies[m.next] = latency
+	m.waits[m.next] = wait
+	m.next = (m.next + 1) % latencySamples
+}
+
+// recordNoOp учитывает задание, обработчик которого вернул ErrNoOp.
+// Замеры таких заданий в задержки не входят.
+func (m *metrics) recordNoOp() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.noOp++
+}
+
+// p95EndToEnd возвращает 95-й процентиль полного времени задания — ожидания плюс обработки —
+// по последним замерам.
+func (m *metrics) p95EndToEnd() time.Duration {
+	m.mu.Lock()
+	totals := make([]time.Duration, len(m.latencies))
+	for i := range m.latencies {
+		totals[i] = m.latencies[i] + m.waits[i]
+	}
+	m.mu.Unlock()
+
+	sort.Slice(totals, func(i, j int) bool { return totals[i] < totals[j] })
+	return percentile(totals, 0.95)
+}
+
+// Stats возвращает текущие показатели пула.
+func (p *Pool[T, R]) Stats() Stats {
+	p.mu.Lock()
+	s := Stats{QueueLen: p.queuedLocked()}
+	for _, worker := range p.workers {
+		if worker.removed {
+			continue
+		}
+		s.Workers++
+		if worker.working {
+			s.BusyWorkers++
+		}
+	}
+	p.mu.Unlock()
+	s.IdleWorkers = s.Workers - s.BusyWorkers
+
+	p.metrics.mu.Lock()
+	defer p.metrics.mu.Unlock()
+
+	s.Processed = p.metrics.processed
+	s.Failed = p.metrics.failed
+	s.NoOp = p.metrics.noOp
+	if n := p.metrics.processed; n > 0 {
+		s.AvgLatency = p.metrics.totalLatency / time.Duration(n)
+		s.AvgQueueWait = p.metrics.totalWait / time.Duration(n)
+	}
+	latencies := sortedCopy(p.metrics.latencies)
+	s.P50Latency = percentile(latencies, 0.50)
+	s.P95Latency = percentile(latencies, 0.95)
+	s.P99Latency = percentile(latencies, 0.99)
+	s.P95QueueWait = percentile(sortedCopy(p.metrics.waits), 0.95)
+	return s
+}
+
+func sortedCopy(samples []time.Duration) []time.Duration {
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// percentile возвращает процентиль q (от 0 до 1) отсортированных замеров.
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(q*float64(len(sorted)-1))]
+}
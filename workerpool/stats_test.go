@@ -0,0 +1,111 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestErrNoOpCountedSeparately(t *testing.T) {
+	var calls atomic.Int32
+	pool := NewPool[string, string](
+		WithHandler(func(ctx context.Context, job string) (string, error) {
+			calls.Add(1)
+			switch job {
+			case "idle":
+				return "", ErrNoOp
+			case "fail":
+				return "", errors.New("boom")
+			}
+			return job, nil
+		}),
+		WithRetry(RetryPolicy{MaxAttempts: 3, Backoff: func(int) time.Duration { return 0 }}),
+		WithSynchronousMode(),
+	)
+	defer pool.Shutdown(context.Background())
+
+	idle, err := pool.Submit("idle")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if err := idle.Err(); err != nil {
+		t.Errorf("no-op job error = %v, want nil", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("no-op job ran %d times, want 1 (no retries)", n)
+	}
+	for _, job := range []string{"work", "fail"} {
+		if err := pool.SendJob(job); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+
+	s := pool.Stats()
+	if s.NoOp != 1 || s.Processed != 2 || s.Failed != 1 {
+		t.Errorf("Stats NoOp=%d Processed=%d Failed=%d, want 1, 2, 1", s.NoOp, s.Processed, s.Failed)
+	}
+}
+
+func TestStatsSnapshot(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			if job == 0 {
+				close(started)
+				<-release
+			}
+			if job < 0 {
+				return 0, errors.New("boom")
+			}
+			return job, nil
+		}),
+		WithInitialWorkers(2),
+	)
+	defer pool.Shutdown(context.Background())
+
+	if err := pool.SendJob(0); err != nil {
+		t.Fatalf("SendJob: %v", err)
+	}
+	<-started
+	s := pool.Stats()
+	if s.Workers != 2 || s.BusyWorkers != 1 || s.IdleWorkers != 1 {
+		t.Errorf("workers = %d busy %d idle %d, want 2, 1, 1", s.Workers, s.BusyWorkers, s.IdleWorkers)
+	}
+	close(release)
+
+	for _, job := range []int{1, 2, -1} {
+		if err := pool.SendJob(job); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	eventually(t, "all jobs processed", func() bool { return pool.Stats().Processed == 4 })
+	if s := pool.Stats(); s.Failed != 1 || s.QueueLen != 0 {
+		t.Errorf("failed = %d, queue = %d; want 1 and 0", s.Failed, s.QueueLen)
+	}
+}
+
+func TestStatsPercentiles(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]))
+	defer pool.Shutdown(context.Background())
+
+	// Замеры 1..100 мс: процентили берутся по последним замерам, среднее — по всем
+	for i := 1; i <= 100; i++ {
+		pool.metrics.record(time.Duration(i)*time.Millisecond, time.Duration(i)*time.Microsecond, false)
+	}
+	s := pool.Stats()
+	for name, tc := range map[string]struct{ got, want time.Duration }{
+		"avg latency": {s.AvgLatency, 50500 * time.Microsecond},
+		"p50 latency": {s.P50Latency, 50 * time.Millisecond},
+		"p95 latency": {s.P95Latency, 95 * time.Millisecond},
+		"p99 latency": {s.P99Latency, 99 * time.Millisecond},
+		"p95 wait":    {s.P95QueueWait, 95 * time.Microsecond},
+	} {
+		// Допускаем расхождение в один замер из-за способа округления ранга
+		if diff := tc.got - tc.want; diff < -time.Millisecond || diff > time.Millisecond {
+			t.Errorf("%s = %v, want about %v", name, tc.got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package workerpool
+
+import "runtime/debug"
+
+// WithSupervision перехватывает паники, которые убили бы горутину воркера, а с ней и процесс:
+// паники вне обработчика (их обработчик сам превращает в *PanicError) — в подписчиках Subscribe,
+// трассировщике WithTracer, функциях OnResult и других обратных вызовах пула.
+// Упавший воркер заменяется новым. Если он упал посреди задания, ещё не получившего итога,
+// задание возвращается в очередь, но не больше maxRequeues раз; дальше оно завершается
+// с *PanicError. Задание, итог которого уже передан (паника в OnResult и т. п.), повторно не выполняется.
+// Перехваченные паники учитываются в PanicCounter.
+func WithSupervision(maxRequeues int) Option {
+	return func(c *config) {
+		if maxRequeues < 0 {
+			panic("workerpool: WithSupervision requires a non-negative requeue limit")
+		}
+		c.supervised = true
+		c.maxRequeues = maxRequeues
+	}
+}
+
+// recoverWorker разбирается с паникой r, завершившей воркера id посреди задания t
+// (nil — между заданиями), и запускает воркера на замену.
+func (p *Pool[T, R]) recoverWorker(id int, t *task[T, R], r any) {
+	p.panics.Add(1)
+	stack := debug.Stack()
+	p.logger.Error("worker crashed", "worker", id, "panic", r, "stack", string(stack))
+
+	if t != nil {
+		p.releasePermits(t.probe)
+		switch {
+		case t.completed:
+			// Итог уже передан — задание не выполняется повторно
+			p.jobDone(t)
+		case t.crashes < p.maxRequeues && p.requeueCrashed(t):
+			p.logger.Warn("job requeued after worker crash", "job", t.job, "id", t.id, "crashes", t.crashes)
+		default:
+			p.complete(t, *new(R), &PanicError{Value: r, Stack: stack}, 0, p.clock.Now(), 0)
+			p.jobDone(t)
+		}
+	}
+	// Замена запускается отдельно: AddWorker может ждать WithWorkerSpawnRate
+	go p.AddWorker()
+}
+
+// requeueCrashed возвращает в очередь задание упавшего воркера. Задание остаётся принятым:
+// его ID, Future и учёт в пуле не меняются. Возвращает false, если пул уже останавливается.
+func (p *Pool[T, R]) requeueCrashed(t *task[T, R]) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.acceptingLocked() != nil {
+		return false
+	}
+	t.crashes++
+	// Места в тегах занимаются заново при следующей выдаче
+	p.releaseTagsLocked(t)
+	p.queueLocked(t, p.clock.Now())
+	return true
+}
@@ -0,0 +1,131 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSupervisionRequeuesCrashedJob(t *testing.T) {
+	var calls atomic.Int32
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			calls.Add(1)
+			return job * 2, nil
+		}),
+		WithInitialWorkers(1),
+		WithSupervision(2),
+	)
+	defer pool.Shutdown(context.Background())
+
+	// Подписчик роняет воркера в начале первого задания — до вызова обработчика
+	var crashed atomic.Bool
+	pool.Subscribe(func(ev Event) {
+		if ev.Type == EventStarted && crashed.CompareAndSwap(false, true) {
+			panic("subscriber bug")
+		}
+	})
+
+	future, err := pool.Submit(21)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	id := future.ID()
+	value, err := await(t, future)
+	if err != nil || value != 42 {
+		t.Fatalf("requeued job result = %d, %v, want 42", value, err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("handler called %d times, want 1", n)
+	}
+	if n := pool.PanicCounter(); n != 1 {
+		t.Errorf("PanicCounter = %d, want 1", n)
+	}
+	if future.ID() != id {
+		t.Errorf("job ID changed from %d to %d after requeue", id, future.ID())
+	}
+	// Упавший воркер заменён
+	eventually(t, "replacement worker", func() bool { return pool.Stats().Workers == 1 })
+	workers := pool.Workers()
+	if len(workers) != 1 || workers[0].ID == 0 {
+		t.Errorf("workers = %+v, want one replacement worker", workers)
+	}
+}
+
+func TestSupervisionRequeueLimit(t *testing.T) {
+	var calls atomic.Int32
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			calls.Add(1)
+			return job, nil
+		}),
+		WithInitialWorkers(1),
+		WithSupervision(2),
+	)
+	defer pool.Shutdown(context.Background())
+
+	var started atomic.Int32
+	pool.Subscribe(func(ev Event) {
+		if ev.Type == EventStarted {
+			started.Add(1)
+			panic("always crashes")
+		}
+	})
+
+	future, err := pool.Submit(1)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	var panicErr *PanicError
+	if _, err := await(t, future); !errors.As(err, &panicErr) {
+		t.Fatalf("job error = %v, want *PanicError", err)
+	}
+	// Первая попытка и два возврата в очередь
+	if n := started.Load(); n != 3 {
+		t.Errorf("job started %d times, want 3", n)
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("handler called %d times, want 0", n)
+	}
+}
+
+func TestSupervisionDoesNotRerunCompletedJob(t *testing.T) {
+	var calls atomic.Int32
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			calls.Add(1)
+			return job, nil
+		}),
+		WithInitialWorkers(1),
+		WithSupervision(3),
+	)
+	defer pool.Shutdown(context.Background())
+
+	// Паника после получения итога не должна приводить к повторному выполнению
+	var crashed atomic.Bool
+	pool.OnResult(func(res Result[int, int]) {
+		if crashed.CompareAndSwap(false, true) {
+			panic("OnResult bug")
+		}
+	})
+
+	future, err := pool.Submit(7)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if value, err := await(t, future); err != nil || value != 7 {
+		t.Fatalf("job result = %d, %v", value, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := pool.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("handler called %d times, want 1", n)
+	}
+	if n := pool.PanicCounter(); n != 1 {
+		t.Errorf("PanicCounter = %d, want 1", n)
+	}
+}
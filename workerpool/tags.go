@@ -0,0 +1,179 @@
+package workerpool
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// WithTagLimit ограничивает число одновременно выполняющихся заданий с тегом tag, независимо
+// от числа воркеров: например, не больше 4 заданий "db" и 16 заданий "s3". Так один пул
+// обслуживает задания с разными ограничениями внешних систем. Теги заданиям задаются через
+// SendJobWithTags и SubmitWithTags; теги без ограничения не учитываются.
+//
+// Задание, тег которого исчерпан, не занимает воркера: оно ждёт в отдельной очереди тега
+// и встаёт в общую очередь, когда завершится другое задание с этим тегом.
+func WithTagLimit(tag string, max int) Option {
+	return func(c *config) {
+		if max < 1 {
+			panic("workerpool: WithTagLimit requires a positive limit")
+		}
+		if c.tagLimits == nil {
+			c.tagLimits = make(map[string]int)
+		}
+		c.tagLimits[tag] = max
+	}
+}
+
+// SendJobWithTags помещает в очередь задание с тегами ограничений WithTagLimit.
+func (p *Pool[T, R]) SendJobWithTags(job T, tags ...string) error {
+	return p.enqueue(&task[T, R]{job: job, cost: 1, tags: tags})
+}
+
+// SubmitWithTags — то же, что SendJobWithTags, но возвращает Future с результатом задания.
+func (p *Pool[T, R]) SubmitWithTags(job T, tags ...string) (*Future[R], error) {
+	t := &task[T, R]{job: job, cost: 1, tags: tags, future: newFuture[R]()}
+	if err := p.enqueue(t); err != nil {
+		return nil, err
+	}
+	return t.future, nil
+}
+
+// CancelByTag отменяет все задания с тегом tag, как Cancel: ждущие убираются из очереди
+// и их Future завершаются с context.Canceled, а у выполняющихся отменяется контекст.
+// Тег не обязан иметь ограничение WithTagLimit. Возвращает число отменённых заданий.
+func (p *Pool[T, R]) CancelByTag(tag string) int {
+	var removed []*task[T, R]
+	n := 0
+	p.mu.Lock()
+	for _, t := range p.tasks {
+		if !slices.Contains(t.tags, tag) {
+			continue
+		}
+		n++
+		if p.cancelLocked(t) {
+			removed = append(removed, t)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, t := range removed {
+		p.finishRemoved(t, context.Canceled)
+	}
+	return n
+}
+
+// queueLocked ставит задание в общую очередь с жетоном или, если один из его тегов
+// исчерпан, в очередь этого тега. Вызывается под p.mu.
+func (p *Pool[T, R]) queueLocked(t *task[T, R], now time.Time) {
+	if p.holdTagsLocked(t) {
+		return
+	}
+	p.queue.push(t, now)
+	// Жетонов в канале не больше, чем заданий в очереди, поэтому отправка не блокируется
+	p.tokens <- struct{}{}
+}
+
+// holdTagsLocked занимает для задания места во всех его тегах или, если какой-то тег
+// исчерпан, откладывает задание в очередь этого тега и возвращает true. Вызывается под p.mu.
+func (p *Pool[T, R]) holdTagsLocked(t *task[T, R]) bool {
+	for _, tag := range t.tags {
+		if limit, ok := p.tagLimits[tag]; ok && p.tagActive[tag] >= limit {
+			if p.tagBacklog == nil {
+				p.tagBacklog = make(map[string][]*task[T, R])
+			}
+			t.tagHeld = tag
+			p.tagBacklog[tag] = append(p.tagBacklog[tag], t)
+			p.backlogged++
+			return true
+		}
+	}
+	for _, tag := range t.tags {
+		if _, ok := p.tagLimits[tag]; ok {
+			if p.tagActive == nil {
+				p.tagActive = make(map[string]int)
+			}
+			p.tagActive[tag]++
+			t.tagsTaken = true
+		}
+	}
+	return false
+}
+
+// releaseTagsLocked освобождает места, занятые заданием в тегах, и ставит в общую
+// очередь ждущие задания этих тегов. Вызывается под p.mu из jobDone.
+func (p *Pool[T, R]) releaseTagsLocked(t *task[T, R]) {
+	if !p.freeTagsLocked(t) {
+		return
+	}
+	now := p.clock.Now()
+	for _, tag := range t.tags {
+		for len(p.tagBacklog[tag]) > 0 && p.tagActive[tag] < p.tagLimits[tag] {
+			backlog := p.tagBacklog[tag]
+			next := backlog[0]
+			backlog[0] = nil
+			if len(backlog) == 1 {
+				delete(p.tagBacklog, tag)
+			} else {
+				p.tagBacklog[tag] = backlog[1:]
+			}
+			p.backlogged--
+			next.tagHeld = ""
+			// Задание может снова встать в очередь другого, тоже исчерпанного тега
+			p.queueLocked(next, now)
+		}
+	}
+	p.closeTokensIfDrainedLocked()
+}
+
+// freeTagsLocked возвращает места, занятые заданием в тегах.
+// Возвращает false, если задание их не занимало. Вызывается под p.mu.
+func (p *Pool[T, R]) freeTagsLocked(t *task[T, R]) bool {
+	if !t.tagsTaken {
+		return false
+	}
+	t.tagsTaken = false
+	for _, tag := range t.tags {
+		if _, ok := p.tagLimits[tag]; !ok {
+			continue
+		}
+		if p.tagActive[tag]--; p.tagActive[tag] <= 0 {
+			delete(p.tagActive, tag)
+		}
+	}
+	return true
+}
+
+// removeTagHeldLocked убирает задание из очереди исчерпанного тега.
+// Возвращает false, если задание там не ждёт. Вызывается под p.mu.
+func (p *Pool[T, R]) removeTagHeldLocked(t *task[T, R]) bool {
+	if t.tagHeld == "" {
+		return false
+	}
+	backlog := p.tagBacklog[t.tagHeld]
+	for i, held := range backlog {
+		if held == t {
+			if len(backlog) == 1 {
+				delete(p.tagBacklog, t.tagHeld)
+			} else {
+				p.tagBacklog[t.tagHeld] = append(backlog[:i], backlog[i+1:]...)
+			}
+			t.tagHeld = ""
+			p.backlogged--
+			p.closeTokensIfDrainedLocked()
+			return true
+		}
+	}
+	return false
+}
+
+// dropTagHeldLocked извлекает все задания из очередей тегов. Вызывается под p.mu.
+func (p *Pool[T, R]) dropTagHeldLocked() []*task[T, R] {
+	var tasks []*task[T, R]
+	for _, backlog := range p.tagBacklog {
+		tasks = append(tasks, backlog...)
+	}
+	p.tagBacklog = nil
+	p.backlogged -= len(tasks)
+	return tasks
+}
@@ -0,0 +1,159 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTagLimits(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		running = map[string]int{}
+		peak    = map[string]int{}
+	)
+	// Задание — список его тегов
+	pool := NewPool[[]string, int](WithHandler(func(ctx context.Context, tags []string) (int, error) {
+		mu.Lock()
+		for _, tag := range tags {
+			running[tag]++
+			peak[tag] = max(peak[tag], running[tag])
+		}
+		mu.Unlock()
+		time.Sleep(2 * time.Millisecond)
+		mu.Lock()
+		for _, tag := range tags {
+			running[tag]--
+		}
+		mu.Unlock()
+		return len(tags), nil
+	}), WithInitialWorkers(8), WithTagLimit("db", 2), WithTagLimit("s3", 1))
+	defer pool.Shutdown(context.Background())
+
+	var futures []*Future[int]
+	for i := 0; i < 4; i++ {
+		for _, tags := range [][]string{{"db"}, {"db"}, {"s3"}, {"db", "s3"}, {"cache"}} {
+			future, err := pool.SubmitWithTags(tags, tags...)
+			if err != nil {
+				t.Fatalf("SubmitWithTags: %v", err)
+			}
+			futures = append(futures, future)
+		}
+	}
+	for _, future := range futures {
+		if _, err := await(t, future); err != nil {
+			t.Fatalf("job %d: %v", future.ID(), err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for tag, limit := range map[string]int{"db": 2, "s3": 1} {
+		if peak[tag] > limit {
+			t.Errorf("%d %q jobs ran at once, want at most %d", peak[tag], tag, limit)
+		}
+	}
+	if peak["cache"] < 2 {
+		t.Errorf("at most %d jobs with an unlimited tag ran at once, want them to share the workers", peak["cache"])
+	}
+}
+
+func TestTagLimitKeepsWorkersFree(t *testing.T) {
+	release := make(chan struct{})
+	pool := NewPool[string, string](WithHandler(func(ctx context.Context, job string) (string, error) {
+		if job == "db1" {
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+		return job, nil
+	}), WithInitialWorkers(2), WithTagLimit("db", 1))
+	defer pool.Shutdown(context.Background())
+
+	if _, err := pool.SubmitWithTags("db1", "db"); err != nil {
+		t.Fatalf("SubmitWithTags: %v", err)
+	}
+	eventually(t, "db1 started", func() bool { return pool.Stats().BusyWorkers == 1 })
+	waiting, err := pool.SubmitWithTags("db2", "db")
+	if err != nil {
+		t.Fatalf("SubmitWithTags: %v", err)
+	}
+
+	// Задание исчерпанного тега ждёт в его очереди и не занимает второго воркера
+	plain, err := pool.Submit("plain")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if _, err := await(t, plain); err != nil {
+		t.Fatalf("untagged job: %v", err)
+	}
+	select {
+	case <-waiting.Done():
+		t.Fatal("db2 ran while db1 held the only db slot")
+	default:
+	}
+	if n := pool.QueueLen(); n != 1 {
+		t.Errorf("QueueLen = %d, want the backlogged db2 counted", n)
+	}
+	close(release)
+	if _, err := await(t, waiting); err != nil {
+		t.Errorf("db2 after db1 finished: %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("WithTagLimit with a zero limit did not panic")
+		}
+	}()
+	NewPool[string, string](WithHandler(echo[string]), WithTagLimit("db", 0))
+}
+
+func TestCancelByTag(t *testing.T) {
+	pool := NewPool[string, string](WithHandler(func(ctx context.Context, job string) (string, error) {
+		if job == "plain" {
+			return job, nil
+		}
+		<-ctx.Done()
+		return "", ctx.Err()
+	}), WithInitialWorkers(1), WithTagLimit("db", 1))
+	defer pool.Shutdown(context.Background())
+
+	running, err := pool.SubmitWithTags("running", "db", "report")
+	if err != nil {
+		t.Fatalf("SubmitWithTags: %v", err)
+	}
+	eventually(t, "job started", func() bool { return pool.Stats().BusyWorkers == 1 })
+	// Второе задание ждёт в очереди тега db, третье — в общей очереди
+	backlogged, err := pool.SubmitWithTags("backlogged", "db", "report")
+	if err != nil {
+		t.Fatalf("SubmitWithTags: %v", err)
+	}
+	queued, err := pool.SubmitWithTags("queued", "report")
+	if err != nil {
+		t.Fatalf("SubmitWithTags: %v", err)
+	}
+	plain, err := pool.Submit("plain")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	// Тег без ограничения тоже отменяет задания, где бы те ни ждали
+	if n := pool.CancelByTag("report"); n != 3 {
+		t.Errorf("CancelByTag = %d, want 3", n)
+	}
+	for _, future := range []*Future[string]{running, backlogged, queued} {
+		if _, err := await(t, future); !errors.Is(err, context.Canceled) {
+			t.Errorf("tagged job %d = %v, want context.Canceled", future.ID(), err)
+		}
+	}
+	if got, err := await(t, plain); err != nil || got != "plain" {
+		t.Errorf("untagged job = %q, %v; want it processed", got, err)
+	}
+	if n := pool.CancelByTag("report"); n != 0 {
+		t.Errorf("repeated CancelByTag = %d, want 0", n)
+	}
+}
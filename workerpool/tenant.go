@@ -0,0 +1,19 @@
+package workerpool
+
+// SendJobForTenant помещает в очередь задание арендатора tenant.
+// Воркеры берут задания разных арендаторов по очереди, поэтому арендатор с большим
+// числом ожидающих заданий не задерживает остальных. Приоритеты и старение действуют
+// внутри очереди одного арендатора; задания без арендатора (SendJob и др.) образуют
+// ещё одну очередь в общем обходе.
+func (p *Pool[T, R]) SendJobForTenant(tenant string, job T) error {
+	return p.enqueue(&task[T, R]{job: job, cost: 1, tenant: tenant})
+}
+
+// SubmitForTenant — то же, что SendJobForTenant, но возвращает Future с результатом задания.
+func (p *Pool[T, R]) SubmitForTenant(tenant string, job T) (*Future[R], error) {
+	t := &task[T, R]{job: job, cost: 1, tenant: tenant, future: newFuture[R]()}
+	if err := p.enqueue(t); err != nil {
+		return nil, err
+	}
+	return t.future, nil
+}
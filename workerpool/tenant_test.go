@@ -0,0 +1,53 @@
+package workerpool
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestTenantRoundRobin(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	pool := NewPool[string, string](WithHandler(func(ctx context.Context, job string) (string, error) {
+		mu.Lock()
+		order = append(order, job)
+		mu.Unlock()
+		return job, nil
+	}))
+	defer pool.Shutdown(context.Background())
+
+	// Шумный арендатор ставит задания первым, но не задерживает остальных
+	for _, job := range []string{"a1", "a2", "a3", "a4"} {
+		if err := pool.SendJobForTenant("a", job); err != nil {
+			t.Fatalf("SendJobForTenant: %v", err)
+		}
+	}
+	if err := pool.SendJobForTenant("b", "b1"); err != nil {
+		t.Fatalf("SendJobForTenant: %v", err)
+	}
+	last, err := pool.SubmitForTenant("b", "b2")
+	if err != nil {
+		t.Fatalf("SubmitForTenant: %v", err)
+	}
+	if err := pool.SendJob("plain"); err != nil {
+		t.Fatalf("SendJob: %v", err)
+	}
+	pool.AddWorker()
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := pool.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if got, err := await(t, last); err != nil || got != "b2" {
+		t.Fatalf("SubmitForTenant result = %q, %v", got, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"a1", "b1", "plain", "a2", "b2", "a3", "a4"}
+	if !slices.Equal(order, want) {
+		t.Errorf("processing order = %v, want %v", order, want)
+	}
+}
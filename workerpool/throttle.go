@@ -0,0 +1,146 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// waitSpawnSlot ждёт разрешения на запуск воркера по WithWorkerSpawnRate.
+// Возвращает false, если за время ожидания началась остановка пула.
+func (p *Pool[T, R]) waitSpawnSlot() bool {
+	if p.spawnInterval == 0 {
+		return true
+	}
+
+	// Каждый вызов занимает ближайший свободный момент запуска
+	p.spawnMu.Lock()
+	now := p.clock.Now()
+	at := p.nextSpawn
+	if at.Before(now) {
+		at = now
+	}
+	p.nextSpawn = at.Add(p.spawnInterval)
+	p.spawnMu.Unlock()
+
+	timer := p.clock.NewTimer(at.Sub(now))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return true
+	case <-p.done:
+		return false
+	}
+}
+
+// slowStart — семафор, число разрешений которого растёт со временем.
+type slowStart struct {
+	start time.Time
+	ramp  time.Duration
+
+	mu     sync.Mutex
+	active int
+}
+
+// limit возвращает допустимое число одновременно работающих воркеров в момент now.
+// После окончания разгона ограничения нет.
+func (s *slowStart) limit(now time.Time, workers int) (int, bool) {
+	elapsed := now.Sub(s.start)
+	if elapsed >= s.ramp {
+		return 0, false
+	}
+	allowed := int(float64(workers) * float64(elapsed) / float64(s.ramp))
+	if allowed < 1 {
+		allowed = 1
+	}
+	return allowed, true
+}
+
+// slowStartAcquire ждёт разрешения на обработку задания при WithSlowStart.
+// Возвращает false, если контекст воркера был отменён во время ожидания.
+func (p *Pool[T, R]) slowStartAcquire(ctx context.Context) bool {
+	s := p.slow
+	if s == nil {
+		return true
+	}
+
+	// Разрешения добавляются со временем, поэтому ожидание периодически перепроверяется
+	step := s.ramp / 20
+	if step < time.Millisecond {
+		step = time.Millisecond
+	}
+	for {
+		p.mu.Lock()
+		workers := len(p.workers)
+		p.mu.Unlock()
+
+		s.mu.Lock()
+		allowed, limited := s.limit(p.clock.Now(), workers)
+		if !limited || s.active < allowed {
+			s.active++
+			s.mu.Unlock()
+			return true
+		}
+		s.mu.Unlock()
+
+		timer := p.clock.NewTimer(step)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C():
+		}
+	}
+}
+
+// slowStartRelease возвращает разрешение, полученное через slowStartAcquire.
+func (p *Pool[T, R]) slowStartRelease() {
+	if s := p.slow; s != nil {
+		s.mu.Lock()
+		s.active--
+		s.mu.Unlock()
+	}
+}
+
+// rateLimiter — корзина жетонов для WithRateLimit.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // время накопления одного жетона
+	burst    float64
+	tokens   float64 // может уходить в минус на число ожидающих
+	last     time.Time
+	clock    Clock // часы пула; задаются в NewPool вместе с last
+}
+
+// wait ждёт жетона на один вызов обработчика. Возвращает ошибку ctx, если тот отменён раньше.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := l.clock.Now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	// Жетон занимается сразу, а если его нет — в долг, с ожиданием его накопления
+	l.tokens--
+	delay := time.Duration(-l.tokens * float64(l.interval))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := l.clock.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		// Возвращаем неиспользованный жетон
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
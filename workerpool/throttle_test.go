@@ -0,0 +1,169 @@
+package workerpool
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerSpawnRate(t *testing.T) {
+	const workers, perSecond = 5, 50 // воркер раз в 20 мс
+	pool := NewPool[int, int](WithHandler(echo[int]), WithWorkerSpawnRate(perSecond))
+	defer pool.Shutdown(context.Background())
+
+	start := time.Now()
+	var mu sync.Mutex
+	var online []time.Duration
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if pool.AddWorker() < 0 {
+				t.Error("AddWorker failed")
+			}
+			mu.Lock()
+			online = append(online, time.Since(start))
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// Воркеры появляются по одному с интервалом, а не все сразу
+	sort.Slice(online, func(i, j int) bool { return online[i] < online[j] })
+	interval := time.Second / perSecond
+	for i := 1; i < len(online); i++ {
+		if gap := online[i] - online[i-1]; gap < interval/2 {
+			t.Errorf("worker %d came online %v after the previous one, want about %v", i, gap, interval)
+		}
+	}
+	if n := pool.Stats().Workers; n != workers {
+		t.Errorf("workers = %d, want %d", n, workers)
+	}
+}
+
+func TestWorkerSpawnRateDoesNotBlockShutdown(t *testing.T) {
+	pool := NewPool[int, int](WithHandler(echo[int]), WithWorkerSpawnRate(1))
+	pool.AddWorker()
+
+	// Следующие воркеры ждут по секунде: остановка не должна их дожидаться
+	results := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		go func() { results <- pool.AddWorker() }()
+	}
+	eventually(t, "spawns waiting", func() bool {
+		pool.spawnMu.Lock()
+		defer pool.spawnMu.Unlock()
+		return time.Until(pool.nextSpawn) > 3*time.Second
+	})
+
+	start := time.Now()
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if id := <-results; id >= 0 {
+			t.Errorf("AddWorker during Shutdown returned worker %d, want -1", id)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Shutdown took %v while spawns were waiting", elapsed)
+	}
+}
+
+func TestSlowStartRampsConcurrency(t *testing.T) {
+	const workers, ramp = 4, 400 * time.Millisecond
+	var running atomic.Int32
+	release := make(chan struct{})
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			running.Add(1)
+			defer running.Add(-1)
+			<-release
+			return job, nil
+		}),
+		WithInitialWorkers(workers),
+		WithSlowStart(ramp),
+	)
+	defer pool.Shutdown(context.Background())
+	defer close(release)
+
+	for i := 0; i < workers; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+
+	// В первой половине разгона разрешено не больше одного задания за раз
+	eventually(t, "first job running", func() bool { return running.Load() == 1 })
+	time.Sleep(ramp / 8)
+	if n := running.Load(); n != 1 {
+		t.Fatalf("%d jobs running early in the ramp, want 1", n)
+	}
+	// После разгона заняты все воркеры
+	eventually(t, "full concurrency", func() bool { return running.Load() == workers })
+}
+
+func TestSlowStartLimit(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := &slowStart{start: start, ramp: time.Second}
+	for _, tc := range []struct {
+		elapsed time.Duration
+		allowed int
+		limited bool
+	}{
+		{0, 1, true},
+		{100 * time.Millisecond, 1, true},
+		{500 * time.Millisecond, 4, true},
+		{900 * time.Millisecond, 7, true},
+		{time.Second, 0, false},
+	} {
+		allowed, limited := s.limit(start.Add(tc.elapsed), 8)
+		if allowed != tc.allowed || limited != tc.limited {
+			t.Errorf("limit after %v = %d, %t, want %d, %t", tc.elapsed, allowed, limited, tc.allowed, tc.limited)
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	const perSecond, burst, jobs = 100, 2, 6 // вызов раз в 10 мс после всплеска из двух
+	var mu sync.Mutex
+	var calls []time.Time
+	pool := NewPool[int, int](
+		WithHandler(func(ctx context.Context, job int) (int, error) {
+			mu.Lock()
+			calls = append(calls, time.Now())
+			mu.Unlock()
+			return job, nil
+		}),
+		WithRateLimit(perSecond, burst),
+		WithInitialWorkers(3),
+	)
+	defer pool.Shutdown(context.Background())
+
+	for i := 0; i < jobs; i++ {
+		if err := pool.SendJob(i); err != nil {
+			t.Fatalf("SendJob: %v", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := pool.Wait(ctx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	sort.Slice(calls, func(i, j int) bool { return calls[i].Before(calls[j]) })
+	// Несколько воркеров вместе не обгоняют общий лимит: после всплеска вызовы идут с интервалом
+	interval := time.Second / perSecond
+	if span := calls[len(calls)-1].Sub(calls[0]); span < (jobs-burst)*interval*3/4 {
+		t.Errorf("%d calls took %v, want at least about %v", jobs, span, (jobs-burst)*interval)
+	}
+	if gap := calls[burst-1].Sub(calls[0]); gap > interval {
+		t.Errorf("burst of %d calls spread over %v, want them back to back", burst, gap)
+	}
+}
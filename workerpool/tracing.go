@@ -0,0 +1,63 @@
+package workerpool
+
+import (
+	"context"
+	"time"
+)
+
+// JobTracer получает события жизненного цикла задания для распределённой трассировки.
+// Реализация для OpenTelemetry находится в модуле workerpool/otel.
+type JobTracer interface {
+	// JobEnqueued вызывается при отправке задания с контекстом отправителя
+	// (context.Background для методов без ctx). Возвращённый контекст сопровождает задание.
+	JobEnqueued(ctx context.Context, job any) context.Context
+	// JobStarted вызывается перед выполнением; значения возвращённого контекста
+	// доступны обработчику через его ctx.
+	JobStarted(ctx context.Context, queueWait time.Duration) context.Context
+	// JobFinished вызывается, когда задание завершено, отклонено или отброшено.
+	JobFinished(ctx context.Context, processing time.Duration, err error)
+}
+
+// WithTracer передаёт события жизненного цикла заданий в tracer.
+func WithTracer(tracer JobTracer) Option {
+	return func(c *config) {
+		c.tracer = tracer
+	}
+}
+
+// traceEnqueued начинает трассировку задания, отправленного с контекстом ctx.
+func (p *Pool[T, R]) traceEnqueued(ctx context.Context, t *task[T, R]) {
+	if p.tracer != nil {
+		t.traceCtx = p.tracer.JobEnqueued(ctx, t.job)
+	}
+}
+
+// traceStarted отмечает начало выполнения и добавляет значения контекста трассировки в ctx.
+func (p *Pool[T, R]) traceStarted(ctx context.Context, t *task[T, R], start time.Time) context.Context {
+	if p.tracer == nil || t.traceCtx == nil {
+		return ctx
+	}
+	t.traceCtx = p.tracer.JobStarted(t.traceCtx, start.Sub(t.enqueued))
+	return valuesContext{Context: ctx, values: t.traceCtx}
+}
+
+// traceFinished завершает трассировку задания.
+func (p *Pool[T, R]) traceFinished(t *task[T, R], processing time.Duration, err error) {
+	if p.tracer != nil && t.traceCtx != nil {
+		p.tracer.JobFinished(t.traceCtx, processing, err)
+	}
+}
+
+// valuesContext берёт отмену и срок из Context, а значения — в первую очередь из values.
+// Так обработчик видит трассировку и значения отправителя, но не зависит от отмены его контекста.
+type valuesContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c valuesContext) Value(key any) any {
+	if v := c.values.Value(key); v != nil {
+		return v
+	}
+	return c.Context.Value(key)
+}
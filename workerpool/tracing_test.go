@@ -0,0 +1,93 @@
+package workerpool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+type traceKey struct{}
+
+// recordingTracer записывает события трассировки и помечает контекст задания этапами.
+type recordingTracer struct {
+	mu       sync.Mutex
+	waits    []time.Duration
+	finished []error
+}
+
+func (r *recordingTracer) JobEnqueued(ctx context.Context, job any) context.Context {
+	return context.WithValue(ctx, traceKey{}, "enqueued")
+}
+
+func (r *recordingTracer) JobStarted(ctx context.Context, queueWait time.Duration) context.Context {
+	r.mu.Lock()
+	r.waits = append(r.waits, queueWait)
+	r.mu.Unlock()
+	return context.WithValue(ctx, traceKey{}, ctx.Value(traceKey{}).(string)+",started")
+}
+
+func (r *recordingTracer) JobFinished(ctx context.Context, processing time.Duration, err error) {
+	r.mu.Lock()
+	r.finished = append(r.finished, err)
+	r.mu.Unlock()
+}
+
+func TestTracer(t *testing.T) {
+	type senderKey struct{}
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	tracer := &recordingTracer{}
+	boom := errors.New("boom")
+	pool := workerpool.NewPool[string, string](
+		workerpool.WithHandler(func(ctx context.Context, job string) (string, error) {
+			if job == "fail" {
+				return "", boom
+			}
+			// Обработчик видит и значения трассировки, и значения отправителя
+			trace, _ := ctx.Value(traceKey{}).(string)
+			sender, _ := ctx.Value(senderKey{}).(string)
+			return trace + "/" + sender, nil
+		}),
+		workerpool.WithClock(clock),
+		workerpool.WithTracer(tracer),
+	)
+	defer pool.Shutdown(context.Background())
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), senderKey{}, "request-1"))
+	ok, err := pool.SubmitContext(ctx, "ok")
+	if err != nil {
+		t.Fatalf("SubmitContext: %v", err)
+	}
+	failed, err := pool.Submit("fail")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	// Ожидание в очереди считается по часам пула
+	clock.Advance(3 * time.Second)
+	pool.AddWorker()
+
+	for _, f := range []*workerpool.Future[string]{ok, failed} {
+		select {
+		case <-f.Done():
+		case <-time.After(2 * time.Second):
+			t.Fatalf("job %d did not finish", f.ID())
+		}
+	}
+	cancel()
+	if got := ok.Result(); got != "enqueued,started/request-1" {
+		t.Errorf("handler saw %q, want enqueued,started/request-1", got)
+	}
+
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	if len(tracer.waits) != 2 || tracer.waits[0] != 3*time.Second || tracer.waits[1] != 3*time.Second {
+		t.Errorf("queue waits = %v, want [3s 3s]", tracer.waits)
+	}
+	if len(tracer.finished) != 2 || tracer.finished[0] != nil || !errors.Is(tracer.finished[1], boom) {
+		t.Errorf("finished errors = %v, want [<nil> boom]", tracer.finished)
+	}
+}
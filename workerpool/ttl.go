@@ -0,0 +1,79 @@
+package workerpool
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrJobExpired — задание пролежало в очереди дольше своего TTL и не выполнялось.
+var ErrJobExpired = errors.New("job expired in queue")
+
+// ExpiredHandler получает задание, отброшенное по TTL, и время, которое оно прождало в очереди.
+type ExpiredHandler func(job any, waited time.Duration)
+
+// WithJobTTL задаёт срок жизни заданий в очереди: задание, прождавшее дольше ttl к моменту,
+// когда его взял воркер, не выполняется, а его Future завершается с ErrJobExpired.
+// Полезно для заданий, теряющих смысл с опозданием, например уведомлений.
+// Отдельным заданиям срок задаётся через SubmitWithTTL.
+func WithJobTTL(ttl time.Duration) Option {
+	return func(c *config) {
+		c.jobTTL = ttl
+	}
+}
+
+// WithExpiredHandler задаёт функцию, вызываемую для каждого задания, отброшенного по TTL.
+// Функция вызывается в горутине воркера и должна быстро возвращать управление.
+func WithExpiredHandler(h ExpiredHandler) Option {
+	return func(c *config) {
+		c.expiredHandler = h
+	}
+}
+
+// SubmitWithTTL — то же, что Submit, но задание отбрасывается, если пролежит в очереди
+// дольше ttl. Срок задания заменяет общий срок WithJobTTL.
+func (p *Pool[T, R]) SubmitWithTTL(job T, ttl time.Duration) (*Future[R], error) {
+	t := &task[T, R]{job: job, cost: 1, ttl: ttl, future: newFuture[R]()}
+	if err := p.enqueue(t); err != nil {
+		return nil, err
+	}
+	return t.future, nil
+}
+
+// expire отбрасывает задание, срок жизни которого в очереди истёк к моменту now.
+// Возвращает false, если срок не истёк или не задан.
+func (p *Pool[T, R]) expire(t *task[T, R], now time.Time) bool {
+	ttl := t.ttl
+	if ttl == 0 {
+		ttl = p.jobTTL
+	}
+	waited := now.Sub(t.enqueued)
+	if ttl <= 0 || waited <= ttl {
+		return false
+	}
+
+	if p.breaker != nil {
+		// Задание не выполнялось, поэтому на состояние цепи не влияет
+		p.breaker.cancel(t.probe)
+	}
+	p.logger.Warn("job expired in queue", "job", t.job, "id", t.id, "waited", waited)
+	p.traceFinished(t, 0, ErrJobExpired)
+	p.emit(EventDropped, t, 0, ErrJobExpired)
+	if p.expiredHandler != nil {
+		p.expiredHandler(t.job, waited)
+	}
+	t.finish(*new(R), ErrJobExpired)
+	p.publishResult(t, *new(R), ErrJobExpired)
+	return true
+}
+
+// dropExpired убирает из пакета задания с истёкшим сроком жизни.
+func (p *Pool[T, R]) dropExpired(tasks []*task[T, R]) []*task[T, R] {
+	now := p.clock.Now()
+	live := tasks[:0:0]
+	for _, t := range tasks {
+		if !p.expire(t, now) {
+			live = append(live, t)
+		}
+	}
+	return live
+}
@@ -0,0 +1,65 @@
+package workerpool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestJobTTL(t *testing.T) {
+	clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	var (
+		mu      sync.Mutex
+		expired = map[any]time.Duration{}
+	)
+	pool := workerpool.NewPool[string, string](
+		workerpool.WithHandler(workerpooltest.Echo[string]),
+		workerpool.WithClock(clock),
+		workerpool.WithJobTTL(time.Minute),
+		workerpool.WithExpiredHandler(func(job any, waited time.Duration) {
+			mu.Lock()
+			expired[job] = waited
+			mu.Unlock()
+		}),
+	)
+	defer pool.Shutdown(context.Background())
+
+	// Воркеров ещё нет, поэтому задания ждут в очереди, пока идут часы пула
+	stale, err := pool.Submit("stale")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	patient, err := pool.SubmitWithTTL("patient", time.Hour)
+	if err != nil {
+		t.Fatalf("SubmitWithTTL: %v", err)
+	}
+	clock.Advance(30 * time.Second)
+	fresh, err := pool.Submit("fresh")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	clock.Advance(40 * time.Second)
+	pool.AddWorker()
+
+	// Общий срок отбрасывает только прождавшее дольше минуты, а свой срок задания его заменяет
+	advanceUntil(t, clock, 0, "stale job finished", isDone(stale))
+	if err := stale.Err(); !errors.Is(err, workerpool.ErrJobExpired) {
+		t.Errorf("job queued for 70s = %v, want ErrJobExpired", err)
+	}
+	for _, future := range []*workerpool.Future[string]{fresh, patient} {
+		advanceUntil(t, clock, 0, "job finished", isDone(future))
+		if err := future.Err(); err != nil {
+			t.Errorf("job %d: %v, want it processed", future.ID(), err)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(expired) != 1 || expired["stale"] != 70*time.Second {
+		t.Errorf("expired handler got %v, want stale after 1m10s", expired)
+	}
+}
@@ -0,0 +1,118 @@
+package workerpool
+
+import (
+	"time"
+)
+
+// StallAction — что делает сторож WithWatchdog с зависшим заданием.
+type StallAction int
+
+const (
+	// StallNotify только сообщает о зависании через OnStall и журнал.
+	StallNotify StallAction = iota
+	// StallCancel отменяет контекст зависшего задания.
+	StallCancel
+	// StallReplace отключает воркера, как RemoveWorker, и запускает вместо него нового,
+	// поэтому пул не теряет воркера, даже если обработчик не реагирует на отмену контекста.
+	StallReplace
+)
+
+// StalledJob — сведения о задании, выполняющемся дольше порога WithWatchdog.
+type StalledJob struct {
+	WorkerID int
+	JobID    JobID
+	Job      any
+	Running  time.Duration // сколько задание уже выполняется
+}
+
+// WatchdogConfig задаёт сторожа зависших заданий.
+type WatchdogConfig struct {
+	// Threshold — дольше этого задание считается зависшим
+	Threshold time.Duration
+
+	// Action — что сделать с зависшим заданием (по умолчанию StallNotify)
+	Action StallAction
+
+	// OnStall вызывается один раз для каждого зависшего задания (nil — только запись в журнал)
+	OnStall func(StalledJob)
+
+	// Interval — период проверки (по умолчанию четверть Threshold)
+	Interval time.Duration
+}
+
+// WithWatchdog включает сторожа, который периодически проверяет, сколько каждый воркер
+// занят текущим заданием, и при превышении Threshold сообщает о зависании и выполняет Action.
+// Так обработчик, повисший на сетевом вызове, не отнимает воркера незаметно.
+func WithWatchdog(cfg WatchdogConfig) Option {
+	return func(c *config) {
+		if cfg.Threshold <= 0 {
+			panic("workerpool: WithWatchdog requires a positive threshold")
+		}
+		if cfg.Interval <= 0 {
+			cfg.Interval = cfg.Threshold / 4
+		}
+		c.watchdog = &cfg
+	}
+}
+
+// runWatchdog проверяет воркеров до остановки пула.
+func (p *Pool[T, R]) runWatchdog() {
+	timer := p.clock.NewTimer(p.watchdog.Interval)
+	defer timer.Stop()
+
+	// reported — последнее задание каждого воркера, о котором уже сообщено
+	reported := make(map[int]JobID)
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-timer.C():
+			p.checkStalls(reported)
+			timer.Reset(p.watchdog.Interval)
+		}
+	}
+}
+
+// checkStalls выполняет одну проверку сторожа.
+func (p *Pool[T, R]) checkStalls(reported map[int]JobID) {
+	cfg := p.watchdog
+	var stalled []StalledJob
+	now := p.clock.Now()
+
+	p.mu.Lock()
+	for id := range reported {
+		if _, exists := p.workers[id]; !exists {
+			delete(reported, id)
+		}
+	}
+	for id, worker := range p.workers {
+		if !worker.working || worker.removed || reported[id] == worker.currentID {
+			continue
+		}
+		if age := now.Sub(worker.jobStarted); age > cfg.Threshold {
+			reported[id] = worker.currentID
+			stalled = append(stalled, StalledJob{WorkerID: id, JobID: worker.currentID, Job: worker.currentJob, Running: age})
+			switch cfg.Action {
+			case StallCancel:
+				if t, exists := p.tasks[worker.currentID]; exists {
+					p.cancelLocked(t)
+				}
+			case StallReplace:
+				worker.Cancel()
+				worker.removed = true
+				p.workers[id] = worker
+			}
+		}
+	}
+	p.mu.Unlock()
+
+	for _, s := range stalled {
+		p.logger.Warn("job stalled", "worker", s.WorkerID, "job", s.JobID, "running", s.Running)
+		if cfg.OnStall != nil {
+			cfg.OnStall(s)
+		}
+		if cfg.Action == StallReplace {
+			p.AddWorker()
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package workerpool_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+	"github.com/Mukam21/go-worker-pool/workerpool/workerpooltest"
+)
+
+func TestWatchdog(t *testing.T) {
+	const threshold = 10 * time.Second
+	// run запускает зависающее задание "stuck" под сторожем с действием action и ждёт
+	// первого сообщения о зависании. Задание "stuck" не реагирует на отмену контекста
+	// с ignoreCtx и завершается только по release.
+	run := func(t *testing.T, action workerpool.StallAction, ignoreCtx bool) (*workerpool.Pool[string, string], *workerpooltest.Clock, *workerpool.Future[string], []workerpool.StalledJob) {
+		clock := workerpooltest.NewClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+		release := make(chan struct{})
+		var (
+			mu      sync.Mutex
+			stalled []workerpool.StalledJob
+		)
+		pool := workerpool.NewPool[string, string](
+			workerpool.WithHandler(func(ctx context.Context, job string) (string, error) {
+				if job != "stuck" {
+					return job, nil
+				}
+				if ignoreCtx {
+					<-release
+					return job, nil
+				}
+				select {
+				case <-release:
+					return job, nil
+				case <-ctx.Done():
+					return "", ctx.Err()
+				}
+			}),
+			workerpool.WithClock(clock),
+			workerpool.WithInitialWorkers(1),
+			workerpool.WithWatchdog(workerpool.WatchdogConfig{
+				Threshold: threshold,
+				Action:    action,
+				OnStall: func(s workerpool.StalledJob) {
+					mu.Lock()
+					stalled = append(stalled, s)
+					mu.Unlock()
+				},
+			}),
+		)
+		t.Cleanup(func() { pool.Shutdown(context.Background()) })
+		t.Cleanup(func() { close(release) })
+
+		stuck, err := pool.Submit("stuck")
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		advanceUntil(t, clock, 0, "job started", func() bool { return pool.Stats().BusyWorkers == 1 })
+		elapsed := advanceUntil(t, clock, time.Second, "stall reported", func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(stalled) > 0
+		})
+		if elapsed <= threshold {
+			t.Errorf("stall reported after %v, want later than the %v threshold", elapsed, threshold)
+		}
+		// О каждом задании сообщается один раз
+		for i := 0; i < 5; i++ {
+			clock.Advance(threshold)
+			time.Sleep(time.Millisecond)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return pool, clock, stuck, append([]workerpool.StalledJob(nil), stalled...)
+	}
+
+	t.Run("notify", func(t *testing.T) {
+		_, _, stuck, stalled := run(t, workerpool.StallNotify, false)
+		if len(stalled) != 1 || stalled[0].JobID != stuck.ID() || stalled[0].Job != "stuck" || stalled[0].Running <= threshold {
+			t.Errorf("OnStall got %+v, want one report of job %d running over %v", stalled, stuck.ID(), threshold)
+		}
+		select {
+		case <-stuck.Done():
+			t.Error("StallNotify interrupted the job")
+		default:
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		_, clock, stuck, stalled := run(t, workerpool.StallCancel, false)
+		if len(stalled) != 1 {
+			t.Errorf("OnStall got %+v, want one report", stalled)
+		}
+		advanceUntil(t, clock, 0, "stuck job cancelled", isDone(stuck))
+		if err := stuck.Err(); !errors.Is(err, context.Canceled) {
+			t.Errorf("stalled job = %v, want context.Canceled", err)
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		pool, clock, stuck, stalled := run(t, workerpool.StallReplace, true)
+		if len(stalled) != 1 {
+			t.Errorf("OnStall got %+v, want one report", stalled)
+		}
+		// Обработчик не отпускает воркера, но пул запустил нового вместо него
+		next, err := pool.Submit("next")
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		advanceUntil(t, clock, 0, "job on the replacement worker", isDone(next))
+		if err := next.Err(); err != nil {
+			t.Errorf("job on the replacement worker: %v", err)
+		}
+		if isDone(stuck)() {
+			t.Error("stuck job finished although its handler ignores cancellation")
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package workerpool
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// WithConcurrencyUnits задаёт ёмкость пула в условных единицах: одновременно выполняются
+// задания с суммарным весом не больше units. Вес задаётся в SubmitWeighted, обычные задания
+// весят 1. Тяжёлое задание (например, перекодирование видео) занимает несколько единиц
+// и тем самым уменьшает параллелизм, даже если свободные воркеры есть.
+// Пока заданию не хватает единиц, взявший его воркер ждёт; задания получают единицы
+// в порядке очереди, поэтому тяжёлые не голодают за потоком лёгких.
+// В пакетном режиме (WithBatchHandler) веса не учитываются.
+func WithConcurrencyUnits(units int) Option {
+	return func(c *config) {
+		if units > 0 {
+			c.units = &unitSemaphore{size: units}
+		}
+	}
+}
+
+// SubmitWeighted — то же, что Submit, но задание занимает weight единиц WithConcurrencyUnits
+// на время выполнения. Вес больше ёмкости пула отклоняется с ошибкой.
+// Без WithConcurrencyUnits вес не влияет на выполнение.
+func (p *Pool[T, R]) SubmitWeighted(job T, weight int) (*Future[R], error) {
+	if weight < 1 {
+		return nil, fmt.Errorf("job weight must be positive, got %d", weight)
+	}
+	if p.units != nil && weight > p.units.size {
+		return nil, fmt.Errorf("job weight %d exceeds concurrency units %d", weight, p.units.size)
+	}
+	t := &task[T, R]{job: job, cost: 1, weight: weight, future: newFuture[R]()}
+	if err := p.enqueue(t); err != nil {
+		return nil, err
+	}
+	return t.future, nil
+}
+
+// acquireUnits занимает единицы под задание t. Возвращает ошибку ctx, если тот отменён раньше.
+func (p *Pool[T, R]) acquireUnits(ctx context.Context, t *task[T, R]) error {
+	if p.units == nil {
+		return nil
+	}
+	return p.units.acquire(ctx, t.units())
+}
+
+// releaseUnits возвращает единицы, занятые acquireUnits.
+func (p *Pool[T, R]) releaseUnits(t *task[T, R]) {
+	if p.units != nil {
+		p.units.release(t.units())
+	}
+}
+
+// units возвращает вес задания: без SubmitWeighted задание весит 1.
+func (t *task[T, R]) units() int {
+	if t.weight < 1 {
+		return 1
+	}
+	return t.weight
+}
+
+// unitSemaphore — взвешенный семафор с очередью ожидающих в порядке прихода.
+type unitSemaphore struct {
+	size int
+
+	mu      sync.Mutex
+	used    int
+	waiters list.List // *unitWaiter
+}
+
+type unitWaiter struct {
+	n     int
+	ready chan struct{} // закрывается, когда единицы выданы
+}
+
+func (s *unitSemaphore) acquire(ctx context.Context, n int) error {
+	s.mu.Lock()
+	if s.used+n <= s.size && s.waiters.Len() == 0 {
+		s.used += n
+		s.mu.Unlock()
+		return nil
+	}
+	w := &unitWaiter{n: n, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		select {
+		case <-w.ready:
+			// Единицы выданы одновременно с отменой — возвращаем их
+			s.used -= n
+		default:
+			s.waiters.Remove(elem)
+		}
+		// Ушедший ожидающий мог задерживать следующих
+		s.grantLocked()
+		return ctx.Err()
+	}
+}
+
+func (s *unitSemaphore) release(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.used -= n
+	s.grantLocked()
+}
+
+// grantLocked выдаёт единицы ожидающим по порядку, пока их хватает. Вызывается под s.mu.
+func (s *unitSemaphore) grantLocked() {
+	for elem := s.waiters.Front(); elem != nil; elem = s.waiters.Front() {
+		w := elem.Value.(*unitWaiter)
+		if s.used+w.n > s.size {
+			return
+		}
+		s.used += w.n
+		s.waiters.Remove(elem)
+		close(w.ready)
+	}
+}
@@ -0,0 +1,58 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrencyUnits(t *testing.T) {
+	const units = 4
+	var (
+		mu            sync.Mutex
+		load, maxLoad int
+	)
+	// Задание — его собственный вес
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, weight int) (int, error) {
+		mu.Lock()
+		load += weight
+		maxLoad = max(maxLoad, load)
+		mu.Unlock()
+		time.Sleep(2 * time.Millisecond)
+		mu.Lock()
+		load -= weight
+		mu.Unlock()
+		return weight, nil
+	}), WithConcurrencyUnits(units), WithInitialWorkers(4))
+	defer pool.Shutdown(context.Background())
+
+	for _, weight := range []int{0, units + 1} {
+		if _, err := pool.SubmitWeighted(weight, weight); err == nil {
+			t.Errorf("SubmitWeighted with weight %d succeeded", weight)
+		}
+	}
+	var futures []*Future[int]
+	for _, weight := range []int{3, 1, 2, 1, 4, 1, 1, 2, 3} {
+		future, err := pool.SubmitWeighted(weight, weight)
+		if err != nil {
+			t.Fatalf("SubmitWeighted(%d): %v", weight, err)
+		}
+		futures = append(futures, future)
+	}
+	for _, future := range futures {
+		if _, err := await(t, future); err != nil {
+			t.Fatalf("job %d: %v", future.ID(), err)
+		}
+	}
+
+	// Суммарный вес выполняющихся заданий не превышает ёмкость, хотя воркеров хватает на большее
+	mu.Lock()
+	defer mu.Unlock()
+	if maxLoad > units {
+		t.Errorf("running jobs weighed %d units at once, want at most %d", maxLoad, units)
+	}
+	if maxLoad < 2 {
+		t.Errorf("running jobs weighed at most %d units, want light jobs to share the pool", maxLoad)
+	}
+}
@@ -0,0 +1,51 @@
+package workerpool
+
+import (
+	"context"
+)
+
+// WithWorkerInit задаёт функцию, которую каждый воркер вызывает один раз при запуске,
+// чтобы создать долгоживущие ресурсы — соединение с БД, клиент gRPC, буферы.
+// Возвращённое состояние доступно обработчику через WorkerState(ctx).
+// Если init возвращает ошибку, воркер пишет её в лог и завершается, не взяв ни одного задания.
+func WithWorkerInit(init func(workerID int) (any, error)) Option {
+	return func(c *config) {
+		c.workerInit = init
+	}
+}
+
+// WithWorkerCleanup задаёт функцию, которую воркер вызывает при завершении
+// с состоянием из WithWorkerInit, чтобы освободить ресурсы.
+func WithWorkerCleanup(cleanup func(workerID int, state any)) Option {
+	return func(c *config) {
+		c.workerCleanup = cleanup
+	}
+}
+
+type workerStateKey struct{}
+
+// WorkerState возвращает состояние воркера, созданное WithWorkerInit,
+// из контекста, переданного обработчику. Без WithWorkerInit возвращает nil.
+func WorkerState(ctx context.Context) any {
+	return ctx.Value(workerStateKey{})
+}
+
+// initWorker готовит состояние воркера и возвращает контекст для его заданий.
+func (p *Pool[T, R]) initWorker(ctx context.Context, id int) (context.Context, bool) {
+	if p.workerInit == nil {
+		return ctx, true
+	}
+	state, err := p.workerInit(id)
+	if err != nil {
+		p.logger.Error("worker init failed", "worker", id, "error", err)
+		return ctx, false
+	}
+	return context.WithValue(ctx, workerStateKey{}, state), true
+}
+
+// cleanupWorker освобождает состояние воркера, созданное initWorker.
+func (p *Pool[T, R]) cleanupWorker(ctx context.Context, id int) {
+	if p.workerCleanup != nil {
+		p.workerCleanup(id, WorkerState(ctx))
+	}
+}
@@ -0,0 +1,74 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWorkerInitAndCleanup(t *testing.T) {
+	var mu sync.Mutex
+	cleaned := map[int]any{}
+	pool := NewPool[int, string](
+		WithHandler(func(ctx context.Context, job int) (string, error) {
+			state, _ := WorkerState(ctx).(string)
+			return state, nil
+		}),
+		WithWorkerInit(func(workerID int) (any, error) {
+			return fmt.Sprintf("conn-%d", workerID), nil
+		}),
+		WithWorkerCleanup(func(workerID int, state any) {
+			mu.Lock()
+			cleaned[workerID] = state
+			mu.Unlock()
+		}),
+	)
+	id := pool.AddWorker()
+
+	future, err := pool.Submit(1)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if got, err := await(t, future); err != nil || got != fmt.Sprintf("conn-%d", id) {
+		t.Fatalf("WorkerState in handler = %q, %v; want conn-%d", got, err, id)
+	}
+
+	// Воркер освобождает при завершении то же состояние, что создал при запуске
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if state, ok := cleaned[id]; !ok || state != fmt.Sprintf("conn-%d", id) {
+		t.Fatalf("cleanup of worker %d got %v (called %t), want conn-%d", id, state, ok, id)
+	}
+}
+
+func TestWorkerInitFailure(t *testing.T) {
+	var cleanups atomic.Int32
+	pool := NewPool[int, int](
+		WithHandler(echo[int]),
+		WithWorkerInit(func(workerID int) (any, error) {
+			return nil, errors.New("no connection")
+		}),
+		WithWorkerCleanup(func(workerID int, state any) { cleanups.Add(1) }),
+	)
+	defer pool.ShutdownNow()
+	if err := pool.SendJob(1); err != nil {
+		t.Fatalf("SendJob: %v", err)
+	}
+	pool.AddWorker()
+
+	// Воркер с неудачным init завершается, не взяв задание, и cleanup для него не вызывается
+	eventually(t, "worker exit", func() bool { return pool.Stats().Workers == 0 })
+	if n := pool.QueueLen(); n != 1 {
+		t.Fatalf("QueueLen = %d, want the job left queued", n)
+	}
+	pool.ShutdownNow()
+	if n := cleanups.Load(); n != 0 {
+		t.Fatalf("cleanup called %d times for a worker whose init failed", n)
+	}
+}
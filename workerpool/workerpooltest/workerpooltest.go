@@ -0,0 +1,196 @@
+// Package workerpooltest содержит помощники для детерминированных тестов кода,
+// использующего workerpool: управляемые вручную часы и синхронный пул.
+//
+//	clock := workerpooltest.NewClock(time.Now())
+//	pool := workerpool.NewPool[string, string](
+//		workerpool.WithHandler(handle),
+//		workerpool.WithRetry(workerpool.RetryPolicy{MaxAttempts: 3}),
+//		workerpool.WithClock(clock),
+//		workerpool.WithInitialWorkers(1),
+//	)
+//	future, _ := pool.Submit("job")
+//	clock.BlockUntil(1) // воркер ждёт паузы перед повтором
+//	clock.Advance(time.Second)
+package workerpooltest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Mukam21/go-worker-pool/workerpool"
+)
+
+// NewSynchronousPool создаёт пул в синхронном режиме: каждое задание выполняется
+// прямо в вызове SendJob или Submit. Остальные опции применяются как обычно.
+func NewSynchronousPool[T, R any](handler workerpool.Handler[T, R], opts ...workerpool.Option) *workerpool.Pool[T, R] {
+	opts = append([]workerpool.Option{workerpool.WithHandler(handler), workerpool.WithSynchronousMode()}, opts...)
+	return workerpool.NewPool[T, R](opts...)
+}
+
+// Echo — обработчик без задержки, возвращающий задание как результат.
+// Подходит для примеров и тестов, которым важен путь задания через пул, а не его обработка.
+func Echo[T any](ctx context.Context, job T) (T, error) {
+	return job, nil
+}
+
+// Clock — часы, которые идут только при вызове Advance или Set. Реализует workerpool.Clock.
+type Clock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	timers  []*timer
+	nextSeq uint64
+}
+
+// NewClock создаёт часы, показывающие время now.
+func NewClock(now time.Time) *Clock {
+	c := &Clock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now возвращает текущее время часов.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// NewTimer создаёт таймер, срабатывающий, когда часы продвинутся на d.
+func (c *Clock) NewTimer(d time.Duration) workerpool.Timer {
+	t := &timer{clock: c, ch: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// AfterFunc вызывает f в отдельной горутине, когда часы продвинутся на d.
+func (c *Clock) AfterFunc(d time.Duration, f func()) workerpool.Timer {
+	t := &timer{clock: c, fn: f}
+	t.Reset(d)
+	return t
+}
+
+// Advance продвигает часы на d, по порядку срабатывая все таймеры, срок которых наступил.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+	c.Set(target)
+}
+
+// Set переводит часы на момент at. Перевод назад не запускает таймеров.
+func (c *Clock) Set(at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.timers) > 0 && !c.timers[0].when.After(at) {
+		t := c.timers[0]
+		c.timers = c.timers[1:]
+		t.active = false
+		if t.when.After(c.now) {
+			c.now = t.when
+		}
+		t.fire(c.now)
+	}
+	c.now = at
+	c.cond.Broadcast()
+}
+
+// Timers возвращает число ожидающих таймеров.
+func (c *Clock) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+// BlockUntil ждёт, пока ожидающих таймеров станет не меньше n. Позволяет продвинуть часы
+// только после того, как воркер действительно начал ждать паузу, таймаут или простой.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// addLocked ставит таймер в отсортированный по сроку список. Вызывается под c.mu.
+func (c *Clock) addLocked(t *timer) {
+	c.nextSeq++
+	t.seq = c.nextSeq
+	t.active = true
+	i := sort.Search(len(c.timers), func(i int) bool {
+		other := c.timers[i]
+		return other.when.After(t.when) || other.when.Equal(t.when) && other.seq > t.seq
+	})
+	c.timers = append(c.timers, nil)
+	copy(c.timers[i+1:], c.timers[i:])
+	c.timers[i] = t
+	c.cond.Broadcast()
+}
+
+// removeLocked убирает таймер из списка. Вызывается под c.mu.
+func (c *Clock) removeLocked(t *timer) bool {
+	if !t.active {
+		return false
+	}
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			break
+		}
+	}
+	t.active = false
+	return true
+}
+
+// timer — таймер Clock. Поля, кроме ch и fn, защищены clock.mu.
+type timer struct {
+	clock  *Clock
+	ch     chan time.Time
+	fn     func()
+	when   time.Time
+	seq    uint64
+	active bool
+}
+
+func (t *timer) C() <-chan time.Time { return t.ch }
+
+func (t *timer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	return t.clock.removeLocked(t)
+}
+
+func (t *timer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wasActive := c.removeLocked(t)
+	t.when = c.now.Add(d)
+	if d <= 0 {
+		// Срок уже наступил — таймер срабатывает сразу, как у time.Timer
+		t.fire(c.now)
+		return wasActive
+	}
+	c.addLocked(t)
+	return wasActive
+}
+
+// fire срабатывает таймер в момент now. Вызывается под clock.mu.
+func (t *timer) fire(now time.Time) {
+	if t.fn != nil {
+		go t.fn()
+		return
+	}
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
@@ -0,0 +1,143 @@
+package workerpool
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// WorkerStatus описывает, чем занят воркер.
+type WorkerStatus int
+
+const (
+	WorkerIdle     WorkerStatus = iota // ждёт задания
+	WorkerBusy                         // обрабатывает задание
+	WorkerStopping                     // отключён и завершается
+)
+
+func (s WorkerStatus) String() string {
+	switch s {
+	case WorkerIdle:
+		return "idle"
+	case WorkerBusy:
+		return "busy"
+	case WorkerStopping:
+		return "stopping"
+	default:
+		return fmt.Sprintf("WorkerStatus(%d)", int(s))
+	}
+}
+
+// WorkerInfo — сведения о воркере для мониторинга.
+// BusyTime вместе с Uptime даёт загрузку воркера.
+type WorkerInfo struct {
+	ID           int
+	Status       WorkerStatus
+	CurrentJob   any   // задание в работе (nil, если воркер простаивает)
+	CurrentJobID JobID // ID задания в работе (0, если воркер простаивает)
+	Started      time.Time
+	Uptime       time.Duration
+	BusyTime     time.Duration
+	Processed    uint64 // число обработанных заданий
+}
+
+// startWork извлекает задание из очереди для воркера id и отмечает его занятым.
+// Возвращает nil, если задание, на которое указывал жетон, уже убрали из очереди.
+func (p *Pool[T, R]) startWork(id int) *task[T, R] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.paused {
+		// Пауза наступила, пока воркер ждал жетона: задание остаётся в очереди до Resume
+		p.heldTokens++
+		return nil
+	}
+	t := p.queue.pop()
+	if t == nil {
+		return nil
+	}
+	if !p.dedupInFlight && !t.singleFlight {
+		p.forgetKeyLocked(t)
+	}
+	p.signalSpaceLocked()
+	p.markWorkingLocked(id, t)
+	return t
+}
+
+// markWorkingLocked отмечает воркера id занятым заданием t. Вызывается под p.mu.
+func (p *Pool[T, R]) markWorkingLocked(id int, t *task[T, R]) {
+	if worker, exists := p.workers[id]; exists {
+		worker.working = true
+		worker.currentJob = t.job
+		worker.currentID = t.id
+		worker.jobStarted = p.clock.Now()
+		p.workers[id] = worker
+	}
+}
+
+// finishWork отмечает завершение jobs заданий (больше одного — в пакетном режиме)
+// и добавляет к счётчику воркера потраченное время.
+func (p *Pool[T, R]) finishWork(id, jobs int, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if worker, exists := p.workers[id]; exists {
+		worker.busyTime += d
+		worker.working = false
+		worker.currentJob = nil
+		worker.currentID = 0
+		worker.processed += uint64(jobs)
+		worker.lastActive = p.clock.Now()
+		p.workers[id] = worker
+	}
+	if p.workStealing && p.pinnedCount > 0 {
+		// Освободившийся воркер проверит личные очереди: свою или занятых соседей
+		p.wakePinnedLocked(id)
+	}
+}
+
+// Workers возвращает сведения о всех воркерах пула, отсортированные по ID.
+func (p *Pool[T, R]) Workers() []WorkerInfo {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	infos := make([]WorkerInfo, 0, len(p.workers))
+	for _, worker := range p.workers {
+		infos = append(infos, worker.info(now))
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+// WorkerStats возвращает сведения об одном воркере.
+func (p *Pool[T, R]) WorkerStats(id int) (WorkerInfo, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	worker, exists := p.workers[id]
+	if !exists {
+		return WorkerInfo{}, false
+	}
+	return worker.info(p.clock.Now()), true
+}
+
+func (w Worker) info(now time.Time) WorkerInfo {
+	status := WorkerIdle
+	switch {
+	case w.removed:
+		status = WorkerStopping
+	case w.working:
+		status = WorkerBusy
+	}
+	return WorkerInfo{
+		ID:           w.ID,
+		Status:       status,
+		CurrentJob:   w.currentJob,
+		CurrentJobID: w.currentID,
+		Started:      w.started,
+		Uptime:       now.Sub(w.started),
+		BusyTime:     w.busyTime,
+		Processed:    w.processed,
+	}
+}
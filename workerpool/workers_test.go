@@ -0,0 +1,87 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWorkerBusyTime(t *testing.T) {
+	const work = 20 * time.Millisecond
+	pool := NewPool[int, int](WithHandler(func(ctx context.Context, job int) (int, error) {
+		time.Sleep(work)
+		return job, nil
+	}))
+	defer pool.Shutdown(context.Background())
+	id := pool.AddWorker()
+
+	before, ok := pool.WorkerStats(id)
+	if !ok {
+		t.Fatalf("WorkerStats(%d) not found", id)
+	}
+	for i := 0; i < 2; i++ {
+		future, err := pool.Submit(i)
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		await(t, future)
+	}
+	eventually(t, "busy time recorded", func() bool {
+		info, _ := pool.WorkerStats(id)
+		return info.Processed == 2
+	})
+
+	after, _ := pool.WorkerStats(id)
+	busy := after.BusyTime - before.BusyTime
+	if busy < 2*work || busy > 2*work+time.Second {
+		t.Errorf("busy time grew by %v, want about %v", busy, 2*work)
+	}
+	if after.Uptime < busy {
+		t.Errorf("uptime %v is less than busy time %v", after.Uptime, busy)
+	}
+	if after.Status != WorkerIdle {
+		t.Errorf("status = %v, want idle", after.Status)
+	}
+}
+
+func TestWorkerStatusAndRemoveWorkerWait(t *testing.T) {
+	started := make(chan struct{})
+	pool := NewPool[string, string](WithHandler(func(ctx context.Context, job string) (string, error) {
+		close(started)
+		<-ctx.Done()
+		return "", ctx.Err()
+	}))
+	defer pool.Shutdown(context.Background())
+	id := pool.AddWorker()
+
+	eventually(t, "worker idle", func() bool {
+		info, ok := pool.WorkerStats(id)
+		return ok && info.Status == WorkerIdle
+	})
+	future, err := pool.Submit("report")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	<-started
+	info, _ := pool.WorkerStats(id)
+	if info.Status != WorkerBusy || info.CurrentJob != "report" || info.CurrentJobID != future.ID() {
+		t.Fatalf("busy worker = %+v, want busy with job %d", info, future.ID())
+	}
+
+	// RemoveWorkerWait прерывает текущее задание и возвращается, когда горутина воркера завершилась
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if err := pool.RemoveWorkerWait(ctx, id); err != nil {
+		t.Fatalf("RemoveWorkerWait: %v", err)
+	}
+	if _, err := await(t, future); !errors.Is(err, context.Canceled) {
+		t.Errorf("interrupted job error = %v, want context.Canceled", err)
+	}
+	if _, ok := pool.WorkerStats(id); ok {
+		t.Errorf("worker %d still listed after RemoveWorkerWait", id)
+	}
+	if err := pool.RemoveWorkerWait(ctx, id); !errors.Is(err, ErrWorkerNotFound) {
+		t.Errorf("RemoveWorkerWait of a removed worker = %v, want ErrWorkerNotFound", err)
+	}
+}